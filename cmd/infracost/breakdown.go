@@ -23,8 +23,10 @@ func breakdownCmd(ctx *config.RunContext) *cobra.Command {
       infracost breakdown --path plan.json`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
-				return err
+			if !ctx.Config.IsOfflinePricing() {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
 			}
 
 			err := loadRunFlags(ctx.Config, cmd)
@@ -47,8 +49,11 @@ func breakdownCmd(ctx *config.RunContext) *cobra.Command {
 	addRunFlags(cmd)
 
 	cmd.Flags().String("out-file", "", "Save output to a file, helpful with format flag")
+	cmd.Flags().StringArray("policy-path", nil, "Path to Infracost policy files, glob patterns need quotes (experimental)")
 	cmd.Flags().Bool("terraform-use-state", false, "Use Terraform state instead of generating a plan. Applicable with --terraform-force-cli")
-	newEnumFlag(cmd, "format", "table", "Output format", []string{"json", "table", "html"})
+	newEnumFlag(cmd, "format", "table", "Output format", []string{"json", "table", "html", "csv", "ndjson", "focus", "template"})
+	cmd.Flags().String("template-path", "", "Path to Go template file, used with the template format")
+	cmd.Flags().Int("forecast-months", 0, "Forecast the monthly costs for 12 or 36 months using the usage_growth rates in the usage file")
 	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: all,price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nSupported by table and html output formats")
 
 	// This is deprecated and will show a warning if used without --terraform-force-cli
@@ -1,20 +1,19 @@
 package main
 
 import (
-	"context"
 	"errors"
 	"fmt"
-	"os"
 	"strconv"
+	"strings"
 	"time"
 
-	"github.com/open-policy-agent/opa/ast"
-	"github.com/open-policy-agent/opa/rego"
+	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
 
 	"github.com/infracost/infracost/internal/apiclient"
 
 	"github.com/infracost/infracost/internal/clierror"
+	"github.com/infracost/infracost/internal/comment"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/output"
 	"github.com/infracost/infracost/internal/ui"
@@ -25,19 +24,21 @@ type CommentOutput struct {
 	HasDiff        bool
 	ValidAt        *time.Time
 	AddRunResponse apiclient.AddRunResponse
+	Root           output.Root
 }
 
 var (
 	validCommentOutputFormats = []string{
 		"json",
+		"template",
 	}
 )
 
 func commentCmd(ctx *config.RunContext) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "comment",
-		Short: "Post an Infracost comment to GitHub, GitLab, Azure Repos or Bitbucket",
-		Long:  "Post an Infracost comment to GitHub, GitLab, Azure Repos or Bitbucket",
+		Short: "Post an Infracost comment to GitHub, GitLab, Azure Repos, Bitbucket, Gitea or a webhook",
+		Long:  "Post an Infracost comment to GitHub, GitLab, Azure Repos, Bitbucket, Gitea or a webhook",
 		Example: `  Update the Infracost comment on a GitHub pull request:
 
       infracost comment github --repo my-org/my-repo --pull-request 3 --path infracost.json --behavior update --github-token $GITHUB_TOKEN
@@ -48,16 +49,22 @@ func commentCmd(ctx *config.RunContext) *cobra.Command {
 
   Post a new comment to an Azure Repos pull request:
 
-      infracost comment azure-repos --repo-url https://dev.azure.com/my-org/my-project/_git/my-repo --pull-request 3 --path infracost.json --behavior new --azure-access-token $AZURE_ACCESS_TOKEN`,
+      infracost comment azure-repos --repo-url https://dev.azure.com/my-org/my-project/_git/my-repo --pull-request 3 --path infracost.json --behavior new --azure-access-token $AZURE_ACCESS_TOKEN
+
+  Post a Slack message to a webhook:
+
+      infracost comment webhook --url https://chatops.example.com/infracost --path infracost.json --body-format slack --secret $WEBHOOK_SECRET`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
 	}
 
-	cmds := []*cobra.Command{commentGitHubCmd(ctx), commentGitLabCmd(ctx), commentAzureReposCmd(ctx), commentBitbucketCmd(ctx)}
+	cmds := []*cobra.Command{commentGitHubCmd(ctx), commentGitLabCmd(ctx), commentAzureReposCmd(ctx), commentBitbucketCmd(ctx), commentGiteaCmd(ctx), commentWebhookCmd(ctx)}
 	for _, subCmd := range cmds {
 		subCmd.Flags().StringArray("policy-path", nil, "Path to Infracost policy files, glob patterns need quotes (experimental)")
+		subCmd.Flags().String("config-file", "", "Path to Infracost config file with budgets and tag policies to check the costs against")
+		_ = subCmd.MarkFlagFilename("config-file", "yml")
 		subCmd.Flags().Bool("show-all-projects", false, "Show all projects in the table of the comment output")
 		subCmd.Flags().Bool("show-changed", false, "Show only projects in the table that have code changes")
 		subCmd.Flags().Bool("show-skipped", false, "List unsupported and free resources")
@@ -66,6 +73,7 @@ func commentCmd(ctx *config.RunContext) *cobra.Command {
 		_ = subCmd.Flags().MarkHidden("skip-no-diff")
 		subCmd.Flags().String("additional-comment-data-path", "", "Path to additional comment text (experimental)")
 		_ = subCmd.Flags().MarkHidden("additional-comment-data-path")
+		subCmd.Flags().String("template-path", "", "Path to Go template file used to render the comment, used with the template format")
 	}
 
 	cmd.AddCommand(cmds...)
@@ -115,46 +123,69 @@ func buildCommentOutput(cmd *cobra.Command, ctx *config.RunContext, paths []stri
 		}
 	}
 
-	var policyChecks output.PolicyCheck
-	policyPaths, _ := cmd.Flags().GetStringArray("policy-path")
-	if len(policyPaths) > 0 {
-		policyChecks, err = queryPolicy(policyPaths, combined)
+	// The budgets and tag policies in the config file are checked by
+	// checkPolicies along with the --policy-path policies.
+	if cfgFilePath, _ := cmd.Flags().GetString("config-file"); cfgFilePath != "" {
+		cfgFile, err := config.LoadConfigFile(cfgFilePath)
 		if err != nil {
 			return nil, err
 		}
 
-		ctx.ContextValues.SetValue("passedPolicyCount", len(policyChecks.Passed))
-		ctx.ContextValues.SetValue("failedPolicyCount", len(policyChecks.Failures))
+		ctx.Config.Budgets = cfgFile.Budgets
+		ctx.Config.TagPolicies = cfgFile.TagPolicies
+	}
+
+	policyOutput, policyErr := checkPolicies(cmd, ctx, &combined, nil)
+	if policyErr != nil && isErrorUnhandled(policyErr) {
+		return nil, policyErr
 	}
 
 	opts := output.Options{
 		DashboardEndpoint: ctx.Config.DashboardEndpoint,
 		NoColor:           ctx.Config.NoColor,
-		PolicyOutput:      output.NewPolicyOutput(policyChecks),
+		PolicyOutput:      policyOutput,
 	}
 	opts.ShowAllProjects, _ = cmd.Flags().GetBool("show-all-projects")
 	opts.ShowOnlyChanges, _ = cmd.Flags().GetBool("show-changed")
 	opts.ShowSkipped, _ = cmd.Flags().GetBool("show-skipped")
+	opts.TemplatePath, _ = cmd.Flags().GetString("template-path")
+
+	var b []byte
+	switch commentBodyFormat(cmd) {
+	case "template":
+		b, err = output.ToTemplate(combined, opts)
+	case "slack":
+		b, err = output.ToSlackMessage(combined, opts)
+	case "json":
+		b, err = output.ToJSON(combined, opts)
+	default:
+		mdOpts.Additional = additionalCommentData
+
+		var md output.MarkdownOutput
+		md, err = output.ToMarkdown(combined, opts, mdOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		b = md.Msg
 
-	mdOpts.Additional = additionalCommentData
-	md, err := output.ToMarkdown(combined, opts, mdOpts)
+		ctx.ContextValues.SetValue("truncated", md.OriginalMsgSize != md.RuneLen)
+		ctx.ContextValues.SetValue("originalLength", md.OriginalMsgSize)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	b := md.Msg
-	ctx.ContextValues.SetValue("truncated", md.OriginalMsgSize != md.RuneLen)
-	ctx.ContextValues.SetValue("originalLength", md.OriginalMsgSize)
-
 	out := &CommentOutput{
 		Body:           string(b),
 		HasDiff:        combined.HasDiff(),
 		ValidAt:        &combined.TimeGenerated,
 		AddRunResponse: result,
+		Root:           combined,
 	}
 
-	if policyChecks.HasFailed() {
-		return out, policyChecks.Failures
+	if policyErr != nil {
+		return out, policyErr
 	}
 	if len(governanceFailures) > 0 {
 		return out, governanceFailures
@@ -163,99 +194,85 @@ func buildCommentOutput(cmd *cobra.Command, ctx *config.RunContext, paths []stri
 	return out, nil
 }
 
-type PRNumber int
+// postReviewComments keeps the review comments on the blocks of the resources
+// whose monthly cost changed in sync with the Infracost output.
+func postReviewComments(cmd *cobra.Command, ctx *config.RunContext, handler *comment.ReviewHandler, out output.Root, platform string) error {
+	threshold, _ := cmd.Flags().GetFloat64("review-comment-threshold")
+
+	var comments []comment.ReviewComment
+	for _, c := range output.ToReviewComments(out, decimal.NewFromFloat(threshold)) {
+		comments = append(comments, comment.ReviewComment{
+			Key:       c.Key,
+			Path:      c.Path,
+			StartLine: c.StartLine,
+			EndLine:   c.EndLine,
+			Body:      c.Body,
+		})
+	}
 
-func (p *PRNumber) Set(value string) error {
-	if value == "" {
+	format, _ := cmd.Flags().GetString("format")
+	quiet := strings.ToLower(format) == "json"
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if dryRun {
+		for _, c := range comments {
+			cmd.Printf("\nReview comment on %s:%d-%d\n%s", c.Path, c.StartLine, c.EndLine, c.Body)
+		}
+		cmd.Printf("\n%d review comments not posted to %s (--dry-run was specified)\n", len(comments), platform)
 		return nil
 	}
 
-	v, err := strconv.Atoi(value)
-	*p = PRNumber(v)
-
+	res, err := handler.SyncReviewComments(ctx.Context(), comments)
 	if err != nil {
-		return errors.New("must be integer")
+		return fmt.Errorf("%s\n%w", fmt.Sprintf("The comment was generated successfully but the review comments could not be posted to %s:", platform), err)
+	}
+
+	if !quiet {
+		cmd.Printf("Review comments posted to %s: %d created, %d updated, %d resolved", platform, res.Created, res.Updated, res.Resolved)
+		if res.Skipped > 0 {
+			cmd.Printf(", %d skipped since the resources are not part of the diff", res.Skipped)
+		}
+		cmd.Println()
 	}
 
 	return nil
 }
 
-func (p *PRNumber) String() string {
-	return fmt.Sprintf("%d", *p)
-}
+// commentBodyFormat returns the format the comment body is rendered in. This is
+// markdown unless --format template is used or the command supports other body
+// formats with the --body-format flag.
+func commentBodyFormat(cmd *cobra.Command) string {
+	if format, _ := cmd.Flags().GetString("format"); strings.ToLower(format) == "template" {
+		return "template"
+	}
 
-func (p *PRNumber) Type() string {
-	return "int"
+	bodyFormat, _ := cmd.Flags().GetString("body-format")
+	return strings.ToLower(bodyFormat)
 }
 
-func queryPolicy(policyPaths []string, input output.Root) (output.PolicyCheck, error) {
-	checks := output.PolicyCheck{
-		Enabled: true,
-	}
+type PRNumber int
 
-	inputValue, err := ast.InterfaceToValue(input)
-	if err != nil {
-		return checks, fmt.Errorf("Unable to process Infracost output into Rego input: %s", err.Error())
+func (p *PRNumber) Set(value string) error {
+	if value == "" {
+		return nil
 	}
 
-	ctx := context.Background()
-	r := rego.New(
-		rego.Query("data.infracost.deny"),
-		rego.ParsedInput(inputValue),
-		rego.Load(policyPaths, func(abspath string, info os.FileInfo, depth int) bool {
-			return false
-		}),
-	)
-	pq, err := r.PrepareForEval(ctx)
-	if err != nil {
-		return checks, fmt.Errorf("Unable to query provided policies: %s", err.Error())
-	}
+	v, err := strconv.Atoi(value)
+	*p = PRNumber(v)
 
-	res, err := pq.Eval(ctx)
 	if err != nil {
-		return checks, err
-	}
-
-	if len(res) == 0 {
-		return checks, fmt.Errorf("The provided polices returned no valid data.infracost.deny rules. Please check that the policies are formatted correctly.")
-	}
-
-	for _, e := range res[0].Expressions {
-		switch v := e.Value.(type) {
-		case map[string]interface{}:
-			readPolicyOut(v, &checks)
-		case []interface{}:
-			for _, ii := range v {
-				if m, ok := ii.(map[string]interface{}); ok {
-					readPolicyOut(m, &checks)
-				}
-			}
-		}
+		return errors.New("must be integer")
 	}
 
-	return checks, nil
+	return nil
 }
 
-func readPolicyOut(v map[string]interface{}, checks *output.PolicyCheck) {
-	if _, ok := v["msg"]; !ok {
-		checks.Failures = append(checks.Failures, "Policy rule invalid as it did not contain {msg: string} property in output object. Please edit rule output object.")
-		return
-	}
-	msg := v["msg"].(string)
-
-	if _, ok := v["failed"]; !ok {
-		checks.Failures = append(checks.Failures, fmt.Sprintf("Policy rule: [%s] did not contain {failed: bool} output property. Please edit rule output object.", msg))
-		return
-	}
-
-	failed, _ := v["failed"].(bool)
-
-	if failed {
-		checks.Failures = append(checks.Failures, msg)
-		return
-	}
+func (p *PRNumber) String() string {
+	return fmt.Sprintf("%d", *p)
+}
 
-	checks.Passed = append(checks.Passed, msg)
+func (p *PRNumber) Type() string {
+	return "int"
 }
 
 func isErrorUnhandled(err error) bool {
@@ -264,7 +281,7 @@ func isErrorUnhandled(err error) bool {
 	}
 
 	switch err.(type) {
-	case output.PolicyCheckFailures, output.GovernanceFailures:
+	case output.PolicyCheckFailures, output.BudgetFailures, output.TagPolicyFailures, output.GovernanceFailures:
 		return false
 	}
 
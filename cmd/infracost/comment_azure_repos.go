@@ -141,7 +141,7 @@ func commentAzureReposCmd(ctx *config.RunContext) *cobra.Command {
 	_ = cmd.MarkFlagRequired("repo-url")
 	cmd.Flags().String("tag", "", "Customize hidden markdown tag used to detect comments posted by Infracost")
 	cmd.Flags().Bool("dry-run", false, "Generate comment without actually posting to Azure Repos")
-	cmd.Flags().String("format", "", "Output format: json")
+	cmd.Flags().String("format", "", "Output format: json, template")
 
 	return cmd
 }
@@ -161,7 +161,7 @@ func commentBitbucketCmd(ctx *config.RunContext) *cobra.Command {
 	cmd.Flags().Bool("exclude-cli-output", false, "Exclude CLI output so comment has just the summary table")
 	cmd.Flags().String("tag", "", "Customize special text used to detect comments posted by Infracost (placed at the bottom of a comment)")
 	cmd.Flags().Bool("dry-run", false, "Generate comment without actually posting to Bitbucket")
-	cmd.Flags().String("format", "", "Output format: json")
+	cmd.Flags().String("format", "", "Output format: json, template")
 
 	return cmd
 }
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strconv"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/comment"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/logging"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+var validCommentGiteaBehaviors = []string{"update", "new", "delete-and-new"}
+
+func commentGiteaCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gitea",
+		Short: "Post an Infracost comment to Gitea or Forgejo",
+		Long: `Post an Infracost comment to Gitea or Forgejo.
+
+Gitea doesn't support comments on commits, so when --commit is used the comment is posted to the
+pull request the commit belongs to.`,
+		Example: `  Update comment on a pull request:
+
+      infracost comment gitea --repo my-org/my-repo --pull-request 3 --path infracost.json --gitea-api-url https://gitea.example.com --gitea-token $GITEA_TOKEN
+
+  Post a new comment to the pull request of a commit:
+
+      infracost comment gitea --repo my-org/my-repo --commit 2ca7182 --path infracost.json --behavior delete-and-new --gitea-token $GITEA_TOKEN`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx.ContextValues.SetValue("platform", "gitea")
+
+			var commentErr error
+
+			format, _ := cmd.Flags().GetString("format")
+			format = strings.ToLower(format)
+			if format != "" && !contains(validCommentOutputFormats, format) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--format only supports %s", strings.Join(validCommentOutputFormats, ", "))
+			}
+
+			apiURL, _ := cmd.Flags().GetString("gitea-api-url")
+			token, _ := cmd.Flags().GetString("gitea-token")
+			tag, _ := cmd.Flags().GetString("tag")
+
+			tlsCertFile, _ := cmd.Flags().GetString("gitea-tls-cert-file")
+			tlsKeyFile, _ := cmd.Flags().GetString("gitea-tls-key-file")
+			tlsInsecureSkipVerify, _ := cmd.Flags().GetBool("gitea-tls-insecure-skip-verify")
+
+			tlsConfig := tls.Config{} // nolint: gosec
+
+			rootCAs, _ := x509.SystemCertPool()
+			if rootCAs == nil {
+				rootCAs = x509.NewCertPool()
+			}
+
+			tlsConfig.RootCAs = rootCAs
+			tlsConfig.InsecureSkipVerify = tlsInsecureSkipVerify // nolint: gosec
+
+			if tlsCertFile != "" && tlsKeyFile != "" {
+				cert, err := tls.LoadX509KeyPair(tlsCertFile, tlsKeyFile)
+				if err != nil {
+					return errors.Wrap(err, "Error loading TLS certificate and key")
+				}
+				tlsConfig.Certificates = []tls.Certificate{cert}
+			}
+
+			extra := comment.GiteaExtra{
+				APIURL:    apiURL,
+				Token:     token,
+				Tag:       tag,
+				TLSConfig: &tlsConfig,
+			}
+
+			commit, _ := cmd.Flags().GetString("commit")
+			prNumber, _ := cmd.Flags().GetInt("pull-request")
+			repo, _ := cmd.Flags().GetString("repo")
+
+			var commentHandler *comment.CommentHandler
+			if prNumber != 0 {
+				ctx.ContextValues.SetValue("targetType", "pull-request")
+
+				commentHandler, commentErr = comment.NewGiteaPRHandler(ctx.Context(), repo, strconv.Itoa(prNumber), extra)
+				if commentErr != nil {
+					return commentErr
+				}
+			} else if commit != "" {
+				ctx.ContextValues.SetValue("targetType", "commit")
+
+				commentHandler, commentErr = comment.NewGiteaCommitHandler(ctx.Context(), repo, commit, extra)
+				if commentErr != nil {
+					return commentErr
+				}
+			} else {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("either --commit or --pull-request is required")
+			}
+
+			behavior, _ := cmd.Flags().GetString("behavior")
+			if behavior != "" && !contains(validCommentGiteaBehaviors, behavior) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--behavior only supports %s", strings.Join(validCommentGiteaBehaviors, ", "))
+			}
+			ctx.ContextValues.SetValue("behavior", behavior)
+
+			paths, _ := cmd.Flags().GetStringArray("path")
+
+			commentOut, commentErr := buildCommentOutput(cmd, ctx, paths, output.MarkdownOptions{
+				WillUpdate:          prNumber != 0 && behavior == "update",
+				WillReplace:         prNumber != 0 && behavior == "delete-and-new",
+				IncludeFeedbackLink: !ctx.Config.IsSelfHosted(),
+			})
+			if isErrorUnhandled(commentErr) {
+				return commentErr
+			}
+
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			if !dryRun {
+				skipNoDiff, _ := cmd.Flags().GetBool("skip-no-diff")
+
+				res, err := commentHandler.CommentWithBehavior(ctx.Context(), behavior, commentOut.Body, &comment.CommentOpts{
+					ValidAt:    commentOut.ValidAt,
+					SkipNoDiff: !commentOut.HasDiff && skipNoDiff,
+				})
+				if err != nil {
+					return err
+				}
+
+				pricingClient := apiclient.GetPricingAPIClient(ctx)
+				err = pricingClient.AddEvent("infracost-comment", ctx.EventEnv())
+				if err != nil {
+					logging.Logger.Err(err).Msg("could not report infracost-comment event")
+				}
+
+				if format == "json" {
+					b, err := jsoniter.MarshalIndent(commentOut.AddRunResponse, "", "  ")
+					if err != nil {
+						return fmt.Errorf("failed to marshal result: %w", err)
+					}
+					cmd.Printf(string(b))
+				} else if res.Posted {
+
+					cmd.Println("Comment posted to Gitea")
+				} else {
+					msg := "Comment not posted to Gitea"
+					if res.SkipReason != "" {
+						msg += fmt.Sprintf(": %s", res.SkipReason)
+					}
+					cmd.Println(msg)
+				}
+			} else {
+				cmd.Println(commentOut.Body)
+				cmd.Println("Comment not posted to Gitea (--dry-run was specified)")
+			}
+
+			if commentErr != nil {
+				cmd.Printf("\n")
+				return commentErr
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("behavior", "update", `Behavior when posting comment, one of:
+  update (default)  Update latest comment
+  new               Create a new comment
+  delete-and-new    Delete previous matching comments and create a new comment`)
+	_ = cmd.RegisterFlagCompletionFunc("behavior", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validCommentGiteaBehaviors, cobra.ShellCompDirectiveDefault
+	})
+	cmd.Flags().String("commit", "", "Commit SHA to post comment on, mutually exclusive with pull-request")
+	cmd.Flags().String("gitea-api-url", "https://gitea.com", "Gitea or Forgejo server URL")
+	cmd.Flags().String("gitea-token", "", "Gitea or Forgejo token")
+	_ = cmd.MarkFlagRequired("gitea-token")
+	cmd.Flags().String("gitea-tls-cert-file", "", "Path to optional client certificate file when communicating with the Gitea API")
+	cmd.Flags().String("gitea-tls-key-file", "", "Path to optional client key file when communicating with the Gitea API")
+	cmd.Flags().Bool("gitea-tls-insecure-skip-verify", false, "Skip TLS certificate checks for the Gitea API")
+	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagFilename("path", "json")
+	var prNumber PRNumber
+	cmd.Flags().Var(&prNumber, "pull-request", "Pull request number to post comment on, mutually exclusive with commit")
+	cmd.Flags().String("repo", "", "Repository in format owner/repo")
+	_ = cmd.MarkFlagRequired("repo")
+	cmd.Flags().String("tag", "", "Customize hidden markdown tag used to detect comments posted by Infracost")
+	cmd.Flags().Bool("dry-run", false, "Generate comment without actually posting to Gitea")
+	cmd.Flags().String("format", "", "Output format: json, template")
+
+	return cmd
+}
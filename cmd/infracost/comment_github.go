@@ -19,7 +19,7 @@ import (
 	"github.com/infracost/infracost/internal/ui"
 )
 
-var validCommentGitHubBehaviors = []string{"update", "new", "hide-and-new", "delete-and-new"}
+var validCommentGitHubBehaviors = []string{"update", "new", "hide-and-new", "delete-and-new", "check-run", "commit-status"}
 
 func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 	cmd := &cobra.Command{
@@ -32,7 +32,11 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 
   Post a new comment to a commit:
 
-      infracost comment github --repo my-org/my-repo --commit 2ca7182 --path infracost.json --behavior hide-and-new --github-token $GITHUB_TOKEN`,
+      infracost comment github --repo my-org/my-repo --commit 2ca7182 --path infracost.json --behavior hide-and-new --github-token $GITHUB_TOKEN
+
+  Publish a check run with annotations to the head commit of a pull request:
+
+      infracost comment github --repo my-org/my-repo --pull-request 3 --path infracost.json --behavior check-run --github-token $GITHUB_TOKEN`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx.ContextValues.SetValue("platform", "github")
@@ -83,6 +87,22 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 			prNumber, _ := cmd.Flags().GetInt("pull-request")
 			repo, _ := cmd.Flags().GetString("repo")
 
+			behavior, _ := cmd.Flags().GetString("behavior")
+			if behavior != "" && !contains(validCommentGitHubBehaviors, behavior) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--behavior only supports %s", strings.Join(validCommentGitHubBehaviors, ", "))
+			}
+			ctx.ContextValues.SetValue("behavior", behavior)
+
+			if behavior == "check-run" || behavior == "commit-status" {
+				if prNumber == 0 && commit == "" {
+					ui.PrintUsage(cmd)
+					return fmt.Errorf("either --commit or --pull-request is required")
+				}
+
+				return postGitHubCheck(cmd, ctx, behavior, repo, commit, prNumber, extra)
+			}
+
 			var commentHandler *comment.CommentHandler
 			if prNumber != 0 {
 				ctx.ContextValues.SetValue("targetType", "pull-request")
@@ -103,12 +123,18 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 				return fmt.Errorf("either --commit or --pull-request is required")
 			}
 
-			behavior, _ := cmd.Flags().GetString("behavior")
-			if behavior != "" && !contains(validCommentGitHubBehaviors, behavior) {
-				ui.PrintUsage(cmd)
-				return fmt.Errorf("--behavior only supports %s", strings.Join(validCommentGitHubBehaviors, ", "))
+			var reviewHandler *comment.ReviewHandler
+			if reviewComments, _ := cmd.Flags().GetBool("review-comments"); reviewComments {
+				if prNumber == 0 {
+					ui.PrintUsage(cmd)
+					return fmt.Errorf("--review-comments is only supported with --pull-request")
+				}
+
+				reviewHandler, commentErr = comment.NewGitHubReviewHandler(ctx.Context(), repo, strconv.Itoa(prNumber), extra)
+				if commentErr != nil {
+					return commentErr
+				}
 			}
-			ctx.ContextValues.SetValue("behavior", behavior)
 
 			paths, _ := cmd.Flags().GetStringArray("path")
 
@@ -161,6 +187,13 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 				cmd.Println("Comment not posted to GitHub (--dry-run was specified)")
 			}
 
+			if reviewHandler != nil {
+				err := postReviewComments(cmd, ctx, reviewHandler, commentOut.Root, "GitHub")
+				if err != nil {
+					return err
+				}
+			}
+
 			if commentErr != nil {
 				cmd.Printf("\n")
 				return commentErr
@@ -174,7 +207,9 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
   update (default)  Update latest comment
   new               Create a new comment
   hide-and-new      Hide previous matching comments and create a new comment
-  delete-and-new    Delete previous matching comments and create a new comment`)
+  delete-and-new    Delete previous matching comments and create a new comment
+  check-run         Create or update a check run with annotations instead of a comment
+  commit-status     Set a commit status instead of a comment`)
 	_ = cmd.RegisterFlagCompletionFunc("behavior", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return validCommentGitHubBehaviors, cobra.ShellCompDirectiveDefault
 	})
@@ -192,9 +227,91 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 	cmd.Flags().Var(&prNumber, "pull-request", "Pull request number to post comment on, mutually exclusive with commit")
 	cmd.Flags().String("repo", "", "Repository in format owner/repo")
 	_ = cmd.MarkFlagRequired("repo")
+	cmd.Flags().Bool("review-comments", false, "Also post review comments on the lines of resources whose monthly cost changed, only for pull requests")
+	cmd.Flags().Float64("review-comment-threshold", 0, "Minimum absolute monthly cost change of a resource to post a review comment")
 	cmd.Flags().String("tag", "", "Customize hidden markdown tag used to detect comments posted by Infracost")
 	cmd.Flags().Bool("dry-run", false, "Generate comment without actually posting to GitHub")
-	cmd.Flags().String("format", "", "Output format: json")
+	cmd.Flags().String("format", "", "Output format: json, template")
 
 	return cmd
 }
+
+// postGitHubCheck publishes the Infracost output as a GitHub check run or
+// commit status. The check fails if any policy checks fail so branch protection
+// rules can require it to pass.
+func postGitHubCheck(cmd *cobra.Command, ctx *config.RunContext, behavior, repo, commit string, prNumber int, extra comment.GitHubExtra) error {
+	ctx.ContextValues.SetValue("targetType", behavior)
+
+	checkHandler, err := comment.NewGitHubCheckHandler(ctx.Context(), repo, commit, prNumber, extra)
+	if err != nil {
+		return err
+	}
+
+	paths, _ := cmd.Flags().GetStringArray("path")
+
+	commentOut, commentErr := buildCommentOutput(cmd, ctx, paths, output.MarkdownOptions{
+		IncludeFeedbackLink: !ctx.Config.IsSelfHosted(),
+		MaxMessageSize:      output.GitHubCheckRunMaxSummarySize,
+	})
+	if isErrorUnhandled(commentErr) {
+		return commentErr
+	}
+
+	opts := comment.CheckRunOpts{
+		Title:      output.CostChangeTitle(commentOut.Root),
+		Summary:    commentOut.Body,
+		Failed:     commentErr != nil,
+		DetailsURL: commentOut.Root.ShareURL,
+	}
+
+	for _, a := range output.ResourceAnnotations(commentOut.Root) {
+		opts.Annotations = append(opts.Annotations, comment.CheckRunAnnotation{
+			Path:      a.Path,
+			StartLine: a.StartLine,
+			EndLine:   a.EndLine,
+			Level:     "notice",
+			Title:     a.Title,
+			Message:   a.Message,
+		})
+	}
+
+	name := "check run"
+	if behavior == "commit-status" {
+		name = "commit status"
+	}
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if dryRun {
+		cmd.Println(opts.Title)
+		cmd.Println(commentOut.Body)
+		for _, a := range opts.Annotations {
+			cmd.Printf("%s:%d %s: %s\n", a.Path, a.StartLine, a.Level, a.Title)
+		}
+		cmd.Printf("GitHub %s not published (--dry-run was specified)\n", name)
+	} else {
+		var err error
+		if behavior == "commit-status" {
+			_, err = checkHandler.PublishCommitStatus(ctx.Context(), opts)
+		} else {
+			_, err = checkHandler.PublishCheckRun(ctx.Context(), opts)
+		}
+		if err != nil {
+			return fmt.Errorf("The comment was generated successfully but the GitHub %s could not be published:\n%w", name, err)
+		}
+
+		pricingClient := apiclient.GetPricingAPIClient(ctx)
+		err = pricingClient.AddEvent("infracost-comment", ctx.EventEnv())
+		if err != nil {
+			logging.Logger.Err(err).Msg("could not report infracost-comment event")
+		}
+
+		cmd.Printf("GitHub %s %s published: %s\n", name, checkHandler.Name(), opts.Title)
+	}
+
+	if commentErr != nil {
+		cmd.Printf("\n")
+		return commentErr
+	}
+
+	return nil
+}
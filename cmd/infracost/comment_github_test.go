@@ -32,6 +32,30 @@ func TestCommentGitHubCommit(t *testing.T) {
 		nil)
 }
 
+func TestCommentGitHubBudgets(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "github", "--github-token", "abc", "--repo", "test/test", "--pull-request", "5", "--path", "./testdata/terraform_v0.14_breakdown.json", "--config-file", "./testdata/comment_git_hub_budgets/infracost.yml", "--dry-run"},
+		nil)
+}
+
+func TestCommentGitHubTagPolicies(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "github", "--github-token", "abc", "--repo", "test/test", "--pull-request", "5", "--path", "./testdata/terraform_v0.14_breakdown.json", "--config-file", "./testdata/comment_git_hub_tag_policies/infracost.yml", "--dry-run"},
+		nil)
+}
+
+func TestCommentGitHubUsageProfiles(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "github", "--github-token", "abc", "--repo", "test/test", "--pull-request", "5", "--path", "./testdata/comment_git_hub_usage_profiles/infracost.json", "--dry-run"},
+		nil)
+}
+
+func TestCommentGitHubWithPolicyPath(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "github", "--github-token", "abc", "--repo", "test/test", "--pull-request", "5", "--path", "./testdata/terraform_v0.14_breakdown.json", "--policy-path", "./testdata/comment_git_hub_with_policy_path/policy.rego", "--dry-run"},
+		nil)
+}
+
 func TestCommentGitHubShowAllProjects(t *testing.T) {
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
 		[]string{"comment", "github", "--github-token", "abc", "--repo", "test/test", "--commit", "5", "--show-all-projects", "--path", "./testdata/terraform_v0.14_breakdown.json", "--path", "./testdata/terraform_v0.14_nochange_breakdown.json", "--dry-run"},
@@ -542,3 +566,74 @@ func ghTestEndpoint() *httptest.Server {
 		i = (i + 1) % len(githubGraphQLresponses)
 	}))
 }
+
+func TestCommentGitHubCheckRunDryRun(t *testing.T) {
+	dir := testutil.CalcGoldenFileTestdataDirName()
+	GoldenFileCommandTest(t, dir,
+		[]string{"comment", "github", "--github-token", "abc", "--repo", "test/test", "--pull-request", "5", "--path", path.Join("./testdata", dir, "infracost.json"), "--behavior", "check-run", "--dry-run"},
+		nil)
+}
+
+func TestCommentGitHubCheckRun(t *testing.T) {
+	var requests []string
+	var checkRun map[string]interface{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/repos/test/test/pulls/5":
+			fmt.Fprintln(w, `{"number": 5, "head": {"sha": "abc123"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/repos/test/test/commits/abc123/check-runs":
+			fmt.Fprintln(w, `{"total_count": 0, "check_runs": []}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v3/repos/test/test/check-runs":
+			b, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(b, &checkRun)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintln(w, `{"id": 1}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "github", "--github-token", "abc", "--repo", "test/test", "--pull-request", "5", "--path", "./testdata/comment_git_hub_check_run_dry_run/infracost.json", "--behavior", "check-run", "--github-api-url", ts.URL},
+		nil)
+
+	if !strings.Contains(strings.Join(requests, "\n"), "POST /api/v3/repos/test/test/check-runs") {
+		t.Fatalf("expected check run to be created, got %v", requests)
+	}
+
+	if checkRun["head_sha"] != "abc123" || checkRun["conclusion"] != "success" {
+		t.Fatalf("unexpected check run %v", checkRun)
+	}
+
+	out := checkRun["output"].(map[string]interface{})
+	if out["title"] != "+$41/mo (+100%)" {
+		t.Fatalf("unexpected check run title %v", out["title"])
+	}
+
+	annotations := out["annotations"].([]interface{})
+	if len(annotations) != 2 {
+		t.Fatalf("expected 2 annotations, got %v", annotations)
+	}
+}
+
+func TestCommentGitHubReviewCommentsDryRun(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "github", "--github-token", "abc", "--repo", "test/test", "--pull-request", "5", "--path", "./testdata/comment_git_hub_check_run_dry_run/infracost.json", "--review-comments", "--review-comment-threshold", "1", "--dry-run"},
+		nil)
+}
+
+func TestCommentGitHubReviewCommentsWithCommit(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "github", "--github-token", "abc", "--repo", "test/test", "--commit", "5", "--path", "./testdata/comment_git_hub_check_run_dry_run/infracost.json", "--review-comments", "--dry-run"},
+		nil)
+}
+
+func TestCommentGitHubWithRecommendations(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "github", "--github-token", "abc", "--repo", "test/test", "--pull-request", "5", "--path", "./testdata/comment_git_hub_with_recommendations/infracost.json", "--dry-run"},
+		nil)
+}
@@ -76,6 +76,19 @@ func commentGitLabCmd(ctx *config.RunContext) *cobra.Command {
 				return fmt.Errorf("either --commit or --merge-request is required")
 			}
 
+			var reviewHandler *comment.ReviewHandler
+			if reviewComments, _ := cmd.Flags().GetBool("review-comments"); reviewComments {
+				if mrNumber == 0 {
+					ui.PrintUsage(cmd)
+					return fmt.Errorf("--review-comments is only supported with --merge-request")
+				}
+
+				reviewHandler, err = comment.NewGitLabReviewHandler(ctx.Context(), repo, strconv.Itoa(mrNumber), extra)
+				if err != nil {
+					return err
+				}
+			}
+
 			behavior, _ := cmd.Flags().GetString("behavior")
 			if behavior != "" && !contains(validCommentGitLabBehaviors, behavior) {
 				ui.PrintUsage(cmd)
@@ -132,6 +145,13 @@ func commentGitLabCmd(ctx *config.RunContext) *cobra.Command {
 				cmd.Println("Comment not posted to GitLab (--dry-run was specified)")
 			}
 
+			if reviewHandler != nil {
+				err = postReviewComments(cmd, ctx, reviewHandler, commentOut.Root, "GitLab")
+				if err != nil {
+					return err
+				}
+			}
+
 			return commentErr
 		},
 	}
@@ -154,9 +174,11 @@ func commentGitLabCmd(ctx *config.RunContext) *cobra.Command {
 	cmd.Flags().Var(&mrNumber, "merge-request", "Merge request number to post comment on, mutually exclusive with commit")
 	cmd.Flags().String("repo", "", "Repository in format owner/repo")
 	_ = cmd.MarkFlagRequired("repo")
+	cmd.Flags().Bool("review-comments", false, "Also post review comments on the lines of resources whose monthly cost changed, only for merge requests")
+	cmd.Flags().Float64("review-comment-threshold", 0, "Minimum absolute monthly cost change of a resource to post a review comment")
 	cmd.Flags().String("tag", "", "Customize hidden markdown tag used to detect comments posted by Infracost")
 	cmd.Flags().Bool("dry-run", false, "Generate comment without actually posting to GitLab")
-	cmd.Flags().String("format", "", "Output format: json")
+	cmd.Flags().String("format", "", "Output format: json, template")
 
 	return cmd
 }
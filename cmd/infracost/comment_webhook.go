@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/comment"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/logging"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+var (
+	validCommentWebhookBehaviors   = []string{"update", "new", "hide-and-new", "delete-and-new"}
+	validCommentWebhookBodyFormats = []string{"markdown", "slack", "json"}
+
+	webhookContentTypes = map[string]string{
+		"markdown": "text/markdown; charset=utf-8",
+		"slack":    "application/json",
+		"json":     "application/json",
+		"template": "text/plain; charset=utf-8",
+	}
+)
+
+func commentWebhookCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "webhook",
+		Short: "Post an Infracost comment to a webhook",
+		Long: `Post an Infracost comment to a webhook.
+
+The comment is sent in a POST request with the following headers:
+
+  Idempotency-Key        Derived from the tag. It is the same for every request with the same
+                         tag, except for the new behavior where it also depends on the body
+  X-Infracost-Behavior   The --behavior flag, receivers should use this with the idempotency key
+                         to update, hide or delete the messages they previously received
+  X-Infracost-Tag        The --tag flag, or infracost-comment if it is not set
+  X-Infracost-Valid-At   The time the Infracost JSON files were generated
+  X-Infracost-Signature  sha256= followed by the hex encoded HMAC-SHA256 of the body, only set
+                         when --secret is used`,
+		Example: `  Post a markdown comment that the receiver should use to update its previous message:
+
+      infracost comment webhook --url https://chatops.example.com/infracost --path infracost.json
+
+  Post a Slack message signed with a secret and an authorization header:
+
+      infracost comment webhook --url https://chatops.example.com/infracost --path infracost.json --body-format slack \
+          --secret $WEBHOOK_SECRET --header "Authorization: Bearer $TOKEN"`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx.ContextValues.SetValue("platform", "webhook")
+
+			format, _ := cmd.Flags().GetString("format")
+			format = strings.ToLower(format)
+			if format != "" && !contains(validCommentOutputFormats, format) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--format only supports %s", strings.Join(validCommentOutputFormats, ", "))
+			}
+
+			bodyFormat, _ := cmd.Flags().GetString("body-format")
+			bodyFormat = strings.ToLower(bodyFormat)
+			if !contains(validCommentWebhookBodyFormats, bodyFormat) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--body-format only supports %s", strings.Join(validCommentWebhookBodyFormats, ", "))
+			}
+
+			behavior, _ := cmd.Flags().GetString("behavior")
+			if behavior != "" && !contains(validCommentWebhookBehaviors, behavior) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--behavior only supports %s", strings.Join(validCommentWebhookBehaviors, ", "))
+			}
+			ctx.ContextValues.SetValue("behavior", behavior)
+
+			rawHeaders, _ := cmd.Flags().GetStringArray("header")
+			headers := make(map[string]string, len(rawHeaders))
+			for _, h := range rawHeaders {
+				k, v, ok := strings.Cut(h, ":")
+				if !ok || strings.TrimSpace(k) == "" {
+					ui.PrintUsage(cmd)
+					return fmt.Errorf("--header must be in the format 'Name: value', got '%s'", h)
+				}
+				headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+			}
+
+			url, _ := cmd.Flags().GetString("url")
+			secret, _ := cmd.Flags().GetString("secret")
+			retries, _ := cmd.Flags().GetInt("retries")
+			tag, _ := cmd.Flags().GetString("tag")
+
+			contentType := webhookContentTypes[commentBodyFormat(cmd)]
+
+			handler, err := comment.NewWebhookHandler(ctx.Context(), comment.WebhookExtra{
+				URL:         url,
+				Headers:     headers,
+				ContentType: contentType,
+				Secret:      secret,
+				Retries:     retries,
+				Tag:         tag,
+			})
+			if err != nil {
+				return err
+			}
+
+			paths, _ := cmd.Flags().GetStringArray("path")
+
+			commentOut, commentErr := buildCommentOutput(cmd, ctx, paths, output.MarkdownOptions{
+				WillUpdate:          behavior == "update",
+				WillReplace:         behavior == "delete-and-new",
+				IncludeFeedbackLink: !ctx.Config.IsSelfHosted(),
+			})
+			if isErrorUnhandled(commentErr) {
+				return commentErr
+			}
+
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			if !dryRun {
+				res, err := handler.CommentWithBehavior(ctx.Context(), behavior, commentOut.Body, &comment.CommentOpts{
+					ValidAt: commentOut.ValidAt,
+				})
+				if err != nil {
+					return err
+				}
+
+				pricingClient := apiclient.GetPricingAPIClient(ctx)
+				err = pricingClient.AddEvent("infracost-comment", ctx.EventEnv())
+				if err != nil {
+					logging.Logger.Err(err).Msg("could not report infracost-comment event")
+				}
+
+				if format == "json" {
+					b, err := jsoniter.MarshalIndent(commentOut.AddRunResponse, "", "  ")
+					if err != nil {
+						return fmt.Errorf("failed to marshal result: %w", err)
+					}
+					cmd.Printf(string(b))
+				} else if res.Posted {
+					cmd.Println("Comment posted to webhook")
+				} else {
+					msg := "Comment not posted to webhook"
+					if res.SkipReason != "" {
+						msg += fmt.Sprintf(": %s", res.SkipReason)
+					}
+					cmd.Println(msg)
+				}
+			} else {
+				cmd.Println(commentOut.Body)
+				cmd.Println("Comment not posted to webhook (--dry-run was specified)")
+			}
+
+			return commentErr
+		},
+	}
+
+	cmd.Flags().String("behavior", "update", `Behavior sent to the webhook, one of:
+  update (default)  Update latest message
+  new               Create a new message
+  hide-and-new      Hide previous matching messages and create a new message
+  delete-and-new    Delete previous matching messages and create a new message`)
+	_ = cmd.RegisterFlagCompletionFunc("behavior", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validCommentWebhookBehaviors, cobra.ShellCompDirectiveDefault
+	})
+	cmd.Flags().String("body-format", "markdown", "Format of the request body: markdown, slack, json")
+	_ = cmd.RegisterFlagCompletionFunc("body-format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validCommentWebhookBodyFormats, cobra.ShellCompDirectiveDefault
+	})
+	cmd.Flags().StringArray("header", nil, "Header to add to the request in the format 'Name: value', can be repeated")
+	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagFilename("path", "json")
+	cmd.Flags().Int("retries", 3, "Number of times to retry the request if it fails with a network error, 429 or 5xx response")
+	cmd.Flags().String("secret", "", "Secret used to sign the request body with HMAC-SHA256")
+	cmd.Flags().String("tag", "", "Customize the tag the idempotency key is derived from")
+	cmd.Flags().String("url", "", "URL to POST the comment to")
+	_ = cmd.MarkFlagRequired("url")
+	cmd.Flags().Bool("dry-run", false, "Generate comment without actually posting to the webhook")
+	cmd.Flags().String("format", "", "Output format: json, template")
+
+	return cmd
+}
@@ -0,0 +1,44 @@
+package main_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestCommentWebhookHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"comment", "webhook", "--help"}, nil)
+}
+
+func TestCommentWebhookDryRun(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "webhook", "--url", "https://example.com/webhook", "--path", "./testdata/terraform_v0.14_breakdown.json", "--dry-run"},
+		nil)
+}
+
+func TestCommentWebhookSlackBody(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "webhook", "--url", "https://example.com/webhook", "--body-format", "slack", "--path", "./testdata/terraform_v0.14_breakdown.json", "--dry-run"},
+		nil)
+}
+
+func TestCommentWebhookInvalidHeader(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "webhook", "--url", "https://example.com/webhook", "--header", "invalid", "--path", "./testdata/terraform_v0.14_breakdown.json", "--dry-run"},
+		nil)
+}
+
+func TestCommentWebhookPost(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer abc" || r.Header.Get("X-Infracost-Signature") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer ts.Close()
+
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "webhook", "--url", ts.URL, "--header", "Authorization: Bearer abc", "--secret", "secret", "--body-format", "json", "--path", "./testdata/terraform_v0.14_breakdown.json"},
+		nil)
+}
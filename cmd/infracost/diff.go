@@ -33,8 +33,10 @@ func diffCmd(ctx *config.RunContext) *cobra.Command {
       infracost diff --path plan.json`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
-				return err
+			if !ctx.Config.IsOfflinePricing() {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
 			}
 
 			err := loadRunFlags(ctx.Config, cmd)
@@ -61,8 +63,10 @@ func diffCmd(ctx *config.RunContext) *cobra.Command {
 	addRunFlags(cmd)
 
 	cmd.Flags().String("compare-to", "", "Path to Infracost JSON file to compare against")
-	newEnumFlag(cmd, "format", "diff", "Output format", []string{"json", "diff"})
+	newEnumFlag(cmd, "format", "diff", "Output format", []string{"json", "diff", "template"})
+	cmd.Flags().String("template-path", "", "Path to Go template file, used with the template format")
 	cmd.Flags().String("out-file", "", "Save output to a file")
+	cmd.Flags().StringArray("policy-path", nil, "Path to Infracost policy files, glob patterns need quotes (experimental)")
 
 	return cmd
 }
@@ -96,6 +100,11 @@ func runCompare(cmd *cobra.Command, ctx *config.RunContext, current output.Root)
 		return err
 	}
 
+	policyOutput, policyErr := checkPolicies(cmd, ctx, &combined, nil)
+	if policyErr != nil && isErrorUnhandled(policyErr) {
+		return policyErr
+	}
+
 	format, _ := cmd.Flags().GetString("format")
 	b, err := output.FormatOutput(strings.ToLower(format), combined, output.Options{
 		DashboardEndpoint: ctx.Config.DashboardEndpoint,
@@ -103,6 +112,8 @@ func runCompare(cmd *cobra.Command, ctx *config.RunContext, current output.Root)
 		NoColor:           ctx.Config.NoColor,
 		Fields:            ctx.Config.Fields,
 		CurrencyFormat:    ctx.Config.CurrencyFormat,
+		TemplatePath:      ctx.Config.TemplatePath,
+		PolicyOutput:      policyOutput,
 	})
 	if err != nil {
 		return err
@@ -115,11 +126,15 @@ func runCompare(cmd *cobra.Command, ctx *config.RunContext, current output.Root)
 	}
 
 	if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
-		return saveOutFile(ctx, cmd, outFile, b)
+		err = saveOutFile(ctx, cmd, outFile, b)
+		if err != nil {
+			return err
+		}
+	} else {
+		cmd.Println(string(b))
 	}
 
-	cmd.Println(string(b))
-	return nil
+	return policyErr
 }
 
 func checkDiffConfig(cfg *config.Config) error {
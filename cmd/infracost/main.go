@@ -81,9 +81,13 @@ func Run(modifyCtx func(*config.RunContext), args *[]string) {
 
 		handleUpdateMessage(updateMessageChan)
 
-		if appErr != nil || unexpectedErr != nil {
+		if unexpectedErr != nil {
 			ctx.Exit(1)
 		}
+
+		if appErr != nil {
+			ctx.Exit(clierror.ExitCode(appErr))
+		}
 	}()
 
 	startUpdateCheck(ctx, updateMessageChan)
@@ -193,6 +197,7 @@ func newRootCmd(ctx *config.RunContext) *cobra.Command {
 	rootCmd.AddCommand(outputCmd(ctx))
 	rootCmd.AddCommand(uploadCmd(ctx))
 	rootCmd.AddCommand(commentCmd(ctx))
+	rootCmd.AddCommand(pricingCmd(ctx))
 	rootCmd.AddCommand(completionCmd())
 	rootCmd.AddCommand(figAutocompleteCmd())
 	rootCmd.AddCommand(newGenerateCommand())
@@ -249,7 +254,7 @@ func startUpdateCheck(ctx *config.RunContext, c chan *update.Info) {
 }
 
 func loadCloudSettings(ctx *config.RunContext) {
-	if ctx.Config.IsSelfHosted() || (ctx.Config.EnableCloud != nil && !*ctx.Config.EnableCloud) {
+	if ctx.Config.IsSelfHosted() || ctx.Config.IsOfflinePricing() || (ctx.Config.EnableCloud != nil && !*ctx.Config.EnableCloud) {
 		return
 	}
 
@@ -302,6 +307,8 @@ func checkAPIKey(apiKey string, apiEndpoint string, defaultEndpoint string) erro
 var ignoredErrors = []string{
 	"Policy check failed",
 	"Governance check failed",
+	"Budget check failed",
+	"Tag policy check failed",
 }
 
 func handleCLIError(ctx *config.RunContext, cliErr error) {
@@ -27,6 +27,11 @@ var (
 		"bitbucket-comment",
 		"bitbucket-comment-summary",
 		"slack-message",
+		"teams-message",
+		"csv",
+		"ndjson",
+		"focus",
+		"template",
 	}
 
 	validCompareToFormats = map[string]bool{
@@ -38,6 +43,8 @@ var (
 		"bitbucket-comment":         true,
 		"bitbucket-comment-summary": true,
 		"slack-message":             true,
+		"teams-message":             true,
+		"template":                  true,
 	}
 )
 
@@ -72,7 +79,19 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 
   Create markdown report to post in a Bitbucket comment:
 
-      infracost output --format bitbucket-comment --path "out*.json" # glob needs quotes`,
+      infracost output --format bitbucket-comment --path "out*.json" # glob needs quotes
+
+  Export a flat CSV file with one row per cost component:
+
+      infracost output --format csv --path "out*.json" --out-file costs.csv # glob needs quotes
+
+  Export a FinOps Open Cost and Usage Specification (FOCUS) CSV file:
+
+      infracost output --format focus --path "out*.json" --out-file focus.csv # glob needs quotes
+
+  Render a custom Go template:
+
+      infracost output --format template --template-path report.tmpl --path "out*.json" # glob needs quotes`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
@@ -86,6 +105,12 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 				return fmt.Errorf("--format only supports %s", strings.Join(validOutputFormats, ", "))
 			}
 
+			templatePath, _ := cmd.Flags().GetString("template-path")
+			if format == "template" && templatePath == "" {
+				ui.PrintUsage(cmd)
+				return errors.New("--template-path is required when using --format template")
+			}
+
 			paths, _ := cmd.Flags().GetStringArray("path")
 
 			inputs, err := output.LoadPaths(paths)
@@ -132,6 +157,7 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 				NoColor:           ctx.Config.NoColor,
 				Fields:            fields,
 				CurrencyFormat:    ctx.Config.CurrencyFormat,
+				TemplatePath:      templatePath,
 			}
 			opts.ShowSkipped, _ = cmd.Flags().GetBool("show-skipped")
 			opts.ShowAllProjects, _ = cmd.Flags().GetBool("show-all-projects")
@@ -178,7 +204,8 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
 	cmd.Flags().StringP("out-file", "o", "", "Save output to a file, helpful with format flag")
 
-	cmd.Flags().String("format", "table", "Output format: json, diff, table, html, github-comment, gitlab-comment, azure-repos-comment, bitbucket-comment, bitbucket-comment-summary, slack-message")
+	cmd.Flags().String("format", "table", "Output format: json, diff, table, html, github-comment, gitlab-comment, azure-repos-comment, bitbucket-comment, bitbucket-comment-summary, slack-message, teams-message, csv, ndjson, focus, template")
+	cmd.Flags().String("template-path", "", "Path to Go template file, used with the template format")
 	cmd.Flags().Bool("show-all-projects", false, "Show all projects in the table of the comment output")
 	cmd.Flags().Bool("show-skipped", false, "List unsupported and free resources")
 	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: all,price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nSupported by table and html output formats")
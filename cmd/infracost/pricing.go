@@ -0,0 +1,227 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/logging"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func pricingCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pricing",
+		Short: "Manage local price snapshots used for offline pricing",
+		Long: `Manage local price snapshots used for offline pricing.
+
+Set INFRACOST_PRICING_SNAPSHOT_FILE to the path of a snapshot to resolve
+prices from it instead of the Cloud Pricing API.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(pricingSnapshotCmd(ctx))
+
+	return cmd
+}
+
+func pricingSnapshotCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Build a price snapshot file",
+		Long: `Build a compressed price snapshot file that can be used to run Infracost
+without access to the Cloud Pricing API.
+
+A snapshot can be built from a full Cloud Pricing API data export, or by
+pricing a set of projects against the Cloud Pricing API and recording every
+price that was looked up. Projects can be given using the same --path and
+--config-file flags as 'infracost breakdown', or by passing Infracost JSON
+outputs whose project paths are priced again.`,
+		Example: `  Build a snapshot from the projects in a config file:
+
+      infracost pricing snapshot --config-file infracost.yml --out-file prices.json.gz
+
+  Build a snapshot from the projects in existing Infracost JSON outputs:
+
+      infracost pricing snapshot --infracost-json infracost-base.json --out-file prices.json.gz
+
+  Build a snapshot from a full Cloud Pricing API data export:
+
+      infracost pricing snapshot --from-export cloudPricing.csv.gz --out-file prices.json.gz
+
+  Use the snapshot:
+
+      INFRACOST_PRICING_SNAPSHOT_FILE=prices.json.gz infracost breakdown --path /code`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outFile, _ := cmd.Flags().GetString("out-file")
+			exports, _ := cmd.Flags().GetStringSlice("from-export")
+			jsonPaths, _ := cmd.Flags().GetStringSlice("infracost-json")
+
+			if ctx.Config.PricingSnapshotFile != "" {
+				return errors.New("INFRACOST_PRICING_SNAPSHOT_FILE cannot be set when building a price snapshot")
+			}
+
+			snapshot := apiclient.NewPriceSnapshot(ctx.Config.Currency)
+
+			for _, path := range exports {
+				s, err := loadPriceExport(path)
+				if err != nil {
+					return err
+				}
+
+				err = snapshot.Merge(s)
+				if err != nil {
+					return err
+				}
+			}
+
+			hasRunFlags := cmd.Flags().Changed("path") || cmd.Flags().Changed("config-file")
+			if hasRunFlags || len(jsonPaths) > 0 {
+				err := recordProjectPrices(cmd, ctx, snapshot, jsonPaths)
+				if err != nil {
+					return err
+				}
+			} else if len(exports) == 0 {
+				ui.PrintUsage(cmd)
+				return errors.New("one of --path, --config-file, --infracost-json or --from-export must be specified")
+			}
+
+			err := snapshot.Write(outFile)
+			if err != nil {
+				return err
+			}
+
+			logging.Logger.Debug().Msgf("wrote %d products and %d queries to price snapshot", len(snapshot.Products), len(snapshot.Queries))
+			cmd.PrintErrf("Price snapshot saved to %s\n", outFile)
+
+			return nil
+		},
+	}
+
+	addRunFlags(cmd)
+
+	cmd.Flags().StringSlice("infracost-json", nil, "Paths to Infracost JSON files, the projects in these files are priced and recorded")
+	cmd.Flags().StringSlice("from-export", nil, "Paths to Cloud Pricing API data exports (CSV, optionally gzipped)")
+	cmd.Flags().String("out-file", "", "Save the price snapshot to a file")
+
+	_ = cmd.MarkFlagRequired("out-file")
+	_ = cmd.MarkFlagFilename("infracost-json", "json")
+	_ = cmd.MarkFlagFilename("from-export", "csv", "gz")
+
+	return cmd
+}
+
+func loadPriceExport(path string) (*apiclient.PriceSnapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open price export %s: %w", path, err)
+	}
+	defer f.Close()
+
+	s, err := apiclient.NewPriceSnapshotFromExport(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not load price export %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// recordProjectPrices prices the projects given by the run flags or Infracost
+// JSON outputs using the Cloud Pricing API, and records all the prices that
+// were looked up in the snapshot.
+func recordProjectPrices(cmd *cobra.Command, ctx *config.RunContext, snapshot *apiclient.PriceSnapshot, jsonPaths []string) error {
+	if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+		return err
+	}
+
+	if len(jsonPaths) > 0 {
+		if cmd.Flags().Changed("path") || cmd.Flags().Changed("config-file") {
+			ui.PrintUsage(cmd)
+			return errors.New("--infracost-json cannot be used with --path or --config-file")
+		}
+
+		projects, err := projectsFromOutputs(jsonPaths)
+		if err != nil {
+			return err
+		}
+
+		ctx.Config.Projects = projects
+	} else {
+		err := loadRunFlags(ctx.Config, cmd)
+		if err != nil {
+			return err
+		}
+	}
+
+	apiclient.GetPricingAPIClient(ctx).RecordSnapshot(snapshot)
+
+	pr, err := newParallelRunner(cmd, ctx)
+	if err != nil {
+		return err
+	}
+
+	results, err := pr.run()
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		for _, project := range result.projectOut.projects {
+			if project.Metadata == nil {
+				continue
+			}
+
+			for _, diag := range project.Metadata.Errors {
+				ui.PrintWarningf(cmd.ErrOrStderr(), "Project %s could not be priced: %s", project.Name, diag.Message)
+			}
+		}
+	}
+
+	return nil
+}
+
+// projectsFromOutputs returns the project configs for all the projects found
+// in the Infracost JSON files.
+func projectsFromOutputs(paths []string) ([]*config.Project, error) {
+	var projects []*config.Project
+	seen := map[string]bool{}
+
+	for _, path := range paths {
+		root, err := output.Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not load Infracost JSON file %s: %w", path, err)
+		}
+
+		for _, p := range root.Projects {
+			if p.Metadata == nil || p.Metadata.Path == "" {
+				continue
+			}
+
+			key := p.Metadata.Path + "|" + p.Metadata.TerraformWorkspace
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			projects = append(projects, &config.Project{
+				Path:               p.Metadata.Path,
+				Name:               p.Name,
+				TerraformWorkspace: p.Metadata.TerraformWorkspace,
+			})
+		}
+	}
+
+	if len(projects) == 0 {
+		return nil, errors.New("no projects with paths were found in the Infracost JSON files")
+	}
+
+	return projects, nil
+}
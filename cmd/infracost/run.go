@@ -25,8 +25,10 @@ import (
 	"github.com/infracost/infracost/internal/clierror"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/policy"
 	"github.com/infracost/infracost/internal/prices"
 	"github.com/infracost/infracost/internal/providers"
+	"github.com/infracost/infracost/internal/recommendations"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/infracost/infracost/internal/usage"
@@ -68,6 +70,7 @@ func addRunFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("no-cache", false, "Don't attempt to cache Terraform plans")
 
 	cmd.Flags().Bool("show-skipped", false, "List unsupported and free resources")
+	cmd.Flags().Bool("recommendations", false, "Check resources against cost best practices and show the potential monthly savings")
 
 	cmd.Flags().Bool("sync-usage-file", false, "Sync usage-file with missing resources, needs usage-file too (experimental)")
 
@@ -147,12 +150,19 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 		return errors.New("The --compare-to option cannot be used with table and html formats as they output breakdowns, specify a different --format.")
 	}
 
+	policyOutput, policyErr := checkPolicies(cmd, runCtx, &r, projects)
+	if policyErr != nil && isErrorUnhandled(policyErr) {
+		return policyErr
+	}
+
 	b, err := output.FormatOutput(format, r, output.Options{
 		DashboardEndpoint: runCtx.Config.DashboardEndpoint,
 		ShowSkipped:       runCtx.Config.ShowSkipped,
 		NoColor:           runCtx.Config.NoColor,
 		Fields:            runCtx.Config.Fields,
 		CurrencyFormat:    runCtx.Config.CurrencyFormat,
+		TemplatePath:      runCtx.Config.TemplatePath,
+		PolicyOutput:      policyOutput,
 	})
 	if err != nil {
 		return err
@@ -184,7 +194,49 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 		cmd.Println(string(b))
 	}
 
-	return nil
+	return policyErr
+}
+
+// checkPolicies evaluates the Rego policies given by the --policy-path flag
+// and the budgets and tag policies in the config file against the output of
+// the run. The results of the tag policies are added to the output so they
+// are included in the JSON. projects are used to add the planned resources to
+// the policy input and can be nil if the output was loaded from Infracost
+// JSON. The returned error contains the policy, budget or tag policy failures
+// if there are any.
+func checkPolicies(cmd *cobra.Command, runCtx *config.RunContext, r *output.Root, projects []*schema.Project) (output.PolicyOutput, error) {
+	var policyChecks output.PolicyCheck
+	policyPaths, _ := cmd.Flags().GetStringArray("policy-path")
+	if len(policyPaths) > 0 {
+		var err error
+		policyChecks, err = policy.Evaluate(runCtx.Context(), policyPaths, policy.NewInput(*r, projects))
+		if err != nil {
+			return output.PolicyOutput{}, err
+		}
+
+		runCtx.ContextValues.SetValue("passedPolicyCount", len(policyChecks.Passed))
+		runCtx.ContextValues.SetValue("failedPolicyCount", len(policyChecks.Failures))
+		runCtx.ContextValues.SetValue("warnedPolicyCount", len(policyChecks.Warnings))
+	}
+
+	budgetCheck := output.CheckBudgets(*r, runCtx.Config.Budgets)
+	r.TagPolicies = output.CheckTagPolicies(*r, runCtx.Config.TagPolicies)
+
+	policyOutput := output.NewPolicyOutput(policyChecks)
+	policyOutput.AddBudgetCheck(budgetCheck)
+	policyOutput.AddTagPolicyResults(r.TagPolicies)
+
+	if policyChecks.HasFailed() {
+		return policyOutput, policyChecks.Failures
+	}
+	if budgetCheck.HasFailed() {
+		return policyOutput, budgetCheck.Failures
+	}
+	if tagFailures := output.NewTagPolicyFailures(r.TagPolicies); len(tagFailures) > 0 {
+		return policyOutput, tagFailures
+	}
+
+	return policyOutput, nil
 }
 
 type projectOutput struct {
@@ -397,30 +449,9 @@ func (r *parallelRunner) runProvider(job projectJob) (*projectOutput, error) {
 	}
 
 	// Merge wildcard usages into individual usage
-	wildCardUsage := make(map[string]*usage.ResourceUsage)
-	for _, us := range usageFile.ResourceUsages {
-		if strings.HasSuffix(us.Name, "[*]") {
-			lastIndexOfOpenBracket := strings.LastIndex(us.Name, "[")
-			prefixName := us.Name[:lastIndexOfOpenBracket]
-			wildCardUsage[prefixName] = us
-		}
-	}
+	usage.MergeWildcardResourceUsages(usageFile.ResourceUsages)
 
-	for _, us := range usageFile.ResourceUsages {
-		if strings.HasSuffix(us.Name, "[*]") {
-			continue
-		}
-
-		if !strings.HasSuffix(us.Name, "]") {
-			continue
-		}
-		lastIndexOfOpenBracket := strings.LastIndex(us.Name, "[")
-		prefixName := us.Name[:lastIndexOfOpenBracket]
-
-		us.MergeResourceUsage(wildCardUsage[prefixName])
-	}
-
-	usageData := usageFile.ToUsageDataMap()
+	usageData := usageFile.ExpectedUsageDataMap()
 	out := &projectOutput{}
 
 	t1 := time.Now()
@@ -432,7 +463,7 @@ func (r *parallelRunner) runProvider(job projectJob) (*projectOutput, error) {
 
 	_ = r.uploadCloudResourceIDs(projects)
 
-	r.buildResources(projects)
+	projectPtrToUsageMap := r.buildResources(projects)
 
 	spinnerOpts := ui.SpinnerOptions{
 		EnableLogging: r.runCtx.Config.IsLogging(),
@@ -476,6 +507,30 @@ func (r *parallelRunner) runProvider(job projectJob) (*projectOutput, error) {
 		schema.CalculateCosts(project)
 
 		project.CalculateDiff()
+
+		if r.runCtx.Config.Recommendations {
+			if err := recommendations.Populate(r.runCtx, project); err != nil {
+				log.Warn().Msgf("Error checking recommendations for project %s: %v", project.Name, err)
+			}
+		}
+	}
+
+	if len(usageFile.UsageProfiles) > 0 {
+		err := r.populateUsageProfiles(usageFile, projects, projectPtrToUsageMap)
+		if err != nil {
+			spinner.Fail()
+			r.cmd.PrintErrln()
+			return nil, err
+		}
+	}
+
+	if r.runCtx.Config.ForecastMonths > 0 {
+		err := r.populateForecast(usageFile, projects, projectPtrToUsageMap)
+		if err != nil {
+			spinner.Fail()
+			r.cmd.PrintErrln()
+			return nil, err
+		}
 	}
 
 	t2 := time.Now()
@@ -535,13 +590,93 @@ func (r *parallelRunner) hasCloudResourceIDToUpload(projects []*schema.Project)
 	return false
 }
 
-func (r *parallelRunner) buildResources(projects []*schema.Project) {
+// buildResources builds the resources of the projects and returns the usage
+// estimates from Infracost Cloud that were used, if any.
+func (r *parallelRunner) buildResources(projects []*schema.Project) map[*schema.Project]schema.UsageMap {
 	var projectPtrToUsageMap map[*schema.Project]schema.UsageMap
 	if r.runCtx.Config.UsageAPIEndpoint != "" {
 		projectPtrToUsageMap = r.fetchProjectUsage(projects)
 	}
 
 	schema.BuildResources(projects, projectPtrToUsageMap)
+
+	return projectPtrToUsageMap
+}
+
+// populateUsageProfiles calculates the costs of the projects with the usage of
+// each usage profile in the usage file, so the outputs can show the range of
+// costs. The usage estimates from Infracost Cloud are reused for the usage that
+// the profiles don't define.
+func (r *parallelRunner) populateUsageProfiles(usageFile *usage.UsageFile, projects []*schema.Project, projectPtrToUsageMap map[*schema.Project]schema.UsageMap) error {
+	expectedUsage := usageFile.ExpectedUsageDataMap()
+
+	for _, name := range usageFile.UsageProfileNames() {
+		if name == schema.ExpectedUsageProfile {
+			for _, project := range projects {
+				project.UsageProfiles = append(project.UsageProfiles, &schema.UsageProfile{Name: name, Resources: project.Resources})
+			}
+
+			continue
+		}
+
+		profileUsage := usageFile.ProfileUsageDataMap(name)
+
+		for _, project := range projects {
+			resources, rebuilt := project.RebuildResources(expectedUsage, profileUsage, projectPtrToUsageMap[project])
+			if len(rebuilt) > 0 {
+				changed := &schema.Project{Resources: rebuilt}
+				if err := prices.PopulatePrices(r.runCtx, changed); err != nil {
+					return fmt.Errorf("Error retrieving prices for usage profile %s: %w", name, err)
+				}
+				schema.CalculateCosts(changed)
+			}
+
+			project.UsageProfiles = append(project.UsageProfiles, &schema.UsageProfile{Name: name, Resources: resources})
+		}
+	}
+
+	return nil
+}
+
+// populateForecast calculates the costs of the projects for each month of the
+// forecast with the growth rates from the usage file applied to the usage, so
+// the tiered prices reflect the usage of the month. The current resources are
+// used for every month if the usage file doesn't define any growth rates.
+func (r *parallelRunner) populateForecast(usageFile *usage.UsageFile, projects []*schema.Project, projectPtrToUsageMap map[*schema.Project]schema.UsageMap) error {
+	currentUsage, err := usageFile.ForecastUsageDataMap(0)
+	if err != nil {
+		return err
+	}
+
+	for month := 0; month < r.runCtx.Config.ForecastMonths; month++ {
+		if month == 0 || !usageFile.HasUsageGrowth() {
+			for _, project := range projects {
+				project.Forecast = append(project.Forecast, &schema.ForecastMonth{Month: month, Resources: project.Resources})
+			}
+
+			continue
+		}
+
+		monthUsage, err := usageFile.ForecastUsageDataMap(month)
+		if err != nil {
+			return err
+		}
+
+		for _, project := range projects {
+			resources, rebuilt := project.RebuildResources(currentUsage, monthUsage, projectPtrToUsageMap[project])
+			if len(rebuilt) > 0 {
+				changed := &schema.Project{Resources: rebuilt}
+				if err := prices.PopulatePrices(r.runCtx, changed); err != nil {
+					return fmt.Errorf("Error retrieving prices for forecast month %d: %w", month+1, err)
+				}
+				schema.CalculateCosts(changed)
+			}
+
+			project.Forecast = append(project.Forecast, &schema.ForecastMonth{Month: month, Resources: resources})
+		}
+	}
+
+	return nil
 }
 
 func (r *parallelRunner) fetchProjectUsage(projects []*schema.Project) map[*schema.Project]schema.UsageMap {
@@ -780,7 +915,10 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 
 	cfg.NoCache, _ = cmd.Flags().GetBool("no-cache")
 	cfg.Format, _ = cmd.Flags().GetString("format")
+	cfg.TemplatePath, _ = cmd.Flags().GetString("template-path")
 	cfg.ShowSkipped, _ = cmd.Flags().GetBool("show-skipped")
+	cfg.Recommendations, _ = cmd.Flags().GetBool("recommendations")
+	cfg.ForecastMonths, _ = cmd.Flags().GetInt("forecast-months")
 	cfg.SyncUsageFile, _ = cmd.Flags().GetBool("sync-usage-file")
 
 	includeAllFields := "all"
@@ -830,6 +968,14 @@ func tfVarsToMap(vars []string) map[string]string {
 }
 
 func checkRunConfig(warningWriter io.Writer, cfg *config.Config) error {
+	if cfg.Format == "template" && cfg.TemplatePath == "" {
+		return errors.New("--template-path is required when using --format template")
+	}
+
+	if cfg.ForecastMonths != 0 && cfg.ForecastMonths != 12 && cfg.ForecastMonths != 36 {
+		return errors.New("--forecast-months must be 12 or 36")
+	}
+
 	if cfg.Format == "json" && cfg.ShowSkipped {
 		ui.PrintWarning(warningWriter, "show-skipped is not needed with JSON output format as that always includes them.\n")
 	}
@@ -0,0 +1,18 @@
+package infracost
+
+deny[out] {
+	maxDiff := 20
+
+	out := {
+		"msg": sprintf("Total monthly cost diff must be less than $%v (actual diff is $%v)", [maxDiff, input.diffTotalMonthlyCost]),
+		"failed": to_number(input.diffTotalMonthlyCost) >= maxDiff,
+	}
+}
+
+warn[msg] {
+	p := input.projects[_]
+	r := p.breakdown.resources[_]
+	not r.tags.Environment
+
+	msg := sprintf("%s is missing the Environment tag", [r.name])
+}
@@ -5,7 +5,6 @@ import (
 	"crypto/x509"
 	"encoding/gob"
 	"fmt"
-	"math"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -40,6 +39,10 @@ type PricingAPIClient struct {
 	cacheFile string
 
 	cache *lru.TwoQueueCache[uint64, cacheValue]
+
+	// recorder stores the result of every price query so that a PriceSnapshot
+	// can be built from live prices.
+	recorder *PriceSnapshot
 }
 
 type cacheValue struct {
@@ -126,7 +129,7 @@ func GetPricingAPIClient(ctx *config.RunContext) *PricingAPIClient {
 			uuid:       ctx.UUID(),
 		},
 		Currency:       currency,
-		EventsDisabled: ctx.Config.EventsDisabled,
+		EventsDisabled: ctx.Config.EventsDisabled || ctx.Config.IsOfflinePricing(),
 	}
 
 	initCache(ctx, c)
@@ -135,7 +138,7 @@ func GetPricingAPIClient(ctx *config.RunContext) *PricingAPIClient {
 }
 
 func initCache(ctx *config.RunContext, c *PricingAPIClient) {
-	if ctx.Config.PricingCacheDisabled {
+	if ctx.Config.PricingCacheDisabled || ctx.Config.IsOfflinePricing() {
 		return
 	}
 
@@ -217,6 +220,12 @@ func (c *PricingAPIClient) FlushCache() error {
 	return gob.NewEncoder(f).Encode(storedCached)
 }
 
+// RecordSnapshot configures the client to record the results of all
+// subsequent price queries into s.
+func (c *PricingAPIClient) RecordSnapshot(s *PriceSnapshot) {
+	c.recorder = s
+}
+
 func (c *PricingAPIClient) AddEvent(name string, env map[string]interface{}) error {
 	if c.EventsDisabled {
 		return nil
@@ -259,36 +268,39 @@ func (c *PricingAPIClient) buildQuery(product *schema.ProductFilter, price *sche
 	return GraphQLQuery{query, v}
 }
 
-// BatchRequests batches all the queries for these resources so we can use less GraphQL requests
-// Use PriceQueryKeys to keep track of which query maps to which sub-resource and price component.
-func (c *PricingAPIClient) BatchRequests(resources []*schema.Resource, batchSize int) []BatchRequest {
-	reqs := make([]BatchRequest, 0)
-
+// PriceQueryKeys returns a PriceQueryKey for every cost component of the
+// resources and their sub-resources.
+func PriceQueryKeys(resources []*schema.Resource) []PriceQueryKey {
 	keys := make([]PriceQueryKey, 0)
-	queries := make([]GraphQLQuery, 0)
 
 	for _, r := range resources {
 		for _, component := range r.CostComponents {
 			keys = append(keys, PriceQueryKey{r, component})
-			queries = append(queries, c.buildQuery(component.ProductFilter, component.PriceFilter))
 		}
 
 		for _, subresource := range r.FlattenedSubResources() {
 			for _, component := range subresource.CostComponents {
 				keys = append(keys, PriceQueryKey{subresource, component})
-				queries = append(queries, c.buildQuery(component.ProductFilter, component.PriceFilter))
 			}
 		}
 	}
 
-	for i := 0; i < len(queries); i += batchSize {
-		keysEnd := int64(math.Min(float64(i+batchSize), float64(len(keys))))
-		queriesEnd := int64(math.Min(float64(i+batchSize), float64(len(queries))))
+	return keys
+}
 
-		reqs = append(reqs, BatchRequest{keys[i:keysEnd], queries[i:queriesEnd]})
+func (c *PricingAPIClient) buildQueries(keys []PriceQueryKey) []GraphQLQuery {
+	queries := make([]GraphQLQuery, len(keys))
+	for i, key := range keys {
+		queries[i] = c.buildQuery(key.CostComponent.ProductFilter, key.CostComponent.PriceFilter)
 	}
 
-	return reqs
+	return queries
+}
+
+// GetPrices fetches the prices for the given keys from the Cloud Pricing API.
+// A result is returned for each key, in the same order as the keys.
+func (c *PricingAPIClient) GetPrices(keys []PriceQueryKey) ([]PriceQueryResult, error) {
+	return c.PerformRequest(BatchRequest{keys: keys, queries: c.buildQueries(keys)})
 }
 
 type pricingQuery struct {
@@ -404,5 +416,11 @@ func (c *PricingAPIClient) PerformRequest(req BatchRequest) ([]PriceQueryResult,
 		}
 	}
 
+	if c.recorder != nil {
+		for _, re := range res {
+			c.recorder.Record(re.CostComponent.ProductFilter, re.CostComponent.PriceFilter, re.Result)
+		}
+	}
+
 	return res, nil
 }
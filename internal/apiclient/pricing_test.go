@@ -118,8 +118,8 @@ func TestPricingAPIClient_PerformRequest(t *testing.T) {
 	assert.NoError(t, err)
 	c.cache.Add(k, cacheValue{Result: gjson.Parse(`{"data":{"products":[{"prices":[{"priceHash":"cached-ee3dd7e4624338037ca6fea0933a662f","USD":"0.1250000000"}]}]}`), ExpiresAt: time.Now().Add(time.Hour)})
 
-	batches := c.BatchRequests(resources, 100)
-	result, err := c.PerformRequest(batches[0])
+	keys := PriceQueryKeys(resources)
+	result, err := c.GetPrices(keys)
 
 	assert.Len(t, requestMap, 1, "invalid number of requests made to pricing API")
 	assert.JSONEq(
@@ -156,7 +156,7 @@ func TestPricingAPIClient_PerformRequest(t *testing.T) {
 	assertResults(t, result)
 
 	// try the request again to ensure we cache everything now
-	result, err = c.PerformRequest(batches[0])
+	result, err = c.GetPrices(keys)
 	assert.NoError(t, err)
 	assertResults(t, result)
 }
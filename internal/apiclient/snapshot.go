@@ -0,0 +1,562 @@
+package apiclient
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dlclark/regexp2"
+	json "github.com/json-iterator/go"
+	"github.com/mitchellh/hashstructure/v2"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/logging"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// PriceSnapshotVersion is the version of the price snapshot file format. It is
+// bumped whenever the file layout changes in a way that older CLIs can't read.
+var PriceSnapshotVersion = "0.1"
+
+// PriceSnapshot is a local, versioned copy of Cloud Pricing API data that can
+// be used to resolve price queries without network access. A snapshot holds
+// two kinds of data:
+//
+//  1. Products, which are full product records (as found in a Cloud Pricing
+//     API export) that are matched against the product and price filters of a
+//     query, in the same way the Cloud Pricing API does.
+//  2. Queries, which are recorded Cloud Pricing API results for an exact pair of
+//     product and price filters. These are built by running projects against
+//     the live API and take precedence over matched products.
+type PriceSnapshot struct {
+	Version   string            `json:"version"`
+	Currency  string            `json:"currency"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Products  []SnapshotProduct `json:"products,omitempty"`
+	Queries   []SnapshotQuery   `json:"queries,omitempty"`
+
+	mu           sync.Mutex
+	queryIndex   map[uint64]int
+	productIndex map[string][]int
+	regexes      map[string]*regexp2.Regexp
+}
+
+// SnapshotProduct is a single product record with all its prices.
+type SnapshotProduct struct {
+	ProductHash   string            `json:"productHash,omitempty"`
+	Sku           string            `json:"sku,omitempty"`
+	VendorName    string            `json:"vendorName"`
+	Service       string            `json:"service"`
+	ProductFamily string            `json:"productFamily"`
+	Region        string            `json:"region"`
+	Attributes    map[string]string `json:"attributes"`
+	Prices        []SnapshotPrice   `json:"prices"`
+}
+
+// SnapshotPrice is a price of a SnapshotProduct. Price is in the currency
+// of the PriceSnapshot.
+type SnapshotPrice struct {
+	PriceHash          string `json:"priceHash"`
+	Price              string `json:"price"`
+	PurchaseOption     string `json:"purchaseOption,omitempty"`
+	Unit               string `json:"unit,omitempty"`
+	Description        string `json:"description,omitempty"`
+	StartUsageAmount   string `json:"startUsageAmount,omitempty"`
+	EndUsageAmount     string `json:"endUsageAmount,omitempty"`
+	TermLength         string `json:"termLength,omitempty"`
+	TermPurchaseOption string `json:"termPurchaseOption,omitempty"`
+	TermOfferingClass  string `json:"termOfferingClass,omitempty"`
+}
+
+// SnapshotQuery is a recorded result of a Cloud Pricing API query.
+type SnapshotQuery struct {
+	ProductFilter *schema.ProductFilter `json:"productFilter"`
+	PriceFilter   *schema.PriceFilter   `json:"priceFilter,omitempty"`
+	Result        json.RawMessage       `json:"result"`
+}
+
+type snapshotQueryKey struct {
+	ProductFilter *schema.ProductFilter
+	PriceFilter   *schema.PriceFilter
+}
+
+// NewPriceSnapshot returns an empty PriceSnapshot for the given currency.
+func NewPriceSnapshot(currency string) *PriceSnapshot {
+	if currency == "" {
+		currency = "USD"
+	}
+
+	return &PriceSnapshot{
+		Version:   PriceSnapshotVersion,
+		Currency:  currency,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// LoadPriceSnapshot reads a PriceSnapshot from path. The file can either be
+// plain JSON or gzip compressed JSON.
+func LoadPriceSnapshot(path string) (*PriceSnapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open price snapshot %s: %w", path, err)
+	}
+	defer f.Close()
+
+	r, err := decompressReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not read price snapshot %s: %w", path, err)
+	}
+
+	var s PriceSnapshot
+	err = json.NewDecoder(r).Decode(&s)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode price snapshot %s: %w", path, err)
+	}
+
+	if s.Version != PriceSnapshotVersion {
+		return nil, fmt.Errorf("price snapshot %s has version %q, this version of Infracost only supports %q", path, s.Version, PriceSnapshotVersion)
+	}
+
+	s.buildIndexes()
+
+	return &s, nil
+}
+
+// NewPriceSnapshotFromExport builds a PriceSnapshot from a Cloud Pricing API
+// data export. The export is a CSV file (optionally gzip compressed) with the
+// columns vendorName, service, productFamily, region, sku, attributes and
+// prices, where attributes and prices are JSON encoded. Only USD prices are
+// included in an export.
+func NewPriceSnapshotFromExport(r io.Reader) (*PriceSnapshot, error) {
+	dr, err := decompressReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cr := csv.NewReader(dr)
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read export header: %w", err)
+	}
+
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[strings.TrimSpace(h)] = i
+	}
+
+	for _, required := range []string{"vendorName", "service", "productFamily", "region", "attributes", "prices"} {
+		if _, ok := cols[required]; !ok {
+			return nil, fmt.Errorf("export is missing required column %q", required)
+		}
+	}
+
+	col := func(record []string, name string) string {
+		i, ok := cols[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+
+		return record[i]
+	}
+
+	s := NewPriceSnapshot("USD")
+	now := time.Now()
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read export record: %w", err)
+		}
+
+		p := SnapshotProduct{
+			ProductHash:   col(record, "productHash"),
+			Sku:           col(record, "sku"),
+			VendorName:    col(record, "vendorName"),
+			Service:       col(record, "service"),
+			ProductFamily: col(record, "productFamily"),
+			Region:        col(record, "region"),
+			Attributes:    map[string]string{},
+		}
+
+		gjson.Parse(col(record, "attributes")).ForEach(func(key, value gjson.Result) bool {
+			p.Attributes[key.String()] = value.String()
+			return true
+		})
+
+		// prices are keyed by price hash, each key contains a list of price
+		// records, one per effective date. Only the price that is currently
+		// effective is kept, as the Cloud Pricing API does.
+		gjson.Parse(col(record, "prices")).ForEach(func(_, prices gjson.Result) bool {
+			price, ok := latestEffectivePrice(prices.Array(), now)
+			if !ok {
+				return true
+			}
+
+			p.Prices = append(p.Prices, SnapshotPrice{
+				PriceHash:          price.Get("priceHash").String(),
+				Price:              price.Get("USD").String(),
+				PurchaseOption:     price.Get("purchaseOption").String(),
+				Unit:               price.Get("unit").String(),
+				Description:        price.Get("description").String(),
+				StartUsageAmount:   price.Get("startUsageAmount").String(),
+				EndUsageAmount:     price.Get("endUsageAmount").String(),
+				TermLength:         price.Get("termLength").String(),
+				TermPurchaseOption: price.Get("termPurchaseOption").String(),
+				TermOfferingClass:  price.Get("termOfferingClass").String(),
+			})
+			return true
+		})
+
+		s.Products = append(s.Products, p)
+	}
+
+	s.buildIndexes()
+
+	return s, nil
+}
+
+// latestEffectivePrice returns the price record with the latest
+// effectiveDateStart that is not after now. Records without an
+// effectiveDateStart are treated as effective since the beginning of time.
+func latestEffectivePrice(records []gjson.Result, now time.Time) (gjson.Result, bool) {
+	var latest gjson.Result
+	var latestStart time.Time
+	found := false
+
+	for _, r := range records {
+		start := r.Get("effectiveDateStart").Time()
+		if start.After(now) {
+			continue
+		}
+
+		if !found || start.After(latestStart) {
+			latest, latestStart, found = r, start, true
+		}
+	}
+
+	return latest, found
+}
+
+// Write writes the snapshot as gzip compressed JSON to path.
+func (s *PriceSnapshot) Write(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// sort the recorded queries so that snapshots built from the same projects
+	// are identical regardless of the order the prices were fetched in.
+	keyed := make([]struct {
+		key uint64
+		q   SnapshotQuery
+	}, len(s.Queries))
+	for k, i := range s.queryIndex {
+		keyed[i].key = k
+	}
+	for i, q := range s.Queries {
+		keyed[i].q = q
+	}
+	sort.SliceStable(keyed, func(i, j int) bool {
+		return keyed[i].key < keyed[j].key
+	})
+	for i, kq := range keyed {
+		s.Queries[i] = kq.q
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create price snapshot %s: %w", path, err)
+	}
+	defer f.Close()
+
+	zw := gzip.NewWriter(f)
+	err = json.NewEncoder(zw).Encode(s)
+	if err != nil {
+		return fmt.Errorf("could not encode price snapshot: %w", err)
+	}
+
+	err = zw.Close()
+	if err != nil {
+		return fmt.Errorf("could not compress price snapshot: %w", err)
+	}
+
+	// sorting the queries invalidates the index.
+	s.buildQueryIndex()
+
+	return nil
+}
+
+// Merge adds all the products and queries from other to the snapshot.
+func (s *PriceSnapshot) Merge(other *PriceSnapshot) error {
+	if other.Currency != s.Currency {
+		return fmt.Errorf("cannot merge price snapshot in %s into a snapshot in %s", other.Currency, s.Currency)
+	}
+
+	for _, q := range other.Queries {
+		s.Record(q.ProductFilter, q.PriceFilter, gjson.ParseBytes(q.Result))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Products = append(s.Products, other.Products...)
+	s.buildProductIndex()
+
+	return nil
+}
+
+// Record stores the result of a Cloud Pricing API query in the snapshot so
+// that the exact same query can be resolved offline.
+func (s *PriceSnapshot) Record(product *schema.ProductFilter, price *schema.PriceFilter, result gjson.Result) {
+	key, err := snapshotKey(product, price)
+	if err != nil {
+		logging.Logger.Debug().Err(err).Msg("failed to hash price snapshot query, skipping")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.queryIndex == nil {
+		s.queryIndex = map[uint64]int{}
+	}
+
+	q := SnapshotQuery{
+		ProductFilter: product,
+		PriceFilter:   price,
+		Result:        json.RawMessage(result.Raw),
+	}
+
+	if i, ok := s.queryIndex[key]; ok {
+		s.Queries[i] = q
+		return
+	}
+
+	s.queryIndex[key] = len(s.Queries)
+	s.Queries = append(s.Queries, q)
+}
+
+// Lookup resolves the product and price filters against the snapshot. The
+// result has the same shape as a Cloud Pricing API GraphQL response so that
+// it can be used interchangeably with results from the API.
+func (s *PriceSnapshot) Lookup(product *schema.ProductFilter, price *schema.PriceFilter) gjson.Result {
+	key, err := snapshotKey(product, price)
+	if err == nil {
+		s.mu.Lock()
+		i, ok := s.queryIndex[key]
+		var raw json.RawMessage
+		if ok {
+			raw = s.Queries[i].Result
+		}
+		s.mu.Unlock()
+
+		if ok {
+			return gjson.ParseBytes(raw)
+		}
+	}
+
+	products := []map[string]interface{}{}
+	for _, i := range s.candidateProducts(product) {
+		p := s.Products[i]
+		if !s.matchProduct(p, product) {
+			continue
+		}
+
+		prices := []map[string]string{}
+		for _, pr := range p.Prices {
+			if !s.matchPrice(pr, price) {
+				continue
+			}
+
+			prices = append(prices, map[string]string{
+				"priceHash": pr.PriceHash,
+				s.Currency:  pr.Price,
+			})
+		}
+
+		products = append(products, map[string]interface{}{"prices": prices})
+	}
+
+	b, err := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{
+			"products": products,
+		},
+	})
+	if err != nil {
+		logging.Logger.Debug().Err(err).Msg("failed to marshal price snapshot result")
+		return gjson.Parse(`{"data":{"products":[]}}`)
+	}
+
+	return gjson.ParseBytes(b)
+}
+
+func (s *PriceSnapshot) buildIndexes() {
+	s.buildQueryIndex()
+	s.buildProductIndex()
+}
+
+func (s *PriceSnapshot) buildQueryIndex() {
+	s.queryIndex = make(map[uint64]int, len(s.Queries))
+	for i, q := range s.Queries {
+		key, err := snapshotKey(q.ProductFilter, q.PriceFilter)
+		if err != nil {
+			continue
+		}
+
+		s.queryIndex[key] = i
+	}
+}
+
+func (s *PriceSnapshot) buildProductIndex() {
+	s.productIndex = map[string][]int{}
+	for i, p := range s.Products {
+		k := productIndexKey(p.VendorName, p.Service)
+		s.productIndex[k] = append(s.productIndex[k], i)
+	}
+}
+
+func (s *PriceSnapshot) candidateProducts(product *schema.ProductFilter) []int {
+	if product != nil && product.VendorName != nil && product.Service != nil {
+		return s.productIndex[productIndexKey(*product.VendorName, *product.Service)]
+	}
+
+	all := make([]int, len(s.Products))
+	for i := range s.Products {
+		all[i] = i
+	}
+
+	return all
+}
+
+func (s *PriceSnapshot) matchProduct(p SnapshotProduct, filter *schema.ProductFilter) bool {
+	if filter == nil {
+		return true
+	}
+
+	if !matchValue(p.VendorName, filter.VendorName) ||
+		!matchValue(p.Service, filter.Service) ||
+		!matchValue(p.ProductFamily, filter.ProductFamily) ||
+		!matchValue(p.Region, filter.Region) ||
+		!matchValue(p.Sku, filter.Sku) {
+		return false
+	}
+
+	for _, af := range filter.AttributeFilters {
+		v, ok := p.Attributes[af.Key]
+		if !ok {
+			return false
+		}
+
+		if !matchValue(v, af.Value) || !s.matchRegex(v, af.ValueRegex) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *PriceSnapshot) matchPrice(p SnapshotPrice, filter *schema.PriceFilter) bool {
+	if filter == nil {
+		return true
+	}
+
+	return matchValue(p.PurchaseOption, filter.PurchaseOption) &&
+		matchValue(p.Unit, filter.Unit) &&
+		matchValue(p.Description, filter.Description) &&
+		s.matchRegex(p.Description, filter.DescriptionRegex) &&
+		matchValue(p.StartUsageAmount, filter.StartUsageAmount) &&
+		matchValue(p.EndUsageAmount, filter.EndUsageAmount) &&
+		matchValue(p.TermLength, filter.TermLength) &&
+		matchValue(p.TermPurchaseOption, filter.TermPurchaseOption) &&
+		matchValue(p.TermOfferingClass, filter.TermOfferingClass)
+}
+
+// matchRegex matches v against a filter regex. Filter regexes use the same
+// `/pattern/flags` syntax as the Cloud Pricing API. regexp2 is used instead of
+// the stdlib regexp so that lookarounds used by some filters are supported.
+func (s *PriceSnapshot) matchRegex(v string, filter *string) bool {
+	if filter == nil {
+		return true
+	}
+
+	s.mu.Lock()
+	re, ok := s.regexes[*filter]
+	if !ok {
+		re = compileFilterRegex(*filter)
+		if s.regexes == nil {
+			s.regexes = map[string]*regexp2.Regexp{}
+		}
+		s.regexes[*filter] = re
+	}
+	s.mu.Unlock()
+
+	if re == nil {
+		return false
+	}
+
+	m, err := re.MatchString(v)
+	return err == nil && m
+}
+
+func compileFilterRegex(filter string) *regexp2.Regexp {
+	pattern := filter
+	var opts regexp2.RegexOptions
+
+	if strings.HasPrefix(filter, "/") {
+		end := strings.LastIndex(filter, "/")
+		if end > 0 {
+			pattern = filter[1:end]
+			if strings.Contains(filter[end+1:], "i") {
+				opts |= regexp2.IgnoreCase
+			}
+		}
+	}
+
+	re, err := regexp2.Compile(pattern, opts)
+	if err != nil {
+		logging.Logger.Debug().Err(err).Msgf("invalid filter regex %s, treating as no match", filter)
+		return nil
+	}
+
+	return re
+}
+
+func matchValue(v string, filter *string) bool {
+	return filter == nil || *filter == v
+}
+
+func productIndexKey(vendorName, service string) string {
+	return vendorName + "/" + service
+}
+
+func snapshotKey(product *schema.ProductFilter, price *schema.PriceFilter) (uint64, error) {
+	return hashstructure.Hash(snapshotQueryKey{ProductFilter: product, PriceFilter: price}, hashstructure.FormatV2, nil)
+}
+
+// decompressReader returns a reader that transparently decompresses r if it
+// is gzip compressed.
+func decompressReader(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		return gzip.NewReader(br)
+	}
+
+	return br, nil
+}
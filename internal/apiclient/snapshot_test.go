@@ -0,0 +1,188 @@
+package apiclient
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+var testPriceExport = `productHash,sku,vendorName,region,service,productFamily,attributes,prices
+p1,SKU1,aws,us-east-1,AmazonEC2,Compute Instance,"{""instanceType"":""t3.micro"",""usagetype"":""BoxUsage:t3.micro"",""tenancy"":""Shared""}","{""h1"":[{""priceHash"":""h1"",""USD"":""0.0104000000"",""purchaseOption"":""on_demand"",""unit"":""Hrs""}],""h2"":[{""priceHash"":""h2"",""USD"":""0.0062000000"",""purchaseOption"":""reserved"",""unit"":""Hrs"",""termLength"":""1yr""}]}"
+p2,SKU2,aws,us-east-1,AmazonEC2,Compute Instance,"{""instanceType"":""t3.large"",""usagetype"":""BoxUsage:t3.large"",""tenancy"":""Shared""}","{""h3"":[{""priceHash"":""h3"",""USD"":""0.0832000000"",""purchaseOption"":""on_demand"",""unit"":""Hrs""}]}"
+p3,SKU3,aws,us-east-1,awswaf,Web Application Firewall,"{""usagetype"":""USE1-ShieldProtected-WebACL""}","{""h4"":[{""priceHash"":""h4"",""USD"":""0"",""purchaseOption"":""on_demand"",""unit"":""WebACL""}]}"
+p4,SKU4,aws,us-east-1,awswaf,Web Application Firewall,"{""usagetype"":""USE1-WebACL""}","{""h5"":[{""priceHash"":""h5"",""USD"":""5"",""purchaseOption"":""on_demand"",""unit"":""WebACL""}]}"
+`
+
+func TestPriceSnapshot_Lookup(t *testing.T) {
+	s, err := NewPriceSnapshotFromExport(strings.NewReader(testPriceExport))
+	require.NoError(t, err)
+	require.Len(t, s.Products, 4)
+
+	tests := []struct {
+		name    string
+		product *schema.ProductFilter
+		price   *schema.PriceFilter
+		want    string
+	}{
+		{
+			name: "exact attribute",
+			product: &schema.ProductFilter{
+				VendorName: strPtr("aws"),
+				Service:    strPtr("AmazonEC2"),
+				Region:     strPtr("us-east-1"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "instanceType", Value: strPtr("t3.micro")},
+				},
+			},
+			price: &schema.PriceFilter{PurchaseOption: strPtr("on_demand")},
+			want:  `{"data":{"products":[{"prices":[{"USD":"0.0104000000","priceHash":"h1"}]}]}}`,
+		},
+		{
+			name: "regex attribute",
+			product: &schema.ProductFilter{
+				VendorName: strPtr("aws"),
+				Service:    strPtr("AmazonEC2"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr("/boxusage:t3.LARGE$/i")},
+				},
+			},
+			want: `{"data":{"products":[{"prices":[{"USD":"0.0832000000","priceHash":"h3"}]}]}}`,
+		},
+		{
+			name: "regex with lookahead",
+			product: &schema.ProductFilter{
+				VendorName: strPtr("aws"),
+				Service:    strPtr("awswaf"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr("/^[A-Z0-9]*-(?!ShieldProtected-)WebACL$/i")},
+				},
+			},
+			want: `{"data":{"products":[{"prices":[{"USD":"5","priceHash":"h5"}]}]}}`,
+		},
+		{
+			name: "product without matching prices",
+			product: &schema.ProductFilter{
+				VendorName: strPtr("aws"),
+				Service:    strPtr("AmazonEC2"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "instanceType", Value: strPtr("t3.large")},
+				},
+			},
+			price: &schema.PriceFilter{PurchaseOption: strPtr("reserved")},
+			want:  `{"data":{"products":[{"prices":[]}]}}`,
+		},
+		{
+			name: "no products",
+			product: &schema.ProductFilter{
+				VendorName: strPtr("aws"),
+				Service:    strPtr("AmazonRDS"),
+			},
+			want: `{"data":{"products":[]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.JSONEq(t, tt.want, s.Lookup(tt.product, tt.price).Raw)
+		})
+	}
+}
+
+func TestPriceSnapshot_EffectiveDates(t *testing.T) {
+	export := `productHash,sku,vendorName,region,service,productFamily,attributes,prices
+p1,SKU1,aws,us-east-1,AmazonEC2,Compute Instance,"{""instanceType"":""t3.micro""}","{""h1"":[{""priceHash"":""h1"",""USD"":""0.0100000000"",""purchaseOption"":""on_demand"",""unit"":""Hrs"",""effectiveDateStart"":""2021-01-01T00:00:00.000Z""},{""priceHash"":""h1"",""USD"":""0.0104000000"",""purchaseOption"":""on_demand"",""unit"":""Hrs"",""effectiveDateStart"":""2023-06-01T00:00:00.000Z""},{""priceHash"":""h1"",""USD"":""0.0102000000"",""purchaseOption"":""on_demand"",""unit"":""Hrs"",""effectiveDateStart"":""2022-03-01T00:00:00.000Z""},{""priceHash"":""h1"",""USD"":""0.0200000000"",""purchaseOption"":""on_demand"",""unit"":""Hrs"",""effectiveDateStart"":""2999-01-01T00:00:00.000Z""}],""h2"":[{""priceHash"":""h2"",""USD"":""0.0062000000"",""purchaseOption"":""reserved"",""unit"":""Hrs"",""effectiveDateStart"":""2999-01-01T00:00:00.000Z""}]}"
+`
+
+	s, err := NewPriceSnapshotFromExport(strings.NewReader(export))
+	require.NoError(t, err)
+	require.Len(t, s.Products, 1)
+	require.Len(t, s.Products[0].Prices, 1)
+	assert.Equal(t, "h1", s.Products[0].Prices[0].PriceHash)
+	assert.Equal(t, "0.0104000000", s.Products[0].Prices[0].Price)
+
+	product := &schema.ProductFilter{
+		VendorName: strPtr("aws"),
+		Service:    strPtr("AmazonEC2"),
+		AttributeFilters: []*schema.AttributeFilter{
+			{Key: "instanceType", Value: strPtr("t3.micro")},
+		},
+	}
+	assert.JSONEq(t, `{"data":{"products":[{"prices":[{"USD":"0.0104000000","priceHash":"h1"}]}]}}`, s.Lookup(product, nil).Raw)
+}
+
+func TestPriceSnapshot_RecordWriteLoad(t *testing.T) {
+	s, err := NewPriceSnapshotFromExport(strings.NewReader(testPriceExport))
+	require.NoError(t, err)
+
+	product := &schema.ProductFilter{
+		VendorName: strPtr("aws"),
+		Service:    strPtr("AmazonEC2"),
+		AttributeFilters: []*schema.AttributeFilter{
+			{Key: "instanceType", Value: strPtr("t3.micro")},
+		},
+	}
+	price := &schema.PriceFilter{PurchaseOption: strPtr("on_demand")}
+
+	recorded := `{"data":{"products":[{"prices":[{"priceHash":"recorded","USD":"0.0200000000"}]}]}}`
+	s.Record(product, price, gjson.Parse(recorded))
+
+	path := filepath.Join(t.TempDir(), "prices.json.gz")
+	require.NoError(t, s.Write(path))
+
+	loaded, err := LoadPriceSnapshot(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "USD", loaded.Currency)
+	assert.Len(t, loaded.Products, 4)
+	assert.JSONEq(t, recorded, loaded.Lookup(product, price).Raw)
+
+	// the same filters with different pointers should resolve to the recorded query.
+	assert.JSONEq(t, recorded, loaded.Lookup(
+		&schema.ProductFilter{
+			VendorName: strPtr("aws"),
+			Service:    strPtr("AmazonEC2"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "instanceType", Value: strPtr("t3.micro")},
+			},
+		},
+		&schema.PriceFilter{PurchaseOption: strPtr("on_demand")},
+	).Raw)
+}
+
+func TestPriceSnapshot_WriteDeterministic(t *testing.T) {
+	var products []*schema.ProductFilter
+	for _, instanceType := range []string{"t3.micro", "t3.small", "t3.medium", "m5.large", "m5.xlarge", "c5.large"} {
+		products = append(products, &schema.ProductFilter{
+			VendorName: strPtr("aws"),
+			Service:    strPtr("AmazonEC2"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "instanceType", Value: strPtr(instanceType)},
+			},
+		})
+	}
+
+	write := func(order []int) []byte {
+		s := NewPriceSnapshot("USD")
+		s.CreatedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		for _, i := range order {
+			s.Record(products[i], nil, gjson.Parse(`{"data":{"products":[]}}`))
+		}
+
+		path := filepath.Join(t.TempDir(), "prices.json.gz")
+		require.NoError(t, s.Write(path))
+
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return b
+	}
+
+	assert.Equal(t, write([]int{0, 1, 2, 3, 4, 5}), write([]int{5, 3, 1, 0, 4, 2}))
+}
@@ -3,6 +3,7 @@ package clierror
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 
@@ -170,3 +171,31 @@ func NewWarningF(warning string, args ...any) WarningError {
 func (w WarningError) Error() string {
 	return w.warning
 }
+
+const (
+	// ExitCodeError is the exit code used for errors that don't define their
+	// own exit code.
+	ExitCodeError = 1
+	// ExitCodeBudgetExceeded is the exit code used when the cost exceeds a
+	// budget in the config file, so CI pipelines can tell budget failures
+	// apart from other errors.
+	ExitCodeBudgetExceeded = 3
+)
+
+// ExitCoder is implemented by errors that exit the CLI with a specific
+// non-zero exit code.
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// ExitCode returns the exit code the CLI should exit with for err. This is
+// the code of the first error in the chain that implements ExitCoder, or
+// ExitCodeError otherwise.
+func ExitCode(err error) int {
+	var coder ExitCoder
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+
+	return ExitCodeError
+}
@@ -0,0 +1,376 @@
+package comment
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// giteaDefaultAPIURL is the URL of the Gitea API for gitea.com. Most Gitea and
+// Forgejo instances are self-hosted so this is usually overridden.
+var giteaDefaultAPIURL = "https://gitea.com"
+
+// giteaComment represents a comment on a Gitea or Forgejo pull request. It
+// implements the Comment interface.
+type giteaComment struct {
+	id        int64
+	body      string
+	createdAt string
+	url       string
+}
+
+// Body returns the body of the comment
+func (c *giteaComment) Body() string {
+	return c.body
+}
+
+// Ref returns the reference to the comment. For Gitea this is a URL to the
+// HTML page of the comment.
+func (c *giteaComment) Ref() string {
+	return c.url
+}
+
+// Less compares the comment to another comment and returns true if this
+// comment should be sorted before the other comment.
+func (c *giteaComment) Less(other Comment) bool {
+	j := other.(*giteaComment)
+
+	if c.createdAt != j.createdAt {
+		return c.createdAt < j.createdAt
+	}
+
+	return c.id < j.id
+}
+
+// IsHidden always returns false for Gitea since Gitea doesn't have a
+// feature for hiding comments.
+func (c *giteaComment) IsHidden() bool {
+	return false
+}
+
+// ValidAt returns the time the comment was tagged as being valid at
+func (c *giteaComment) ValidAt() *time.Time {
+	return extractValidAt(c.Body())
+}
+
+// GiteaExtra contains any extra inputs that can be passed to the Gitea comment
+// handlers. These are also used for Forgejo since it has the same API.
+type GiteaExtra struct {
+	// APIURL is the URL of the Gitea server, e.g. https://gitea.example.com.
+	// If not set, gitea.com will be used.
+	APIURL string
+	// Token is the Gitea access token.
+	Token string
+	// Tag used to identify the Infracost comment
+	Tag string
+	// TLSConfig is the TLS configuration to use when connecting to the Gitea API.
+	TLSConfig *tls.Config
+}
+
+// giteaAPIComment represents API response structure of a Gitea comment.
+type giteaAPIComment struct {
+	ID        int64  `json:"id"`
+	Body      string `json:"body"`
+	HTMLURL   string `json:"html_url"`
+	CreatedAt string `json:"created_at"`
+}
+
+// giteaAPIClient calls the Gitea REST API for a repository.
+type giteaAPIClient struct {
+	httpClient *http.Client
+	apiURL     string
+	token      string
+}
+
+// newGiteaAPIClient creates a client for the v1 API of the given repository.
+func newGiteaAPIClient(project string, extra GiteaExtra) (*giteaAPIClient, error) {
+	parts := strings.SplitN(project, "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid Gitea repository name: %s, expecting owner/repo", project)
+	}
+
+	apiURL := extra.APIURL
+	if apiURL == "" {
+		apiURL = giteaDefaultAPIURL
+	}
+
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing API URL")
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	if !strings.HasSuffix(u.Path, "/api/v1") {
+		u.Path += "/api/v1"
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = extra.TLSConfig
+
+	return &giteaAPIClient{
+		httpClient: &http.Client{Transport: transport},
+		apiURL:     fmt.Sprintf("%s/repos/%s/%s", u.String(), url.PathEscape(parts[0]), url.PathEscape(parts[1])),
+		token:      extra.Token,
+	}, nil
+}
+
+// do sends a request to the API path and decodes the response into out if it
+// is not nil. An error is returned if the response status doesn't match the
+// expected status.
+func (c *giteaAPIClient) do(ctx context.Context, method, path string, body interface{}, expectedStatus int, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return errors.Wrap(err, "Error marshaling request body")
+		}
+		reqBody = bytes.NewBuffer(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, reqBody)
+	if err != nil {
+		return errors.Wrap(err, "Error creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != expectedStatus {
+		return errors.Errorf("%s", res.Status)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return errors.Wrap(err, "Error reading response body")
+	}
+
+	err = json.Unmarshal(resBody, out)
+	if err != nil {
+		return errors.Wrap(err, "Error unmarshaling response body")
+	}
+
+	return nil
+}
+
+// giteaPRHandler is a PlatformHandler for Gitea pull requests. It
+// implements the PlatformHandler interface and contains the functions
+// for finding, creating, updating, deleting comments on Gitea pull requests.
+type giteaPRHandler struct {
+	client   *giteaAPIClient
+	prNumber int
+}
+
+// NewGiteaPRHandler creates a new CommentHandler for Gitea pull requests.
+func NewGiteaPRHandler(ctx context.Context, project, targetRef string, extra GiteaExtra) (*CommentHandler, error) {
+	prNumber, err := strconv.Atoi(targetRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing targetRef as pull request number")
+	}
+
+	client, err := newGiteaAPIClient(project, extra)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &giteaPRHandler{
+		client:   client,
+		prNumber: prNumber,
+	}
+
+	return NewCommentHandler(ctx, h, extra.Tag), nil
+}
+
+// CallFindMatchingComments calls the Gitea API to find the pull request
+// comments that match the given tag, which has been embedded at the beginning
+// of the comment.
+func (h *giteaPRHandler) CallFindMatchingComments(ctx context.Context, tag string) ([]Comment, error) {
+	// Pull request comments are issue comments in Gitea. This endpoint isn't
+	// paginated so returns all the comments.
+	var resData []giteaAPIComment
+	err := h.client.do(ctx, http.MethodGet, fmt.Sprintf("/issues/%d/comments", h.prNumber), nil, http.StatusOK, &resData)
+	if err != nil {
+		return []Comment{}, errors.Wrap(err, "Error getting comments")
+	}
+
+	var matchingComments []Comment
+	for _, c := range resData {
+		if hasTagKey(c.Body, tag) {
+			matchingComments = append(matchingComments, &giteaComment{
+				id:        c.ID,
+				body:      c.Body,
+				createdAt: c.CreatedAt,
+				url:       c.HTMLURL,
+			})
+		}
+	}
+
+	return matchingComments, nil
+}
+
+// CallCreateComment calls the Gitea API to create a new comment on the pull request.
+func (h *giteaPRHandler) CallCreateComment(ctx context.Context, body string) (Comment, error) {
+	var resData giteaAPIComment
+	err := h.client.do(ctx, http.MethodPost, fmt.Sprintf("/issues/%d/comments", h.prNumber), map[string]string{"body": body}, http.StatusCreated, &resData)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating comment")
+	}
+
+	return &giteaComment{
+		id:        resData.ID,
+		body:      resData.Body,
+		createdAt: resData.CreatedAt,
+		url:       resData.HTMLURL,
+	}, nil
+}
+
+// CallUpdateComment calls the Gitea API to update the body of a comment on the pull request.
+func (h *giteaPRHandler) CallUpdateComment(ctx context.Context, comment Comment, body string) error {
+	err := h.client.do(ctx, http.MethodPatch, fmt.Sprintf("/issues/comments/%d", comment.(*giteaComment).id), map[string]string{"body": body}, http.StatusOK, nil)
+	if err != nil {
+		return errors.Wrap(err, "Error updating comment")
+	}
+
+	return nil
+}
+
+// CallDeleteComment calls the Gitea API to delete the pull request comment.
+func (h *giteaPRHandler) CallDeleteComment(ctx context.Context, comment Comment) error {
+	err := h.client.do(ctx, http.MethodDelete, fmt.Sprintf("/issues/comments/%d", comment.(*giteaComment).id), nil, http.StatusNoContent, nil)
+	if err != nil {
+		return errors.Wrap(err, "Error deleting comment")
+	}
+
+	return nil
+}
+
+// CallHideComment calls the Gitea API to minimize the pull request comment.
+func (h *giteaPRHandler) CallHideComment(ctx context.Context, comment Comment) error {
+	return errors.New("Not implemented")
+}
+
+// AddMarkdownTags prepends tags as a markdown comment to the given string.
+func (h *giteaPRHandler) AddMarkdownTags(s string, tags []CommentTag) (string, error) {
+	return addMarkdownTags(s, tags)
+}
+
+// giteaCommitHandler is a PlatformHandler for Gitea commits. Gitea doesn't
+// have an API for commit comments so the comments are posted on the pull
+// request the commit belongs to.
+type giteaCommitHandler struct {
+	client    *giteaAPIClient
+	commitSHA string
+	pr        *giteaPRHandler
+}
+
+// NewGiteaCommitHandler creates a new CommentHandler for Gitea commits.
+func NewGiteaCommitHandler(ctx context.Context, project, targetRef string, extra GiteaExtra) (*CommentHandler, error) {
+	client, err := newGiteaAPIClient(project, extra)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &giteaCommitHandler{
+		client:    client,
+		commitSHA: targetRef,
+	}
+
+	return NewCommentHandler(ctx, h, extra.Tag), nil
+}
+
+// prHandler returns the handler for the pull request of the commit, looking
+// it up the first time it is called.
+func (h *giteaCommitHandler) prHandler(ctx context.Context) (*giteaPRHandler, error) {
+	if h.pr != nil {
+		return h.pr, nil
+	}
+
+	var resData struct {
+		Number int `json:"number"`
+	}
+
+	err := h.client.do(ctx, http.MethodGet, fmt.Sprintf("/commits/%s/pull", url.PathEscape(h.commitSHA)), nil, http.StatusOK, &resData)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error finding pull request for commit %s, Gitea only supports comments on pull requests", h.commitSHA)
+	}
+
+	h.pr = &giteaPRHandler{
+		client:   h.client,
+		prNumber: resData.Number,
+	}
+
+	return h.pr, nil
+}
+
+// CallFindMatchingComments calls the Gitea API to find the comments on the
+// pull request of the commit that match the given tag.
+func (h *giteaCommitHandler) CallFindMatchingComments(ctx context.Context, tag string) ([]Comment, error) {
+	pr, err := h.prHandler(ctx)
+	if err != nil {
+		return []Comment{}, err
+	}
+
+	return pr.CallFindMatchingComments(ctx, tag)
+}
+
+// CallCreateComment calls the Gitea API to create a new comment on the pull
+// request of the commit.
+func (h *giteaCommitHandler) CallCreateComment(ctx context.Context, body string) (Comment, error) {
+	pr, err := h.prHandler(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return pr.CallCreateComment(ctx, body)
+}
+
+// CallUpdateComment calls the Gitea API to update the body of a comment.
+func (h *giteaCommitHandler) CallUpdateComment(ctx context.Context, comment Comment, body string) error {
+	pr, err := h.prHandler(ctx)
+	if err != nil {
+		return err
+	}
+
+	return pr.CallUpdateComment(ctx, comment, body)
+}
+
+// CallDeleteComment calls the Gitea API to delete the comment.
+func (h *giteaCommitHandler) CallDeleteComment(ctx context.Context, comment Comment) error {
+	pr, err := h.prHandler(ctx)
+	if err != nil {
+		return err
+	}
+
+	return pr.CallDeleteComment(ctx, comment)
+}
+
+// CallHideComment calls the Gitea API to minimize the comment.
+func (h *giteaCommitHandler) CallHideComment(ctx context.Context, comment Comment) error {
+	return errors.New("Not implemented")
+}
+
+// AddMarkdownTags prepends tags as a markdown comment to the given string.
+func (h *giteaCommitHandler) AddMarkdownTags(s string, tags []CommentTag) (string, error) {
+	return addMarkdownTags(s, tags)
+}
@@ -0,0 +1,156 @@
+package comment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGiteaServer is an in-memory implementation of the Gitea issue comments
+// API for pull request 5 of test/repo, and the commit pull request API for
+// commit abc123.
+type fakeGiteaServer struct {
+	mu       sync.Mutex
+	comments []giteaAPIComment
+	nextID   int64
+	auth     []string
+}
+
+func (s *fakeGiteaServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.auth = append(s.auth, r.Header.Get("Authorization"))
+
+	var req struct {
+		Body string `json:"body"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	switch {
+	case r.URL.Path == "/api/v1/repos/test/repo/commits/abc123/pull" && r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(map[string]int{"number": 5})
+	case r.URL.Path == "/api/v1/repos/test/repo/issues/5/comments" && r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(s.comments)
+	case r.URL.Path == "/api/v1/repos/test/repo/issues/5/comments" && r.Method == http.MethodPost:
+		s.nextID++
+		c := giteaAPIComment{
+			ID:        s.nextID,
+			Body:      req.Body,
+			HTMLURL:   fmt.Sprintf("https://gitea.example.com/test/repo/pulls/5#issuecomment-%d", s.nextID),
+			CreatedAt: fmt.Sprintf("2024-01-01T00:00:%02dZ", s.nextID),
+		}
+		s.comments = append(s.comments, c)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(c)
+	case strings.HasPrefix(r.URL.Path, "/api/v1/repos/test/repo/issues/comments/"):
+		var id int64
+		_, _ = fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/api/v1/repos/test/repo/issues/comments/"), "%d", &id)
+		for i, c := range s.comments {
+			if c.ID != id {
+				continue
+			}
+
+			if r.Method == http.MethodPatch {
+				s.comments[i].Body = req.Body
+				_ = json.NewEncoder(w).Encode(s.comments[i])
+				return
+			}
+
+			if r.Method == http.MethodDelete {
+				s.comments = append(s.comments[:i], s.comments[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestGiteaPRHandler(t *testing.T) {
+	s := &fakeGiteaServer{
+		comments: []giteaAPIComment{{ID: 100, Body: "unrelated comment", CreatedAt: "2023-01-01T00:00:00Z"}},
+		nextID:   100,
+	}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	ctx := context.Background()
+	h, err := NewGiteaPRHandler(ctx, "test/repo", "5", GiteaExtra{APIURL: ts.URL + "/", Token: "abc"})
+	require.NoError(t, err)
+
+	res, err := h.CommentWithBehavior(ctx, "update", "first", nil)
+	require.NoError(t, err)
+	assert.True(t, res.Posted)
+	require.Len(t, s.comments, 2)
+	assert.Contains(t, s.comments[1].Body, "first")
+	assert.True(t, hasTagKey(s.comments[1].Body, defaultTag))
+
+	res, err = h.CommentWithBehavior(ctx, "update", "second", nil)
+	require.NoError(t, err)
+	assert.True(t, res.Posted)
+	require.Len(t, s.comments, 2)
+	assert.Contains(t, s.comments[1].Body, "second")
+
+	res, err = h.CommentWithBehavior(ctx, "new", "third", nil)
+	require.NoError(t, err)
+	assert.True(t, res.Posted)
+	require.Len(t, s.comments, 3)
+
+	res, err = h.CommentWithBehavior(ctx, "delete-and-new", "fourth", nil)
+	require.NoError(t, err)
+	assert.True(t, res.Posted)
+	require.Len(t, s.comments, 2)
+	assert.Equal(t, "unrelated comment", s.comments[0].Body)
+	assert.Contains(t, s.comments[1].Body, "fourth")
+
+	for _, auth := range s.auth {
+		assert.Equal(t, "token abc", auth)
+	}
+}
+
+func TestGiteaCommitHandler(t *testing.T) {
+	s := &fakeGiteaServer{}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	ctx := context.Background()
+	h, err := NewGiteaCommitHandler(ctx, "test/repo", "abc123", GiteaExtra{APIURL: ts.URL + "/api/v1", Token: "abc"})
+	require.NoError(t, err)
+
+	res, err := h.CommentWithBehavior(ctx, "update", "cost estimate", nil)
+	require.NoError(t, err)
+	assert.True(t, res.Posted)
+	require.Len(t, s.comments, 1)
+	assert.Contains(t, s.comments[0].Body, "cost estimate")
+}
+
+func TestGiteaCommitHandlerNoPullRequest(t *testing.T) {
+	s := &fakeGiteaServer{}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	ctx := context.Background()
+	h, err := NewGiteaCommitHandler(ctx, "test/repo", "def456", GiteaExtra{APIURL: ts.URL, Token: "abc"})
+	require.NoError(t, err)
+
+	_, err = h.CommentWithBehavior(ctx, "update", "cost estimate", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Error finding pull request for commit def456")
+}
+
+func TestNewGiteaPRHandlerInvalidRepo(t *testing.T) {
+	_, err := NewGiteaPRHandler(context.Background(), "test", "5", GiteaExtra{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expecting owner/repo")
+}
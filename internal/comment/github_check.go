@@ -0,0 +1,266 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/pkg/errors"
+)
+
+const (
+	// GitHubCheckRunDefaultName is the name of the check run and the context of
+	// the commit status if no tag is set.
+	GitHubCheckRunDefaultName = "Infracost"
+
+	// githubMaxAnnotationsPerRequest is the maximum number of annotations that
+	// the GitHub API accepts in a single create or update check run request.
+	githubMaxAnnotationsPerRequest = 50
+	// githubMaxStatusDescriptionLength is the maximum length of a commit status
+	// description.
+	githubMaxStatusDescriptionLength = 140
+)
+
+// CheckRunAnnotation is an annotation of a file that is shown in the GitHub
+// check run and the pull request diff.
+type CheckRunAnnotation struct {
+	Path      string
+	StartLine int
+	EndLine   int
+	// Level is one of notice, warning or failure.
+	Level   string
+	Title   string
+	Message string
+}
+
+// CheckRunOpts contains the details of the GitHub check run or commit status.
+type CheckRunOpts struct {
+	// Title is a short summary of the cost change, e.g. "+$240/mo (+12%)". It is
+	// used as the commit status description.
+	Title string
+	// Summary is the markdown shown in the details of the check run.
+	Summary string
+	// Failed sets the conclusion of the check run and the state of the commit
+	// status to failure, e.g. when policy checks fail.
+	Failed bool
+	// DetailsURL is linked from the check run and commit status.
+	DetailsURL  string
+	Annotations []CheckRunAnnotation
+}
+
+// GitHubCheckHandler publishes the Infracost output as a GitHub check run or a
+// commit status instead of a comment. This keeps pull request conversations
+// clean and lets branch protection rules require the check to pass.
+type GitHubCheckHandler struct {
+	v3client *github.Client
+	owner    string
+	repo     string
+	headSHA  string
+	prNumber int
+	name     string
+}
+
+// NewGitHubCheckHandler creates a new GitHubCheckHandler for the commit or the
+// head commit of the pull request. The tag is used to customize the name of
+// the check so multiple Infracost checks can be added to the same commit.
+func NewGitHubCheckHandler(ctx context.Context, project, commit string, prNumber int, extra GitHubExtra) (*GitHubCheckHandler, error) {
+	owner, repo, err := splitGitHubProject(project)
+	if err != nil {
+		return nil, err
+	}
+
+	if commit == "" && prNumber == 0 {
+		return nil, errors.New("Either a commit or a pull request is required")
+	}
+
+	v3client, _, err := newGitHubAPIClients(ctx, extra.Token, extra.APIURL, extra.TLSConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	name := GitHubCheckRunDefaultName
+	if extra.Tag != "" {
+		name = fmt.Sprintf("%s (%s)", GitHubCheckRunDefaultName, extra.Tag)
+	}
+
+	return &GitHubCheckHandler{
+		v3client: v3client,
+		owner:    owner,
+		repo:     repo,
+		headSHA:  commit,
+		prNumber: prNumber,
+		name:     name,
+	}, nil
+}
+
+// Name returns the name of the check run.
+func (h *GitHubCheckHandler) Name() string {
+	return h.name
+}
+
+// resolveHeadSHA returns the commit SHA the check is added to, looking up the
+// head commit of the pull request if no commit was given.
+func (h *GitHubCheckHandler) resolveHeadSHA(ctx context.Context) (string, error) {
+	if h.headSHA != "" {
+		return h.headSHA, nil
+	}
+
+	pr, _, err := h.v3client.PullRequests.Get(ctx, h.owner, h.repo, h.prNumber)
+	if err != nil {
+		return "", errors.Wrap(err, "Error getting pull request")
+	}
+
+	h.headSHA = pr.GetHead().GetSHA()
+	if h.headSHA == "" {
+		return "", fmt.Errorf("Pull request %s/%s#%d has no head commit", h.owner, h.repo, h.prNumber)
+	}
+
+	return h.headSHA, nil
+}
+
+// PublishCheckRun creates a completed check run on the commit, or updates the
+// existing check run with the same name so reruns for the same commit don't
+// add duplicate checks. Annotations are sent in batches since the GitHub API
+// limits the number of annotations per request.
+func (h *GitHubCheckHandler) PublishCheckRun(ctx context.Context, opts CheckRunOpts) (PostResult, error) {
+	sha, err := h.resolveHeadSHA(ctx)
+	if err != nil {
+		return PostResult{}, err
+	}
+
+	existing, _, err := h.v3client.Checks.ListCheckRunsForRef(ctx, h.owner, h.repo, sha, &github.ListCheckRunsOptions{
+		CheckName: github.String(h.name),
+	})
+	if err != nil {
+		return PostResult{}, errors.Wrap(err, "Error listing check runs")
+	}
+
+	conclusion := "success"
+	if opts.Failed {
+		conclusion = "failure"
+	}
+
+	var detailsURL *string
+	if opts.DetailsURL != "" {
+		detailsURL = github.String(opts.DetailsURL)
+	}
+
+	batches := annotationBatches(opts.Annotations)
+	completedAt := &github.Timestamp{Time: time.Now()}
+
+	var checkRun *github.CheckRun
+	if existing != nil && len(existing.CheckRuns) > 0 {
+		checkRun, _, err = h.v3client.Checks.UpdateCheckRun(ctx, h.owner, h.repo, existing.CheckRuns[0].GetID(), github.UpdateCheckRunOptions{
+			Name:        h.name,
+			DetailsURL:  detailsURL,
+			Status:      github.String("completed"),
+			Conclusion:  github.String(conclusion),
+			CompletedAt: completedAt,
+			Output:      checkRunOutput(opts, batches[0]),
+		})
+		if err != nil {
+			return PostResult{}, errors.Wrap(err, "Error updating check run")
+		}
+	} else {
+		checkRun, _, err = h.v3client.Checks.CreateCheckRun(ctx, h.owner, h.repo, github.CreateCheckRunOptions{
+			Name:        h.name,
+			HeadSHA:     sha,
+			DetailsURL:  detailsURL,
+			Status:      github.String("completed"),
+			Conclusion:  github.String(conclusion),
+			CompletedAt: completedAt,
+			Output:      checkRunOutput(opts, batches[0]),
+		})
+		if err != nil {
+			return PostResult{}, errors.Wrap(err, "Error creating check run")
+		}
+	}
+
+	for _, batch := range batches[1:] {
+		_, _, err = h.v3client.Checks.UpdateCheckRun(ctx, h.owner, h.repo, checkRun.GetID(), github.UpdateCheckRunOptions{
+			Name:   h.name,
+			Output: checkRunOutput(opts, batch),
+		})
+		if err != nil {
+			return PostResult{}, errors.Wrap(err, "Error adding check run annotations")
+		}
+	}
+
+	return PostResult{Posted: true}, nil
+}
+
+// PublishCommitStatus sets a commit status with the title as the description.
+// Commit statuses are supported by tokens that can't create check runs, but
+// they don't support a summary or annotations.
+func (h *GitHubCheckHandler) PublishCommitStatus(ctx context.Context, opts CheckRunOpts) (PostResult, error) {
+	sha, err := h.resolveHeadSHA(ctx)
+	if err != nil {
+		return PostResult{}, err
+	}
+
+	state := "success"
+	if opts.Failed {
+		state = "failure"
+	}
+
+	description := opts.Title
+	if r := []rune(description); len(r) > githubMaxStatusDescriptionLength {
+		description = string(r[:githubMaxStatusDescriptionLength-3]) + "..."
+	}
+
+	status := &github.RepoStatus{
+		State:       github.String(state),
+		Description: github.String(description),
+		Context:     github.String(h.name),
+	}
+	if opts.DetailsURL != "" {
+		status.TargetURL = github.String(opts.DetailsURL)
+	}
+
+	_, _, err = h.v3client.Repositories.CreateStatus(ctx, h.owner, h.repo, sha, status)
+	if err != nil {
+		return PostResult{}, errors.Wrap(err, "Error creating commit status")
+	}
+
+	return PostResult{Posted: true}, nil
+}
+
+func checkRunOutput(opts CheckRunOpts, annotations []*github.CheckRunAnnotation) *github.CheckRunOutput {
+	return &github.CheckRunOutput{
+		Title:       github.String(opts.Title),
+		Summary:     github.String(opts.Summary),
+		Annotations: annotations,
+	}
+}
+
+// annotationBatches converts the annotations to the GitHub type and splits
+// them into batches that can be sent in a single request. It always returns
+// at least one batch.
+func annotationBatches(annotations []CheckRunAnnotation) [][]*github.CheckRunAnnotation {
+	batches := [][]*github.CheckRunAnnotation{nil}
+
+	for _, a := range annotations {
+		last := len(batches) - 1
+		if len(batches[last]) == githubMaxAnnotationsPerRequest {
+			batches = append(batches, nil)
+			last++
+		}
+
+		level := a.Level
+		if level == "" {
+			level = "notice"
+		}
+
+		batches[last] = append(batches[last], &github.CheckRunAnnotation{
+			Path:            github.String(a.Path),
+			StartLine:       github.Int(a.StartLine),
+			EndLine:         github.Int(a.EndLine),
+			AnnotationLevel: github.String(level),
+			Title:           github.String(a.Title),
+			Message:         github.String(a.Message),
+		})
+	}
+
+	return batches
+}
@@ -0,0 +1,119 @@
+package comment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubCheckHandlerPublishCheckRun(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.Method == http.MethodGet {
+			assert.Equal(t, "/api/v3/repos/owner/repo/commits/abc123/check-runs", r.URL.Path)
+			assert.Equal(t, "Infracost (dev)", r.URL.Query().Get("check_name"))
+			fmt.Fprintln(w, `{"total_count": 1, "check_runs": [{"id": 42}]}`)
+			return
+		}
+
+		var body map[string]interface{}
+		b, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(b, &body))
+		bodies = append(bodies, body)
+		fmt.Fprintln(w, `{"id": 42}`)
+	}))
+	defer ts.Close()
+
+	h, err := NewGitHubCheckHandler(context.Background(), "owner/repo", "abc123", 0, GitHubExtra{APIURL: ts.URL, Token: "abc", Tag: "dev"})
+	require.NoError(t, err)
+
+	var annotations []CheckRunAnnotation
+	for i := 1; i <= 60; i++ {
+		annotations = append(annotations, CheckRunAnnotation{Path: "main.tf", StartLine: i, EndLine: i, Title: "title", Message: "message"})
+	}
+
+	res, err := h.PublishCheckRun(context.Background(), CheckRunOpts{
+		Title:       "+$240/mo (+12%)",
+		Summary:     "summary",
+		Failed:      true,
+		Annotations: annotations,
+	})
+	require.NoError(t, err)
+	assert.True(t, res.Posted)
+
+	assert.Equal(t, []string{
+		"GET /api/v3/repos/owner/repo/commits/abc123/check-runs",
+		"PATCH /api/v3/repos/owner/repo/check-runs/42",
+		"PATCH /api/v3/repos/owner/repo/check-runs/42",
+	}, requests)
+
+	require.Len(t, bodies, 2)
+	assert.Equal(t, "failure", bodies[0]["conclusion"])
+	assert.Equal(t, "completed", bodies[0]["status"])
+
+	output := bodies[0]["output"].(map[string]interface{})
+	assert.Equal(t, "+$240/mo (+12%)", output["title"])
+	assert.Equal(t, "summary", output["summary"])
+	assert.Len(t, output["annotations"], 50)
+	assert.Equal(t, "notice", output["annotations"].([]interface{})[0].(map[string]interface{})["annotation_level"])
+
+	output = bodies[1]["output"].(map[string]interface{})
+	assert.Len(t, output["annotations"], 10)
+}
+
+func TestGitHubCheckHandlerPublishCommitStatus(t *testing.T) {
+	var requests []string
+	var status map[string]interface{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.Method == http.MethodGet {
+			fmt.Fprintln(w, `{"number": 5, "head": {"sha": "def456"}}`)
+			return
+		}
+
+		b, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(b, &status))
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintln(w, `{"id": 1}`)
+	}))
+	defer ts.Close()
+
+	h, err := NewGitHubCheckHandler(context.Background(), "owner/repo", "", 5, GitHubExtra{APIURL: ts.URL, Token: "abc"})
+	require.NoError(t, err)
+
+	res, err := h.PublishCommitStatus(context.Background(), CheckRunOpts{
+		Title:      "+$240/mo (+12%)",
+		DetailsURL: "https://dashboard.infracost.io/share/abc",
+	})
+	require.NoError(t, err)
+	assert.True(t, res.Posted)
+
+	assert.Equal(t, []string{
+		"GET /api/v3/repos/owner/repo/pulls/5",
+		"POST /api/v3/repos/owner/repo/statuses/def456",
+	}, requests)
+	assert.Equal(t, map[string]interface{}{
+		"state":       "success",
+		"description": "+$240/mo (+12%)",
+		"context":     "Infracost",
+		"target_url":  "https://dashboard.infracost.io/share/abc",
+	}, status)
+}
+
+func TestNewGitHubCheckHandlerRequiresTarget(t *testing.T) {
+	_, err := NewGitHubCheckHandler(context.Background(), "owner/repo", "", 0, GitHubExtra{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,213 @@
+package comment
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/pkg/errors"
+	"github.com/shurcooL/githubv4"
+)
+
+// githubReviewHandler is a ReviewPlatformHandler for GitHub pull requests. It
+// posts review comments on the lines of the pull request diff.
+type githubReviewHandler struct {
+	v4client *githubv4.Client
+	v3client *github.Client
+	owner    string
+	repo     string
+	prNumber int
+	headSHA  string
+}
+
+// NewGitHubReviewHandler creates a new ReviewHandler for GitHub pull requests.
+func NewGitHubReviewHandler(ctx context.Context, project, targetRef string, extra GitHubExtra) (*ReviewHandler, error) {
+	owner, repo, err := splitGitHubProject(project)
+	if err != nil {
+		return nil, err
+	}
+
+	prNumber, err := strconv.Atoi(targetRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing targetRef as pull request number")
+	}
+
+	v3client, v4client, err := newGitHubAPIClients(ctx, extra.Token, extra.APIURL, extra.TLSConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &githubReviewHandler{
+		v3client: v3client,
+		v4client: v4client,
+		owner:    owner,
+		repo:     repo,
+		prNumber: prNumber,
+	}
+
+	return NewReviewHandler(ctx, h, extra.Tag), nil
+}
+
+// CallDiffLines calls the GitHub API to get the patches of the files changed
+// in the pull request.
+func (h *githubReviewHandler) CallDiffLines(ctx context.Context) (DiffLines, error) {
+	diffLines := make(DiffLines)
+
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		files, res, err := h.v3client.PullRequests.ListFiles(ctx, h.owner, h.repo, h.prNumber, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error listing pull request files")
+		}
+
+		for _, f := range files {
+			if f.GetStatus() == "removed" {
+				continue
+			}
+
+			diffLines[f.GetFilename()] = parseDiffLines(f.GetPatch())
+		}
+
+		if res.NextPage == 0 {
+			break
+		}
+		opts.Page = res.NextPage
+	}
+
+	return diffLines, nil
+}
+
+// CallFindMatchingReviewThreads calls the GitHub API to find the review
+// threads of the pull request whose first comment contains the tag.
+func (h *githubReviewHandler) CallFindMatchingReviewThreads(ctx context.Context, tag string) ([]ReviewThread, error) {
+	var q struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewThreads struct {
+					Nodes []struct {
+						ID         githubv4.String
+						IsResolved githubv4.Boolean
+						Path       githubv4.String
+						Line       *githubv4.Int
+						Comments   struct {
+							Nodes []struct {
+								DatabaseID githubv4.Int
+								Body       githubv4.String
+							}
+						} `graphql:"comments(first: 1)"`
+					}
+					PageInfo struct {
+						EndCursor   githubv4.String
+						HasNextPage bool
+					}
+				} `graphql:"reviewThreads(first: 100, after: $after)"`
+			} `graphql:"pullRequest(number: $prNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	variables := map[string]interface{}{
+		"owner":    githubv4.String(h.owner),
+		"repo":     githubv4.String(h.repo),
+		"prNumber": githubv4.Int(h.prNumber),
+		"after":    (*githubv4.String)(nil), // Null after argument to get first page.
+	}
+
+	var threads []ReviewThread
+	for {
+		err := h.v4client.Query(ctx, &q, variables)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error getting review threads")
+		}
+
+		for _, node := range q.Repository.PullRequest.ReviewThreads.Nodes {
+			if len(node.Comments.Nodes) == 0 || !hasTagKey(string(node.Comments.Nodes[0].Body), tag) {
+				continue
+			}
+
+			var line int
+			if node.Line != nil {
+				line = int(*node.Line)
+			}
+
+			threads = append(threads, ReviewThread{
+				ID:        string(node.ID),
+				CommentID: strconv.Itoa(int(node.Comments.Nodes[0].DatabaseID)),
+				Path:      string(node.Path),
+				Line:      line,
+				Body:      string(node.Comments.Nodes[0].Body),
+				Resolved:  bool(node.IsResolved),
+			})
+		}
+
+		if !q.Repository.PullRequest.ReviewThreads.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = githubv4.NewString(q.Repository.PullRequest.ReviewThreads.PageInfo.EndCursor)
+	}
+
+	return threads, nil
+}
+
+// CallCreateReviewComment calls the GitHub API to create a review comment on
+// the line of the head commit of the pull request.
+func (h *githubReviewHandler) CallCreateReviewComment(ctx context.Context, path string, line int, body string) error {
+	if h.headSHA == "" {
+		pr, _, err := h.v3client.PullRequests.Get(ctx, h.owner, h.repo, h.prNumber)
+		if err != nil {
+			return errors.Wrap(err, "Error getting pull request")
+		}
+		h.headSHA = pr.GetHead().GetSHA()
+	}
+
+	_, _, err := h.v3client.PullRequests.CreateComment(ctx, h.owner, h.repo, h.prNumber, &github.PullRequestComment{
+		Body:     github.String(body),
+		CommitID: github.String(h.headSHA),
+		Path:     github.String(path),
+		Line:     github.Int(line),
+		Side:     github.String("RIGHT"),
+	})
+	if err != nil {
+		return errors.Wrap(err, "Error creating review comment")
+	}
+
+	return nil
+}
+
+// CallUpdateReviewComment calls the GitHub API to update the body of the
+// review comment.
+func (h *githubReviewHandler) CallUpdateReviewComment(ctx context.Context, thread ReviewThread, body string) error {
+	id, err := strconv.ParseInt(thread.CommentID, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "Error parsing review comment ID")
+	}
+
+	_, _, err = h.v3client.PullRequests.EditComment(ctx, h.owner, h.repo, id, &github.PullRequestComment{
+		Body: github.String(body),
+	})
+	if err != nil {
+		return errors.Wrap(err, "Error updating review comment")
+	}
+
+	return nil
+}
+
+// CallResolveReviewThread calls the GitHub API to resolve or unresolve the
+// review thread.
+func (h *githubReviewHandler) CallResolveReviewThread(ctx context.Context, thread ReviewThread, resolved bool) error {
+	if resolved {
+		var m struct {
+			ResolveReviewThread struct {
+				ClientMutationId githubv4.ID //nolint
+			} `graphql:"resolveReviewThread(input: $input)"`
+		}
+
+		return h.v4client.Mutate(ctx, &m, githubv4.ResolveReviewThreadInput{ThreadID: githubv4.ID(thread.ID)}, nil)
+	}
+
+	var m struct {
+		UnresolveReviewThread struct {
+			ClientMutationId githubv4.ID //nolint
+		} `graphql:"unresolveReviewThread(input: $input)"`
+	}
+
+	return h.v4client.Mutate(ctx, &m, githubv4.UnresolveReviewThreadInput{ThreadID: githubv4.ID(thread.ID)}, nil)
+}
@@ -0,0 +1,248 @@
+package comment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// gitlabReviewHandler is a ReviewPlatformHandler for GitLab merge requests. It
+// posts review comments as diff discussions on the lines of the merge request
+// diff.
+type gitlabReviewHandler struct {
+	httpClient *http.Client
+	serverURL  string
+	project    string
+	mrNumber   int
+	diffRefs   *gitlabDiffRefs
+}
+
+// gitlabDiffRefs are the SHAs of the merge request diff which are required to
+// position diff discussions.
+type gitlabDiffRefs struct {
+	BaseSHA  string `json:"base_sha"`
+	HeadSHA  string `json:"head_sha"`
+	StartSHA string `json:"start_sha"`
+}
+
+// NewGitLabReviewHandler creates a new ReviewHandler for GitLab merge requests.
+func NewGitLabReviewHandler(ctx context.Context, project string, targetRef string, extra GitLabExtra) (*ReviewHandler, error) {
+	mrNumber, err := strconv.Atoi(targetRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing targetRef as merge request number")
+	}
+
+	serverURL := extra.ServerURL
+	if serverURL == "" {
+		serverURL = "https://gitlab.com"
+	}
+
+	httpClient, _, err := newGitLabAPIClients(ctx, extra.Token, serverURL)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &gitlabReviewHandler{
+		httpClient: httpClient,
+		serverURL:  serverURL,
+		project:    project,
+		mrNumber:   mrNumber,
+	}
+
+	return NewReviewHandler(ctx, h, extra.Tag), nil
+}
+
+// do sends a request to the merge request API path and decodes the response
+// into out if it is not nil.
+func (h *gitlabReviewHandler) do(ctx context.Context, method, path string, body interface{}, expectedStatus int, out interface{}) (*http.Response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error marshaling request body")
+		}
+		reqBody = bytes.NewBuffer(b)
+	}
+
+	u := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests/%d%s", h.serverURL, url.PathEscape(h.project), h.mrNumber, path)
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := h.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != expectedStatus {
+		return res, errors.Errorf("%s", res.Status)
+	}
+
+	if out == nil {
+		return res, nil
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return res, errors.Wrap(err, "Error reading response body")
+	}
+
+	err = json.Unmarshal(resBody, out)
+	if err != nil {
+		return res, errors.Wrap(err, "Error unmarshaling response body")
+	}
+
+	return res, nil
+}
+
+// CallDiffLines calls the GitLab API to get the changes of the merge request.
+// Only added lines are returned since GitLab requires the line of the old
+// version of the file to comment on unchanged lines.
+func (h *gitlabReviewHandler) CallDiffLines(ctx context.Context) (DiffLines, error) {
+	var resData struct {
+		DiffRefs gitlabDiffRefs `json:"diff_refs"`
+		Changes  []struct {
+			NewPath     string `json:"new_path"`
+			Diff        string `json:"diff"`
+			DeletedFile bool   `json:"deleted_file"`
+		} `json:"changes"`
+	}
+
+	_, err := h.do(ctx, http.MethodGet, "/changes", nil, http.StatusOK, &resData)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting merge request changes")
+	}
+
+	h.diffRefs = &resData.DiffRefs
+
+	diffLines := make(DiffLines)
+	for _, c := range resData.Changes {
+		if c.DeletedFile {
+			continue
+		}
+
+		added := make(map[int]bool)
+		for line, isAdded := range parseDiffLines(c.Diff) {
+			if isAdded {
+				added[line] = true
+			}
+		}
+		diffLines[c.NewPath] = added
+	}
+
+	return diffLines, nil
+}
+
+// CallFindMatchingReviewThreads calls the GitLab API to find the diff
+// discussions of the merge request whose first note contains the tag.
+func (h *gitlabReviewHandler) CallFindMatchingReviewThreads(ctx context.Context, tag string) ([]ReviewThread, error) {
+	var threads []ReviewThread
+
+	page := "1"
+	for page != "" {
+		var resData []struct {
+			ID    string `json:"id"`
+			Notes []struct {
+				ID       int    `json:"id"`
+				Body     string `json:"body"`
+				Resolved bool   `json:"resolved"`
+				Position *struct {
+					NewPath string `json:"new_path"`
+					NewLine int    `json:"new_line"`
+				} `json:"position"`
+			} `json:"notes"`
+		}
+
+		res, err := h.do(ctx, http.MethodGet, "/discussions?per_page=100&page="+page, nil, http.StatusOK, &resData)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error getting merge request discussions")
+		}
+
+		for _, d := range resData {
+			if len(d.Notes) == 0 || d.Notes[0].Position == nil || !hasTagKey(d.Notes[0].Body, tag) {
+				continue
+			}
+
+			note := d.Notes[0]
+			threads = append(threads, ReviewThread{
+				ID:        d.ID,
+				CommentID: strconv.Itoa(note.ID),
+				Path:      note.Position.NewPath,
+				Line:      note.Position.NewLine,
+				Body:      note.Body,
+				Resolved:  note.Resolved,
+			})
+		}
+
+		page = res.Header.Get("X-Next-Page")
+	}
+
+	return threads, nil
+}
+
+// CallCreateReviewComment calls the GitLab API to create a diff discussion on
+// the line of the merge request.
+func (h *gitlabReviewHandler) CallCreateReviewComment(ctx context.Context, path string, line int, body string) error {
+	if h.diffRefs == nil {
+		_, err := h.CallDiffLines(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	reqData := map[string]interface{}{
+		"body": body,
+		"position": map[string]interface{}{
+			"position_type": "text",
+			"base_sha":      h.diffRefs.BaseSHA,
+			"start_sha":     h.diffRefs.StartSHA,
+			"head_sha":      h.diffRefs.HeadSHA,
+			"new_path":      path,
+			"new_line":      line,
+		},
+	}
+
+	_, err := h.do(ctx, http.MethodPost, "/discussions", reqData, http.StatusCreated, nil)
+	if err != nil {
+		return errors.Wrap(err, "Error creating review comment")
+	}
+
+	return nil
+}
+
+// CallUpdateReviewComment calls the GitLab API to update the body of the
+// first note of the discussion.
+func (h *gitlabReviewHandler) CallUpdateReviewComment(ctx context.Context, thread ReviewThread, body string) error {
+	path := fmt.Sprintf("/discussions/%s/notes/%s", thread.ID, thread.CommentID)
+
+	_, err := h.do(ctx, http.MethodPut, path, map[string]interface{}{"body": body}, http.StatusOK, nil)
+	if err != nil {
+		return errors.Wrap(err, "Error updating review comment")
+	}
+
+	return nil
+}
+
+// CallResolveReviewThread calls the GitLab API to resolve or unresolve the
+// discussion.
+func (h *gitlabReviewHandler) CallResolveReviewThread(ctx context.Context, thread ReviewThread, resolved bool) error {
+	path := fmt.Sprintf("/discussions/%s?resolved=%t", thread.ID, resolved)
+
+	_, err := h.do(ctx, http.MethodPut, path, nil, http.StatusOK, nil)
+	if err != nil {
+		return errors.Wrap(err, "Error resolving review comment")
+	}
+
+	return nil
+}
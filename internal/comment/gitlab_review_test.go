@@ -0,0 +1,75 @@
+package comment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitLabReviewHandler(t *testing.T) {
+	var requests []string
+	var created map[string]interface{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/projects/group/project/merge_requests/3/changes":
+			fmt.Fprintln(w, `{
+				"diff_refs": {"base_sha": "base", "head_sha": "head", "start_sha": "start"},
+				"changes": [
+					{"new_path": "main.tf", "diff": "@@ -1,2 +1,2 @@\n resource \"aws_instance\" \"web\" {\n-  instance_type = \"t3.micro\"\n+  instance_type = \"m5.large\"\n"},
+					{"new_path": "old.tf", "deleted_file": true, "diff": "@@ -1 +0,0 @@\n-resource \"aws_instance\" \"old\" {}\n"}
+				]
+			}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/projects/group/project/merge_requests/3/discussions":
+			fmt.Fprintln(w, `[
+				{"id": "general", "notes": [{"id": 1, "body": "[//]: <> (infracost-comment)\nsummary"}]},
+				{"id": "stale", "notes": [{"id": 2, "body": "[//]: <> (infracost-comment-review, infracost-block=main.tf%3Aaws_instance.old)\n+$5", "position": {"new_path": "main.tf", "new_line": 9}}]}
+			]`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/projects/group/project/merge_requests/3/discussions":
+			b, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(b, &created))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintln(w, `{}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v4/projects/group/project/merge_requests/3/discussions/stale":
+			fmt.Fprintln(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	h, err := NewGitLabReviewHandler(ctx, "group/project", "3", GitLabExtra{ServerURL: ts.URL, Token: "abc"})
+	require.NoError(t, err)
+
+	res, err := h.SyncReviewComments(ctx, []ReviewComment{
+		{Key: "main.tf:aws_instance.web", Path: "main.tf", StartLine: 1, EndLine: 3, Body: "+$10"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ReviewResult{Created: 1, Resolved: 1}, res)
+
+	assert.Equal(t, []string{
+		"GET /api/v4/projects/group%2Fproject/merge_requests/3/changes",
+		"GET /api/v4/projects/group%2Fproject/merge_requests/3/discussions?per_page=100&page=1",
+		"POST /api/v4/projects/group%2Fproject/merge_requests/3/discussions",
+		"PUT /api/v4/projects/group%2Fproject/merge_requests/3/discussions/stale?resolved=true",
+	}, requests)
+
+	assert.Equal(t, map[string]interface{}{
+		"position_type": "text",
+		"base_sha":      "base",
+		"start_sha":     "start",
+		"head_sha":      "head",
+		"new_path":      "main.tf",
+		"new_line":      float64(2),
+	}, created["position"])
+}
@@ -0,0 +1,274 @@
+package comment
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/infracost/infracost/internal/logging"
+)
+
+// reviewTagSuffix is appended to the tag of the Infracost comment to identify
+// review comments. A separate tag is used so the review comments are never
+// matched when finding the Infracost comment, since some platforms return
+// review comments along with the other comments.
+var reviewTagSuffix = "-review"
+
+// reviewKeyTagKey is the tag key used to store the key of the block a review
+// comment is for.
+var reviewKeyTagKey = "infracost-block"
+
+// ReviewComment is an inline comment on a block of a file in a pull request.
+type ReviewComment struct {
+	// Key identifies the block the comment is for so the comment can be updated
+	// or resolved on subsequent runs.
+	Key       string
+	Path      string
+	StartLine int
+	EndLine   int
+	Body      string
+}
+
+// ReviewThread is an existing review comment thread on a pull request.
+type ReviewThread struct {
+	// ID is the ID of the thread, used to resolve it.
+	ID string
+	// CommentID is the ID of the first comment in the thread, used to update it.
+	CommentID string
+	Path      string
+	// Line is the line of the new version of the file the thread is on. It is 0
+	// if the thread is outdated.
+	Line     int
+	Body     string
+	Resolved bool
+}
+
+// DiffLines contains the lines of the new version of each file in a pull
+// request diff that review comments can be added to. The value is true if the
+// line was added or changed, false if it is an unchanged context line.
+type DiffLines map[string]map[int]bool
+
+// ReviewPlatformHandler is an interface that represents the platform specific
+// API calls for review comments on pull requests.
+type ReviewPlatformHandler interface {
+	// CallDiffLines calls the platform-specific API to get the lines of the pull
+	// request diff that can be commented on.
+	CallDiffLines(ctx context.Context) (DiffLines, error)
+
+	// CallFindMatchingReviewThreads calls the platform-specific API to find the
+	// review threads whose first comment contains the given tag.
+	CallFindMatchingReviewThreads(ctx context.Context, tag string) ([]ReviewThread, error)
+
+	// CallCreateReviewComment calls the platform-specific API to create a review
+	// comment on the line of the file.
+	CallCreateReviewComment(ctx context.Context, path string, line int, body string) error
+
+	// CallUpdateReviewComment calls the platform-specific API to update the body
+	// of the first comment in the thread.
+	CallUpdateReviewComment(ctx context.Context, thread ReviewThread, body string) error
+
+	// CallResolveReviewThread calls the platform-specific API to resolve or
+	// unresolve the thread.
+	CallResolveReviewThread(ctx context.Context, thread ReviewThread, resolved bool) error
+}
+
+// ReviewResult contains the number of review comments that were changed when
+// syncing the review comments.
+type ReviewResult struct {
+	Created  int
+	Updated  int
+	Resolved int
+	// Skipped is the number of review comments that couldn't be posted since
+	// the block isn't part of the pull request diff.
+	Skipped int
+}
+
+// ReviewHandler contains the logic for keeping the review comments on a pull
+// request in sync with the cost changes of the blocks. It uses a
+// ReviewPlatformHandler to call the platform-specific APIs.
+type ReviewHandler struct {
+	PlatformHandler ReviewPlatformHandler
+	Tag             string
+}
+
+// NewReviewHandler creates a new ReviewHandler.
+func NewReviewHandler(ctx context.Context, platformHandler ReviewPlatformHandler, tag string) *ReviewHandler {
+	if tag == "" {
+		tag = defaultTag
+	}
+
+	return &ReviewHandler{
+		PlatformHandler: platformHandler,
+		Tag:             tag + reviewTagSuffix,
+	}
+}
+
+// SyncReviewComments creates or updates a review comment for each of the
+// given comments, and resolves the review threads of blocks that no longer
+// have a cost change. Comments are added to the first changed line of the
+// block in the pull request diff, and are skipped if the block isn't part of
+// the diff.
+func (h *ReviewHandler) SyncReviewComments(ctx context.Context, comments []ReviewComment) (ReviewResult, error) {
+	var result ReviewResult
+
+	diffLines, err := h.PlatformHandler.CallDiffLines(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	threads, err := h.PlatformHandler.CallFindMatchingReviewThreads(ctx, h.Tag)
+	if err != nil {
+		return result, err
+	}
+
+	threadsByKey := make(map[string][]ReviewThread)
+	for _, t := range threads {
+		key := reviewThreadKey(t.Body)
+		threadsByKey[key] = append(threadsByKey[key], t)
+	}
+
+	synced := make(map[string]bool)
+
+	for _, c := range comments {
+		line, ok := reviewLine(diffLines[c.Path], c.StartLine, c.EndLine)
+		if !ok {
+			logging.Logger.Debug().Msgf("Skipping review comment for %s since it isn't part of the diff", c.Key)
+			result.Skipped++
+			continue
+		}
+
+		body, err := addMarkdownTags(c.Body, []CommentTag{
+			{Key: h.Tag},
+			{Key: reviewKeyTagKey, Value: url.QueryEscape(c.Key)},
+		})
+		if err != nil {
+			return result, err
+		}
+
+		var existing *ReviewThread
+		for i, t := range threadsByKey[c.Key] {
+			if t.Path == c.Path && t.Line == line {
+				existing = &threadsByKey[c.Key][i]
+				break
+			}
+		}
+
+		if existing == nil {
+			logging.Logger.Info().Msgf("Creating review comment on %s:%d", c.Path, line)
+			err = h.PlatformHandler.CallCreateReviewComment(ctx, c.Path, line, body)
+			if err != nil {
+				return result, err
+			}
+			result.Created++
+			continue
+		}
+
+		synced[existing.ID] = true
+
+		if strings.TrimSpace(existing.Body) != strings.TrimSpace(body) {
+			logging.Logger.Info().Msgf("Updating review comment on %s:%d", c.Path, line)
+			err = h.PlatformHandler.CallUpdateReviewComment(ctx, *existing, body)
+			if err != nil {
+				return result, err
+			}
+			result.Updated++
+		}
+
+		if existing.Resolved {
+			err = h.PlatformHandler.CallResolveReviewThread(ctx, *existing, false)
+			if err != nil {
+				return result, err
+			}
+		}
+	}
+
+	for _, t := range threads {
+		if synced[t.ID] || t.Resolved {
+			continue
+		}
+
+		logging.Logger.Info().Msgf("Resolving review comment on %s", t.Path)
+		err = h.PlatformHandler.CallResolveReviewThread(ctx, t, true)
+		if err != nil {
+			return result, err
+		}
+		result.Resolved++
+	}
+
+	return result, nil
+}
+
+// reviewThreadKey returns the key of the block stored in the tags of the body.
+func reviewThreadKey(body string) string {
+	key, err := url.QueryUnescape(extractTagValue(body, reviewKeyTagKey))
+	if err != nil {
+		return ""
+	}
+
+	return key
+}
+
+// reviewLine returns the line of the block the review comment is added to.
+// This is the first added or changed line of the block, or the first line of
+// the block that is part of the diff if none of them changed.
+func reviewLine(lines map[int]bool, startLine, endLine int) (int, bool) {
+	var candidates []int
+	for line := range lines {
+		if line >= startLine && line <= endLine {
+			candidates = append(candidates, line)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return 0, false
+	}
+
+	sort.Ints(candidates)
+
+	for _, line := range candidates {
+		if lines[line] {
+			return line, true
+		}
+	}
+
+	return candidates[0], true
+}
+
+var diffHunkHeaderRegex = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// parseDiffLines returns the lines of the new version of the file that are
+// part of the unified diff. The value is true for added lines.
+func parseDiffLines(diff string) map[int]bool {
+	lines := make(map[int]bool)
+
+	line := 0
+	inHunk := false
+	for _, l := range strings.Split(diff, "\n") {
+		if m := diffHunkHeaderRegex.FindStringSubmatch(l); m != nil {
+			line, _ = strconv.Atoi(m[1])
+			inHunk = true
+			continue
+		}
+
+		if !inHunk || l == "" {
+			continue
+		}
+
+		switch l[0] {
+		case '+':
+			lines[line] = true
+			line++
+		case ' ':
+			lines[line] = false
+			line++
+		case '-', '\\':
+			// removed lines and "\ No newline at end of file" aren't part of
+			// the new version of the file.
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,160 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeReviewPlatformHandler stores review threads in memory.
+type fakeReviewPlatformHandler struct {
+	diffLines DiffLines
+	threads   []ReviewThread
+	calls     []string
+}
+
+func (h *fakeReviewPlatformHandler) CallDiffLines(ctx context.Context) (DiffLines, error) {
+	return h.diffLines, nil
+}
+
+func (h *fakeReviewPlatformHandler) CallFindMatchingReviewThreads(ctx context.Context, tag string) ([]ReviewThread, error) {
+	var threads []ReviewThread
+	for _, t := range h.threads {
+		if hasTagKey(t.Body, tag) {
+			threads = append(threads, t)
+		}
+	}
+	return threads, nil
+}
+
+func (h *fakeReviewPlatformHandler) CallCreateReviewComment(ctx context.Context, path string, line int, body string) error {
+	id := fmt.Sprintf("%d", len(h.threads)+1)
+	h.threads = append(h.threads, ReviewThread{ID: id, CommentID: id, Path: path, Line: line, Body: body})
+	h.calls = append(h.calls, fmt.Sprintf("create %s:%d", path, line))
+	return nil
+}
+
+func (h *fakeReviewPlatformHandler) CallUpdateReviewComment(ctx context.Context, thread ReviewThread, body string) error {
+	for i, t := range h.threads {
+		if t.ID == thread.ID {
+			h.threads[i].Body = body
+		}
+	}
+	h.calls = append(h.calls, "update "+thread.ID)
+	return nil
+}
+
+func (h *fakeReviewPlatformHandler) CallResolveReviewThread(ctx context.Context, thread ReviewThread, resolved bool) error {
+	for i, t := range h.threads {
+		if t.ID == thread.ID {
+			h.threads[i].Resolved = resolved
+		}
+	}
+	h.calls = append(h.calls, fmt.Sprintf("resolve %s %t", thread.ID, resolved))
+	return nil
+}
+
+func TestReviewHandlerSyncReviewComments(t *testing.T) {
+	p := &fakeReviewPlatformHandler{
+		diffLines: DiffLines{
+			"main.tf": {1: false, 2: true, 3: false, 10: false, 11: false},
+		},
+		threads: []ReviewThread{
+			// A review comment from another tool is ignored.
+			{ID: "other", Path: "main.tf", Line: 2, Body: "looks good"},
+		},
+	}
+
+	ctx := context.Background()
+	h := NewReviewHandler(ctx, p, "")
+
+	comments := []ReviewComment{
+		{Key: "main.tf:aws_instance.web", Path: "main.tf", StartLine: 1, EndLine: 5, Body: "+$10"},
+		{Key: "main.tf:module.db", Path: "main.tf", StartLine: 10, EndLine: 12, Body: "+$20"},
+		{Key: "other.tf:aws_instance.app", Path: "other.tf", StartLine: 1, EndLine: 5, Body: "+$30"},
+	}
+
+	res, err := h.SyncReviewComments(ctx, comments)
+	require.NoError(t, err)
+	assert.Equal(t, ReviewResult{Created: 2, Skipped: 1}, res)
+	assert.Equal(t, []string{"create main.tf:2", "create main.tf:10"}, p.calls)
+	assert.Equal(t, "[//]: <> (infracost-comment-review, infracost-block="+url.QueryEscape("main.tf:aws_instance.web")+")\n+$10", p.threads[1].Body)
+
+	// Running again with the same comments doesn't change anything.
+	p.calls = nil
+	res, err = h.SyncReviewComments(ctx, comments)
+	require.NoError(t, err)
+	assert.Equal(t, ReviewResult{Skipped: 1}, res)
+	assert.Empty(t, p.calls)
+
+	// Changed costs are updated and blocks without cost changes are resolved.
+	p.calls = nil
+	res, err = h.SyncReviewComments(ctx, []ReviewComment{
+		{Key: "main.tf:aws_instance.web", Path: "main.tf", StartLine: 1, EndLine: 5, Body: "+$15"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ReviewResult{Updated: 1, Resolved: 1}, res)
+	assert.Equal(t, []string{"update 2", "resolve 3 true"}, p.calls)
+
+	// Resolved threads are reopened when the block has a cost change again.
+	p.calls = nil
+	res, err = h.SyncReviewComments(ctx, comments[:2])
+	require.NoError(t, err)
+	assert.Equal(t, ReviewResult{Updated: 1}, res)
+	assert.Equal(t, []string{"update 2", "resolve 3 false"}, p.calls)
+
+	// Threads are moved when the block moves to different lines.
+	p.calls = nil
+	p.diffLines = DiffLines{"main.tf": {20: true}}
+	res, err = h.SyncReviewComments(ctx, []ReviewComment{
+		{Key: "main.tf:aws_instance.web", Path: "main.tf", StartLine: 20, EndLine: 25, Body: "+$15"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ReviewResult{Created: 1, Resolved: 2}, res)
+	assert.Equal(t, []string{"create main.tf:20", "resolve 2 true", "resolve 3 true"}, p.calls)
+}
+
+func TestParseDiffLines(t *testing.T) {
+	diff := `diff --git a/main.tf b/main.tf
+--- a/main.tf
++++ b/main.tf
+@@ -1,4 +1,5 @@
+ resource "aws_instance" "web" {
+-  instance_type = "t3.micro"
++  instance_type = "m5.large"
++  monitoring    = true
+   ami           = "ami-123"
+ }
+@@ -20,2 +21 @@ resource "aws_s3_bucket" "logs" {
+-  acl = "private"
+   bucket = "logs"
+\ No newline at end of file`
+
+	assert.Equal(t, map[int]bool{
+		1:  false,
+		2:  true,
+		3:  true,
+		4:  false,
+		5:  false,
+		21: false,
+	}, parseDiffLines(diff))
+}
+
+func TestReviewLine(t *testing.T) {
+	lines := map[int]bool{1: false, 2: false, 4: true, 9: true}
+
+	line, ok := reviewLine(lines, 2, 5)
+	assert.True(t, ok)
+	assert.Equal(t, 4, line)
+
+	line, ok = reviewLine(lines, 1, 2)
+	assert.True(t, ok)
+	assert.Equal(t, 1, line)
+
+	_, ok = reviewLine(lines, 5, 8)
+	assert.False(t, ok)
+}
@@ -0,0 +1,177 @@
+package comment
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/logging"
+)
+
+const (
+	// WebhookSignatureHeader contains the hex encoded HMAC-SHA256 of the request
+	// body, prefixed with sha256=, when a secret is configured.
+	WebhookSignatureHeader = "X-Infracost-Signature"
+	// WebhookIdempotencyKeyHeader contains the key receivers can use to apply
+	// the behavior, e.g. a receiver handling the update behavior should replace
+	// the message it previously received with the same key.
+	WebhookIdempotencyKeyHeader = "Idempotency-Key"
+	WebhookBehaviorHeader       = "X-Infracost-Behavior"
+	WebhookTagHeader            = "X-Infracost-Tag"
+	WebhookValidAtHeader        = "X-Infracost-Valid-At"
+)
+
+// webhookRetryBackoff is the time to wait before the first retry, it is
+// doubled for each subsequent retry.
+var webhookRetryBackoff = time.Second
+
+// WebhookExtra contains any extra inputs that can be passed to the webhook
+// handler.
+type WebhookExtra struct {
+	// URL is the URL the comment body is POSTed to.
+	URL string
+	// Headers are added to the request, e.g. for authentication.
+	Headers map[string]string
+	// ContentType is the content type of the comment body.
+	ContentType string
+	// Secret is used to sign the request body so receivers can verify the request
+	// was sent by Infracost. The request isn't signed if this is empty.
+	Secret string
+	// Retries is the number of times a failed request is retried.
+	Retries int
+	// Tag is used to identify the Infracost comment.
+	Tag string
+}
+
+// WebhookHandler posts comments to an arbitrary URL. Since webhooks can't be
+// queried for existing comments, the behavior is passed to the receiver along
+// with an idempotency key derived from the tag that the receiver can use to
+// find the messages it previously received for the same tag.
+type WebhookHandler struct {
+	httpClient *http.Client
+	extra      WebhookExtra
+	tag        string
+}
+
+// NewWebhookHandler creates a new WebhookHandler.
+func NewWebhookHandler(ctx context.Context, extra WebhookExtra) (*WebhookHandler, error) {
+	if extra.URL == "" {
+		return nil, errors.New("Webhook URL is required")
+	}
+
+	tag := extra.Tag
+	if tag == "" {
+		tag = defaultTag
+	}
+
+	if extra.ContentType == "" {
+		extra.ContentType = "text/markdown; charset=utf-8"
+	}
+
+	return &WebhookHandler{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		extra:      extra,
+		tag:        tag,
+	}, nil
+}
+
+// IdempotencyKey returns the key that is sent with the request. For the new
+// behavior the key is unique for each body so every distinct comment creates
+// a new message, for the other behaviors the key only depends on the tag so
+// receivers can update, hide or delete the previous messages.
+func (h *WebhookHandler) IdempotencyKey(behavior, body string) string {
+	s := h.tag
+	if behavior == "new" {
+		s += "\n" + body
+	}
+
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// Sign returns the signature of the body that is sent in the
+// X-Infracost-Signature header.
+func (h *WebhookHandler) Sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(h.extra.Secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// CommentWithBehavior posts the body to the webhook, retrying the request if it
+// fails with a network error, a 429 or a 5xx response.
+func (h *WebhookHandler) CommentWithBehavior(ctx context.Context, behavior, body string, opts *CommentOpts) (PostResult, error) {
+	backoff := webhookRetryBackoff
+
+	var err error
+	for i := 0; i <= h.extra.Retries; i++ {
+		var retry bool
+		retry, err = h.post(ctx, behavior, body, opts)
+		if err == nil {
+			return PostResult{Posted: true}, nil
+		}
+
+		if !retry || i == h.extra.Retries {
+			break
+		}
+
+		logging.Logger.Debug().Err(err).Msgf("received an error trying to post to webhook pausing %v seconds then will retry", backoff.Seconds())
+
+		select {
+		case <-ctx.Done():
+			return PostResult{Posted: false}, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return PostResult{Posted: false}, fmt.Errorf("%s\n%w", "The comment was generated successfully but could not be posted to the webhook:", err)
+}
+
+// post sends a single request to the webhook. It returns true if the request
+// can be retried.
+func (h *WebhookHandler) post(ctx context.Context, behavior, body string, opts *CommentOpts) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.extra.URL, bytes.NewBufferString(body))
+	if err != nil {
+		return false, errors.Wrap(err, "Error creating webhook request")
+	}
+
+	req.Header.Set("Content-Type", h.extra.ContentType)
+	for k, v := range h.extra.Headers {
+		req.Header.Set(k, v)
+	}
+
+	req.Header.Set(WebhookIdempotencyKeyHeader, h.IdempotencyKey(behavior, body))
+	req.Header.Set(WebhookBehaviorHeader, behavior)
+	req.Header.Set(WebhookTagHeader, h.tag)
+
+	if opts != nil && opts.ValidAt != nil {
+		req.Header.Set(WebhookValidAtHeader, opts.ValidAt.Format(time.RFC3339))
+	}
+
+	if h.extra.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, h.Sign([]byte(body)))
+	}
+
+	res, err := h.httpClient.Do(req)
+	if err != nil {
+		return true, errors.Wrap(err, "Error posting to webhook")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return false, nil
+	}
+
+	resBody, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	err = errors.Errorf("Error posting to webhook: %s %s", res.Status, bytes.TrimSpace(resBody))
+
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500, err
+}
@@ -0,0 +1,102 @@
+package comment
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookHandlerCommentWithBehavior(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		requests = append(requests, r)
+		bodies = append(bodies, string(b))
+	}))
+	defer ts.Close()
+
+	h, err := NewWebhookHandler(context.Background(), WebhookExtra{
+		URL:         ts.URL,
+		Headers:     map[string]string{"Authorization": "Bearer abc"},
+		ContentType: "application/json",
+		Secret:      "secret",
+		Tag:         "my-tag",
+	})
+	require.NoError(t, err)
+
+	validAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	res, err := h.CommentWithBehavior(context.Background(), "update", `{"text":"cost"}`, &CommentOpts{ValidAt: &validAt})
+	require.NoError(t, err)
+	assert.True(t, res.Posted)
+
+	require.Len(t, requests, 1)
+	r := requests[0]
+	assert.Equal(t, http.MethodPost, r.Method)
+	assert.Equal(t, `{"text":"cost"}`, bodies[0])
+	assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+	assert.Equal(t, "Bearer abc", r.Header.Get("Authorization"))
+	assert.Equal(t, "update", r.Header.Get(WebhookBehaviorHeader))
+	assert.Equal(t, "my-tag", r.Header.Get(WebhookTagHeader))
+	assert.Equal(t, "2024-01-02T03:04:05Z", r.Header.Get(WebhookValidAtHeader))
+	// echo -n '{"text":"cost"}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=e7ca4930f66ac5b081aa1e03767e61a216e2aba5d3d2497c24676c9bd5632c99", r.Header.Get(WebhookSignatureHeader))
+
+	_, err = h.CommentWithBehavior(context.Background(), "update", `{"text":"new cost"}`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, requests[0].Header.Get(WebhookIdempotencyKeyHeader), requests[1].Header.Get(WebhookIdempotencyKeyHeader))
+
+	_, err = h.CommentWithBehavior(context.Background(), "new", `{"text":"new cost"}`, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, requests[1].Header.Get(WebhookIdempotencyKeyHeader), requests[2].Header.Get(WebhookIdempotencyKeyHeader))
+}
+
+func TestWebhookHandlerRetries(t *testing.T) {
+	defer func(b time.Duration) { webhookRetryBackoff = b }(webhookRetryBackoff)
+	webhookRetryBackoff = time.Millisecond
+
+	tests := []struct {
+		name          string
+		statuses      []int
+		retries       int
+		expectedCalls int
+		expectedErr   string
+	}{
+		{name: "succeeds after server errors", statuses: []int{500, 429, 200}, retries: 3, expectedCalls: 3},
+		{name: "gives up after retries", statuses: []int{502, 502, 502}, retries: 2, expectedCalls: 3, expectedErr: "502 Bad Gateway"},
+		{name: "does not retry client errors", statuses: []int{400, 200}, retries: 3, expectedCalls: 1, expectedErr: "400 Bad Request"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statuses[calls])
+				calls++
+			}))
+			defer ts.Close()
+
+			h, err := NewWebhookHandler(context.Background(), WebhookExtra{URL: ts.URL, Retries: tt.retries})
+			require.NoError(t, err)
+
+			res, err := h.CommentWithBehavior(context.Background(), "update", "body", nil)
+			assert.Equal(t, tt.expectedCalls, calls)
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				assert.False(t, res.Posted)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, res.Posted)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+)
+
+// Budget defines limits on the monthly cost of projects. A budget that fails
+// fails the run so cost limits can be enforced in CI without Rego policies or
+// Infracost Cloud.
+//
+// A budget applies to each project that matches its Path or Project. If
+// neither is set the budget applies to the overall total of all projects.
+type Budget struct {
+	// Name is used to identify the budget in the output. It defaults to a
+	// description of the projects the budget applies to.
+	Name string `yaml:"name,omitempty"`
+	// Path matches the path of the project, including projects in its
+	// subdirectories. It supports glob patterns, e.g. infra/*/prod.
+	Path string `yaml:"path,omitempty"`
+	// Project matches the name of the project. It supports glob patterns.
+	Project string `yaml:"project,omitempty"`
+	// MaxMonthlyCost is the maximum total monthly cost.
+	MaxMonthlyCost *float64 `yaml:"max_monthly_cost,omitempty"`
+	// MaxMonthlyCostIncrease is the maximum increase of the total monthly cost
+	// compared to the previous cost, e.g. the base branch.
+	MaxMonthlyCostIncrease *float64 `yaml:"max_monthly_cost_increase,omitempty"`
+	// MaxMonthlyCostIncreasePercent is the maximum percentage increase of the
+	// total monthly cost compared to the previous cost, e.g. 10 for 10%. It is
+	// not checked if the previous cost is zero.
+	MaxMonthlyCostIncreasePercent *float64 `yaml:"max_monthly_cost_increase_percent,omitempty"`
+}
+
+// Validate checks that the budget defines at least one valid limit and that
+// its patterns are valid.
+func (b *Budget) Validate() error {
+	if b.MaxMonthlyCost == nil && b.MaxMonthlyCostIncrease == nil && b.MaxMonthlyCostIncreasePercent == nil {
+		return errors.New("one of max_monthly_cost, max_monthly_cost_increase or max_monthly_cost_increase_percent must be set")
+	}
+
+	for _, l := range []struct {
+		name  string
+		value *float64
+	}{
+		{"max_monthly_cost", b.MaxMonthlyCost},
+		{"max_monthly_cost_increase", b.MaxMonthlyCostIncrease},
+		{"max_monthly_cost_increase_percent", b.MaxMonthlyCostIncreasePercent},
+	} {
+		if l.value != nil && *l.value < 0 {
+			return fmt.Errorf("%s must not be negative, got %v", l.name, *l.value)
+		}
+	}
+
+	for _, p := range []struct {
+		name    string
+		pattern string
+	}{
+		{"path", b.Path},
+		{"project", b.Project},
+	} {
+		if p.pattern == "" {
+			continue
+		}
+
+		// doublestar only reports bad patterns for the parts of the pattern it
+		// reaches, so the pattern is matched against itself to check all of it.
+		if _, err := doublestar.Match(p.pattern, p.pattern); err != nil {
+			return fmt.Errorf("%s %q is not a valid pattern", p.name, p.pattern)
+		}
+	}
+
+	return nil
+}
+
+// IsOverall returns true if the budget applies to the overall total of all
+// projects rather than to individual projects.
+func (b *Budget) IsOverall() bool {
+	return b.Path == "" && b.Project == ""
+}
+
+// Matches returns true if the budget applies to the project with the given
+// path and name. Empty budget fields match any value.
+func (b *Budget) Matches(path, name string) bool {
+	if b.IsOverall() {
+		return false
+	}
+
+	if b.Path != "" && !matchProjectPath(b.Path, path) {
+		return false
+	}
+
+	if b.Project != "" {
+		ok, _ := doublestar.Match(b.Project, name)
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchProjectPath(pattern, path string) bool {
+	pattern = filepath.ToSlash(filepath.Clean(pattern))
+	path = filepath.ToSlash(filepath.Clean(path))
+
+	ok, _ := doublestar.Match(pattern, path)
+	if ok {
+		return true
+	}
+
+	// A path of a directory also matches the projects in its subdirectories,
+	// e.g. plan JSON files.
+	return strings.HasPrefix(path, strings.TrimSuffix(pattern, "/")+"/")
+}
+
+// String returns a human-readable description of the budget.
+func (b *Budget) String() string {
+	if b.Name != "" {
+		return b.Name
+	}
+
+	var matches []string
+	if b.Path != "" {
+		matches = append(matches, fmt.Sprintf("path %s", b.Path))
+	}
+	if b.Project != "" {
+		matches = append(matches, fmt.Sprintf("project %s", b.Project))
+	}
+
+	if len(matches) == 0 {
+		return "Overall budget"
+	}
+
+	return "Budget for " + strings.Join(matches, ", ")
+}
+
+// Budgets is a list of Budget.
+type Budgets []*Budget
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestBudget_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "max monthly cost", yaml: "max_monthly_cost: 1000"},
+		{name: "all limits", yaml: "path: infra/prod\nmax_monthly_cost: 1000\nmax_monthly_cost_increase: 100\nmax_monthly_cost_increase_percent: 10"},
+		{name: "missing limit", yaml: "path: infra/prod", wantErr: "one of max_monthly_cost, max_monthly_cost_increase or max_monthly_cost_increase_percent must be set"},
+		{name: "negative limit", yaml: "max_monthly_cost_increase: -5", wantErr: "max_monthly_cost_increase must not be negative, got -5"},
+		{name: "invalid pattern", yaml: "project: \"[prod\"\nmax_monthly_cost: 1000", wantErr: "project \"[prod\" is not a valid pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b Budget
+			require.NoError(t, yaml.Unmarshal([]byte(tt.yaml), &b))
+
+			err := b.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestBudget_Matches(t *testing.T) {
+	tests := []struct {
+		name   string
+		budget Budget
+		path   string
+		pName  string
+		want   bool
+	}{
+		{name: "overall budget", budget: Budget{}, path: "infra/prod", pName: "prod", want: false},
+		{name: "exact path", budget: Budget{Path: "infra/prod"}, path: "infra/prod", want: true},
+		{name: "path with dot prefix", budget: Budget{Path: "./infra/prod"}, path: "infra/prod", want: true},
+		{name: "subdirectory", budget: Budget{Path: "infra/prod"}, path: "infra/prod/plan.json", want: true},
+		{name: "sibling directory", budget: Budget{Path: "infra/prod"}, path: "infra/production", want: false},
+		{name: "glob path", budget: Budget{Path: "infra/*/prod"}, path: "infra/eu/prod", want: true},
+		{name: "project name", budget: Budget{Project: "prod-*"}, pName: "prod-eu", want: true},
+		{name: "project name mismatch", budget: Budget{Project: "prod-*"}, pName: "dev-eu", want: false},
+		{name: "path and project", budget: Budget{Path: "infra/prod", Project: "other"}, path: "infra/prod", pName: "prod", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.budget.Matches(tt.path, tt.pName))
+		})
+	}
+}
+
+func TestBudget_String(t *testing.T) {
+	assert.Equal(t, "Overall budget", (&Budget{}).String())
+	assert.Equal(t, "Prod", (&Budget{Name: "Prod", Path: "infra/prod"}).String())
+	assert.Equal(t, "Budget for path infra/prod, project prod", (&Budget{Path: "infra/prod", Project: "prod"}).String())
+}
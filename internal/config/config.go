@@ -70,7 +70,7 @@ type Project struct {
 	TerraformPlanFlags string `yaml:"terraform_plan_flags,omitempty" ignored:"true"`
 	// TerraformInitFlags are flags to pass to terraform init
 	TerraformInitFlags string `yaml:"terraform_init_flags,omitempty" ignored:"true"`
-	// TerraformBinary is an optional field used to change the path to the terraform or terragrunt binary
+	// TerraformBinary is an optional field used to change the path to the terraform, tofu or terragrunt binary. If unset, tofu is used for projects with .tofu files or when terraform isn't installed
 	TerraformBinary string `yaml:"terraform_binary,omitempty" envconfig:"TERRAFORM_BINARY"`
 	// TerraformWorkspace is an optional field used to set the Terraform workspace
 	TerraformWorkspace string `yaml:"terraform_workspace,omitempty" envconfig:"TERRAFORM_WORKSPACE"`
@@ -82,6 +82,12 @@ type Project struct {
 	TerraformCloudToken string `yaml:"terraform_cloud_token,omitempty" envconfig:"TERRAFORM_CLOUD_TOKEN"`
 	// TerragruntFlags set additional flags that should be passed to terragrunt.
 	TerragruntFlags string `yaml:"terragrunt_flags,omitempty" envconfig:"TERRAGRUNT_FLAGS"`
+	// CloudFormationParametersFile is the path to a JSON file of parameter values for a CloudFormation template.
+	// This supports the format used by the AWS CLI and the CodePipeline template configuration format.
+	CloudFormationParametersFile string `yaml:"cloudformation_parameters_file,omitempty" ignored:"true"`
+	// CloudFormationPastTemplate is the path to the CloudFormation template that is currently deployed. If set,
+	// the template at Path is treated as the update to this template so the diff shows the cost change.
+	CloudFormationPastTemplate string `yaml:"cloudformation_past_template,omitempty" ignored:"true"`
 	// UsageFile is the full path to usage file that specifies values for usage-based resources
 	UsageFile string `yaml:"usage_file,omitempty" ignored:"true"`
 	// TerraformUseState sets if the users wants to use the terraform state for infracost ops.
@@ -106,6 +112,8 @@ type Config struct {
 	PricingAPIEndpoint        string `yaml:"pricing_api_endpoint,omitempty" envconfig:"PRICING_API_ENDPOINT"`
 	PricingCacheDisabled      bool   `yaml:"pricing_cache_disabled" envconfig:"PRICING_CACHE_DISABLED"`
 	PricingCacheObjectSize    int    `yaml:"pricing_cache_object_size" envconfig:"PRICING_CACHE_OBJECT_SIZE"`
+	PricingSnapshotFile       string `yaml:"pricing_snapshot_file,omitempty" envconfig:"PRICING_SNAPSHOT_FILE"`
+	PricingSnapshotFallback   bool   `yaml:"pricing_snapshot_fallback,omitempty" envconfig:"PRICING_SNAPSHOT_FALLBACK"`
 	DefaultPricingAPIEndpoint string `yaml:"default_pricing_api_endpoint,omitempty" envconfig:"DEFAULT_PRICING_API_ENDPOINT"`
 	DashboardAPIEndpoint      string `yaml:"dashboard_api_endpoint,omitempty" envconfig:"DASHBOARD_API_ENDPOINT"`
 	DashboardEndpoint         string `yaml:"dashboard_endpoint,omitempty" envconfig:"DASHBOARD_ENDPOINT"`
@@ -138,13 +146,27 @@ type Config struct {
 
 	Projects        []*Project `yaml:"projects" ignored:"true"`
 	Format          string     `yaml:"format,omitempty" ignored:"true"`
+	TemplatePath    string     `yaml:"template_path,omitempty" ignored:"true"`
 	ShowAllProjects bool       `yaml:"show_all_projects,omitempty" ignored:"true"`
 	ShowSkipped     bool       `yaml:"show_skipped,omitempty" ignored:"true"`
+	Recommendations bool       `yaml:"recommendations,omitempty" ignored:"true"`
+	ForecastMonths  int        `yaml:"forecast_months,omitempty" ignored:"true"`
 	SyncUsageFile   bool       `yaml:"sync_usage_file,omitempty" ignored:"true"`
 	Fields          []string   `yaml:"fields,omitempty" ignored:"true"`
 	CompareTo       string
 	GitDiffTarget   *string
 
+	// PricingOverrides are discounts or fixed unit prices that are applied to
+	// the prices of matching cost components.
+	PricingOverrides PricingOverrides `yaml:"pricing_overrides,omitempty" ignored:"true"`
+
+	// Budgets are limits on the monthly cost of projects that fail the run
+	// when they are exceeded.
+	Budgets Budgets `yaml:"budgets,omitempty" ignored:"true"`
+
+	// TagPolicies define the tags that resources must have.
+	TagPolicies TagPolicies `yaml:"tag_policies,omitempty" ignored:"true"`
+
 	// Base configuration settings
 	// RootPath defines the raw value of the `--path` flag provided by the user
 	RootPath string
@@ -243,6 +265,9 @@ func (c *Config) LoadFromConfigFile(path string, cmd *cobra.Command) error {
 	}
 
 	c.Projects = cfgFile.Projects
+	c.PricingOverrides = cfgFile.PricingOverrides
+	c.Budgets = cfgFile.Budgets
+	c.TagPolicies = cfgFile.TagPolicies
 
 	// Reload the environment and global flags to overwrite any of the config file configs
 	err = c.LoadFromEnv()
@@ -392,6 +417,12 @@ func (c *Config) IsSelfHosted() bool {
 	return c.PricingAPIEndpoint != "" && c.PricingAPIEndpoint != c.DefaultPricingAPIEndpoint
 }
 
+// IsOfflinePricing returns true if prices should only be resolved from a local
+// price snapshot file instead of the Cloud Pricing API.
+func (c *Config) IsOfflinePricing() bool {
+	return c.PricingSnapshotFile != "" && !c.PricingSnapshotFallback
+}
+
 func IsTest() bool {
 	return os.Getenv("INFRACOST_ENV") == "test" || strings.HasSuffix(os.Args[0], ".test")
 }
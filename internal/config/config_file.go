@@ -108,6 +108,15 @@ func (y *YamlError) Error() string {
 type ConfigFileSpec struct {
 	Version  string     `yaml:"version"`
 	Projects []*Project `yaml:"projects" ignored:"true"`
+	// PricingOverrides defines discounts or fixed unit prices that are applied
+	// to the prices of all projects.
+	PricingOverrides PricingOverrides `yaml:"pricing_overrides,omitempty" ignored:"true"`
+	// Budgets defines limits on the monthly cost of projects that fail the
+	// run when they are exceeded.
+	Budgets Budgets `yaml:"budgets,omitempty" ignored:"true"`
+	// TagPolicies defines the tags that resources must have. Resources that
+	// don't have them fail the run, unless the tag policy is a warning.
+	TagPolicies TagPolicies `yaml:"tag_policies,omitempty" ignored:"true"`
 }
 
 // UnmarshalYAML implements the yaml.v2.Unmarshaller interface. Marshalls the
@@ -194,8 +203,50 @@ func (f *ConfigFileSpec) UnmarshalYAML(unmarshal func(interface{}) error) error
 		return &YamlError{raw: ErrorInvalidConfigFile}
 	}
 
+	overridesError := &YamlError{
+		base: "config file is invalid, see https://infracost.io/config-file for valid options",
+	}
+	for i, o := range c.PricingOverrides {
+		if err := o.Validate(); err != nil {
+			overridesError.add(fmt.Errorf("pricing override at index %d is invalid: %w", i, err))
+		}
+	}
+
+	if overridesError.isValid() {
+		return overridesError
+	}
+
+	budgetsError := &YamlError{
+		base: "config file is invalid, see https://infracost.io/config-file for valid options",
+	}
+	for i, b := range c.Budgets {
+		if err := b.Validate(); err != nil {
+			budgetsError.add(fmt.Errorf("budget at index %d is invalid: %w", i, err))
+		}
+	}
+
+	if budgetsError.isValid() {
+		return budgetsError
+	}
+
+	tagPoliciesError := &YamlError{
+		base: "config file is invalid, see https://infracost.io/config-file for valid options",
+	}
+	for i, t := range c.TagPolicies {
+		if err := t.Validate(); err != nil {
+			tagPoliciesError.add(fmt.Errorf("tag policy at index %d is invalid: %w", i, err))
+		}
+	}
+
+	if tagPoliciesError.isValid() {
+		return tagPoliciesError
+	}
+
 	f.Version = c.Version
 	f.Projects = c.Projects
+	f.PricingOverrides = c.PricingOverrides
+	f.Budgets = c.Budgets
+	f.TagPolicies = c.TagPolicies
 	return nil
 }
 
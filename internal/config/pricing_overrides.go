@@ -0,0 +1,153 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// PricingOverride defines a percentage discount or a fixed unit price that is
+// applied to the prices of matching cost components. This can be used to
+// model enterprise discount programmes or privately negotiated prices.
+//
+// An override matches a cost component if all of its non-empty match fields
+// match. When more than one override matches a cost component, the most
+// specific override (the one with the most match fields set) is used. If
+// overrides are equally specific the one defined last is used.
+type PricingOverride struct {
+	// Vendor matches the vendor of the price, e.g. aws, azure or gcp.
+	Vendor string `yaml:"vendor,omitempty"`
+	// Service matches the service of the price, e.g. AmazonEC2.
+	Service string `yaml:"service,omitempty"`
+	// ProductFamily matches the product family of the price, e.g. Compute Instance.
+	ProductFamily string `yaml:"product_family,omitempty"`
+	// Region matches the region of the price, e.g. eu-west-1.
+	Region string `yaml:"region,omitempty"`
+	// ResourceType matches the Terraform resource type, e.g. aws_instance.
+	ResourceType string `yaml:"resource_type,omitempty"`
+	// DiscountPercent is the percentage discount that is applied to the list
+	// price, e.g. 12 for a 12% discount.
+	DiscountPercent *float64 `yaml:"discount_percent,omitempty"`
+	// UnitPrice is a fixed price per unit that replaces the list price. The
+	// price is per unit as shown in the output, e.g. per hour or per GB.
+	UnitPrice *string `yaml:"unit_price,omitempty"`
+}
+
+// Validate checks that the override defines exactly one valid adjustment.
+func (o *PricingOverride) Validate() error {
+	if o.DiscountPercent == nil && o.UnitPrice == nil {
+		return errors.New("one of discount_percent or unit_price must be set")
+	}
+
+	if o.DiscountPercent != nil && o.UnitPrice != nil {
+		return errors.New("discount_percent and unit_price cannot both be set")
+	}
+
+	if o.DiscountPercent != nil && (*o.DiscountPercent < 0 || *o.DiscountPercent > 100) {
+		return fmt.Errorf("discount_percent must be between 0 and 100, got %v", *o.DiscountPercent)
+	}
+
+	if o.UnitPrice != nil {
+		p, err := decimal.NewFromString(*o.UnitPrice)
+		if err != nil {
+			return fmt.Errorf("unit_price %q is not a valid number", *o.UnitPrice)
+		}
+
+		if p.IsNegative() {
+			return fmt.Errorf("unit_price must not be negative, got %s", *o.UnitPrice)
+		}
+	}
+
+	return nil
+}
+
+// Matches returns true if the override applies to a price with the given
+// attributes. Empty override fields match any value.
+func (o *PricingOverride) Matches(vendor, service, productFamily, region, resourceType string) bool {
+	return matchOverrideField(o.Vendor, vendor) &&
+		matchOverrideField(o.Service, service) &&
+		matchOverrideField(o.ProductFamily, productFamily) &&
+		matchOverrideField(o.Region, region) &&
+		matchOverrideField(o.ResourceType, resourceType)
+}
+
+// Specificity returns the number of match fields that are set.
+func (o *PricingOverride) Specificity() int {
+	n := 0
+	for _, f := range []string{o.Vendor, o.Service, o.ProductFamily, o.Region, o.ResourceType} {
+		if f != "" {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Apply returns the effective price for the list price. unitMultiplier is the
+// multiplier used to convert the list price to the unit shown in the output.
+func (o *PricingOverride) Apply(listPrice decimal.Decimal, unitMultiplier decimal.Decimal) decimal.Decimal {
+	if o.UnitPrice != nil {
+		p, _ := decimal.NewFromString(*o.UnitPrice)
+		if unitMultiplier.IsZero() {
+			return p
+		}
+
+		return p.Div(unitMultiplier)
+	}
+
+	discount := decimal.NewFromFloat(*o.DiscountPercent).Div(decimal.NewFromInt(100))
+	return listPrice.Mul(decimal.NewFromInt(1).Sub(discount))
+}
+
+// String returns a human-readable description of the override.
+func (o *PricingOverride) String() string {
+	var matches []string
+	for _, f := range [][2]string{
+		{"vendor", o.Vendor},
+		{"service", o.Service},
+		{"product_family", o.ProductFamily},
+		{"region", o.Region},
+		{"resource_type", o.ResourceType},
+	} {
+		if f[1] != "" {
+			matches = append(matches, fmt.Sprintf("%s=%s", f[0], f[1]))
+		}
+	}
+
+	match := "all prices"
+	if len(matches) > 0 {
+		match = strings.Join(matches, ", ")
+	}
+
+	if o.UnitPrice != nil {
+		return fmt.Sprintf("unit price %s for %s", *o.UnitPrice, match)
+	}
+
+	return fmt.Sprintf("%v%% discount for %s", *o.DiscountPercent, match)
+}
+
+// PricingOverrides is a list of PricingOverride.
+type PricingOverrides []*PricingOverride
+
+// Find returns the most specific override that matches a price with the given
+// attributes, or nil if no override matches.
+func (p PricingOverrides) Find(vendor, service, productFamily, region, resourceType string) *PricingOverride {
+	var found *PricingOverride
+	for _, o := range p {
+		if !o.Matches(vendor, service, productFamily, region, resourceType) {
+			continue
+		}
+
+		if found == nil || o.Specificity() >= found.Specificity() {
+			found = o
+		}
+	}
+
+	return found
+}
+
+func matchOverrideField(want, got string) bool {
+	return want == "" || strings.EqualFold(want, got)
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestPricingOverride_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "discount", yaml: "discount_percent: 12"},
+		{name: "unit price", yaml: "unit_price: \"0.05\""},
+		{name: "missing adjustment", yaml: "vendor: aws", wantErr: "one of discount_percent or unit_price must be set"},
+		{name: "both adjustments", yaml: "discount_percent: 12\nunit_price: \"0.05\"", wantErr: "discount_percent and unit_price cannot both be set"},
+		{name: "discount out of range", yaml: "discount_percent: 120", wantErr: "discount_percent must be between 0 and 100, got 120"},
+		{name: "invalid unit price", yaml: "unit_price: abc", wantErr: "unit_price \"abc\" is not a valid number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var o PricingOverride
+			require.NoError(t, yaml.Unmarshal([]byte(tt.yaml), &o))
+
+			err := o.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPricingOverrides_Find(t *testing.T) {
+	var overrides PricingOverrides
+	err := yaml.Unmarshal([]byte(`
+- vendor: aws
+  discount_percent: 12
+- vendor: aws
+  service: AmazonEC2
+  region: eu-west-1
+  discount_percent: 30
+- resource_type: aws_db_instance
+  discount_percent: 5
+- resource_type: aws_db_instance
+  discount_percent: 10
+`), &overrides)
+	require.NoError(t, err)
+
+	assert.Equal(t, overrides[0], overrides.Find("aws", "AmazonEC2", "Compute Instance", "us-east-1", "aws_instance"))
+	assert.Equal(t, overrides[1], overrides.Find("AWS", "AmazonEC2", "Compute Instance", "eu-west-1", "aws_instance"))
+	assert.Equal(t, overrides[3], overrides.Find("aws", "AmazonRDS", "Database Instance", "eu-west-1", "aws_db_instance"))
+	assert.Nil(t, overrides.Find("gcp", "Compute Engine", "Compute Instance", "us-central1", "google_compute_instance"))
+}
+
+func TestPricingOverride_Apply(t *testing.T) {
+	discount := 12.0
+	o := &PricingOverride{DiscountPercent: &discount}
+	assert.Equal(t, "0.88", o.Apply(decimal.NewFromInt(1), decimal.NewFromInt(1)).String())
+
+	unitPrice := "10"
+	o = &PricingOverride{UnitPrice: &unitPrice}
+	assert.Equal(t, "0.01", o.Apply(decimal.NewFromInt(1), decimal.NewFromInt(1000)).String())
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+)
+
+// TagPolicy defines the tags that resources must have. Tag policies are
+// evaluated against the tags of the resources, including any provider default
+// tags, so untagged resources can be blocked in CI without Infracost Cloud.
+//
+// A tag policy applies to the taggable resources of the projects that match
+// its Path, and can be limited to resources of certain types.
+type TagPolicy struct {
+	// Name is used to identify the tag policy in the output. It defaults to a
+	// description of the resources the tag policy applies to.
+	Name string `yaml:"name,omitempty"`
+	// Path matches the path of the project, including projects in its
+	// subdirectories. It supports glob patterns, e.g. infra/*/prod.
+	Path string `yaml:"path,omitempty"`
+	// ResourceTypes limits the tag policy to resources of these types. It
+	// supports glob patterns, e.g. aws_*.
+	ResourceTypes []string `yaml:"resource_types,omitempty"`
+	// Tags are the tag requirements of the resources.
+	Tags []TagRequirement `yaml:"tags"`
+	// Warning reports resources that don't meet the requirements as a warning
+	// instead of failing the run.
+	Warning bool `yaml:"warning,omitempty"`
+}
+
+// TagRequirement defines a tag key and its valid values.
+type TagRequirement struct {
+	Key string `yaml:"key"`
+	// Optional tags don't have to be set, but their values are checked if
+	// they are.
+	Optional bool `yaml:"optional,omitempty"`
+	// AllowedValues are the valid values of the tag.
+	AllowedValues []string `yaml:"allowed_values,omitempty"`
+	// AllowedPattern is a regular expression that matches the valid values of
+	// the tag, e.g. ^[0-9]{4}$.
+	AllowedPattern string `yaml:"allowed_pattern,omitempty"`
+}
+
+// Validate checks that the tag policy defines at least one valid tag
+// requirement and that its patterns are valid.
+func (t *TagPolicy) Validate() error {
+	if len(t.Tags) == 0 {
+		return errors.New("tags must not be empty")
+	}
+
+	for i, tag := range t.Tags {
+		if tag.Key == "" {
+			return fmt.Errorf("tag at index %d must have a key", i)
+		}
+
+		if tag.AllowedPattern != "" {
+			if _, err := regexp.Compile(tag.AllowedPattern); err != nil {
+				return fmt.Errorf("tag %s allowed_pattern %q is not a valid regular expression", tag.Key, tag.AllowedPattern)
+			}
+		}
+	}
+
+	patterns := append([]string{t.Path}, t.ResourceTypes...)
+	for _, p := range patterns {
+		if p == "" {
+			continue
+		}
+
+		// doublestar only reports bad patterns for the parts of the pattern it
+		// reaches, so the pattern is matched against itself to check all of it.
+		if _, err := doublestar.Match(p, p); err != nil {
+			return fmt.Errorf("%q is not a valid pattern", p)
+		}
+	}
+
+	return nil
+}
+
+// MatchesPath returns true if the tag policy applies to the project with the
+// given path.
+func (t *TagPolicy) MatchesPath(path string) bool {
+	return t.Path == "" || matchProjectPath(t.Path, path)
+}
+
+// MatchesResourceType returns true if the tag policy applies to resources of
+// the given type.
+func (t *TagPolicy) MatchesResourceType(resourceType string) bool {
+	if len(t.ResourceTypes) == 0 {
+		return true
+	}
+
+	for _, p := range t.ResourceTypes {
+		if ok, _ := doublestar.Match(p, resourceType); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Violations returns a description of each tag requirement that the tags
+// don't meet.
+func (t *TagPolicy) Violations(tags map[string]string) []string {
+	var violations []string
+
+	for _, tag := range t.Tags {
+		value, ok := tags[tag.Key]
+		if !ok {
+			if !tag.Optional {
+				violations = append(violations, fmt.Sprintf("Missing required tag %s", tag.Key))
+			}
+
+			continue
+		}
+
+		if len(tag.AllowedValues) > 0 && !isAllowedValue(tag.AllowedValues, value) {
+			violations = append(violations, fmt.Sprintf("Tag %s has value %q, allowed values are %s", tag.Key, value, strings.Join(tag.AllowedValues, ", ")))
+			continue
+		}
+
+		if tag.AllowedPattern != "" {
+			// The pattern is checked by Validate.
+			if ok, _ := regexp.MatchString(tag.AllowedPattern, value); !ok {
+				violations = append(violations, fmt.Sprintf("Tag %s has value %q, which does not match %s", tag.Key, value, tag.AllowedPattern))
+			}
+		}
+	}
+
+	return violations
+}
+
+func isAllowedValue(allowed []string, value string) bool {
+	for _, v := range allowed {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns a human-readable description of the tag policy.
+func (t *TagPolicy) String() string {
+	if t.Name != "" {
+		return t.Name
+	}
+
+	var matches []string
+	if len(t.ResourceTypes) > 0 {
+		matches = append(matches, fmt.Sprintf("resource types %s", strings.Join(t.ResourceTypes, ", ")))
+	}
+	if t.Path != "" {
+		matches = append(matches, fmt.Sprintf("path %s", t.Path))
+	}
+
+	if len(matches) == 0 {
+		return "Tag policy"
+	}
+
+	return "Tag policy for " + strings.Join(matches, ", ")
+}
+
+// TagPolicies is a list of TagPolicy.
+type TagPolicies []*TagPolicy
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestTagPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "required tag", yaml: "tags:\n  - key: Owner"},
+		{name: "all fields", yaml: "path: infra/*\nresource_types: [aws_*]\nwarning: true\ntags:\n  - key: Environment\n    allowed_values: [dev, prod]\n  - key: CostCenter\n    optional: true\n    allowed_pattern: ^[0-9]{4}$"},
+		{name: "missing tags", yaml: "path: infra/prod", wantErr: "tags must not be empty"},
+		{name: "missing key", yaml: "tags:\n  - optional: true", wantErr: "tag at index 0 must have a key"},
+		{name: "invalid regex", yaml: "tags:\n  - key: Owner\n    allowed_pattern: \"[a-z\"", wantErr: "tag Owner allowed_pattern \"[a-z\" is not a valid regular expression"},
+		{name: "invalid resource type pattern", yaml: "resource_types: [\"aws_[instance\"]\ntags:\n  - key: Owner", wantErr: "\"aws_[instance\" is not a valid pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tp TagPolicy
+			require.NoError(t, yaml.Unmarshal([]byte(tt.yaml), &tp))
+
+			err := tp.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTagPolicy_Matches(t *testing.T) {
+	tp := &TagPolicy{Path: "infra/prod", ResourceTypes: []string{"aws_instance", "aws_db_*"}}
+
+	assert.True(t, tp.MatchesPath("infra/prod"))
+	assert.True(t, tp.MatchesPath("infra/prod/plan.json"))
+	assert.False(t, tp.MatchesPath("infra/production"))
+	assert.True(t, tp.MatchesResourceType("aws_instance"))
+	assert.True(t, tp.MatchesResourceType("aws_db_instance"))
+	assert.False(t, tp.MatchesResourceType("aws_s3_bucket"))
+
+	tp = &TagPolicy{}
+	assert.True(t, tp.MatchesPath(""))
+	assert.True(t, tp.MatchesResourceType("google_compute_instance"))
+}
+
+func TestTagPolicy_Violations(t *testing.T) {
+	tp := &TagPolicy{Tags: []TagRequirement{
+		{Key: "Environment", AllowedValues: []string{"dev", "prod"}},
+		{Key: "Owner"},
+		{Key: "CostCenter", Optional: true, AllowedPattern: "^[0-9]{4}$"},
+	}}
+
+	assert.Empty(t, tp.Violations(map[string]string{"Environment": "prod", "Owner": "team-a"}))
+	assert.Empty(t, tp.Violations(map[string]string{"Environment": "dev", "Owner": "team-a", "CostCenter": "1234"}))
+
+	assert.Equal(t, []string{
+		"Tag Environment has value \"staging\", allowed values are dev, prod",
+		"Missing required tag Owner",
+		"Tag CostCenter has value \"abc\", which does not match ^[0-9]{4}$",
+	}, tp.Violations(map[string]string{"Environment": "staging", "CostCenter": "abc"}))
+
+	assert.Equal(t, []string{
+		"Missing required tag Environment",
+		"Missing required tag Owner",
+	}, tp.Violations(map[string]string{}))
+}
+
+func TestTagPolicy_String(t *testing.T) {
+	assert.Equal(t, "Tag policy", (&TagPolicy{}).String())
+	assert.Equal(t, "Owners", (&TagPolicy{Name: "Owners", Path: "infra/prod"}).String())
+	assert.Equal(t, "Tag policy for resource types aws_instance, aws_db_*, path infra/prod", (&TagPolicy{Path: "infra/prod", ResourceTypes: []string{"aws_instance", "aws_db_*"}}).String())
+}
@@ -0,0 +1,189 @@
+package funcs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// ProviderFunctions returns the provider-defined functions that are supported,
+// keyed by the name they are called with, e.g. provider::aws::arn_parse.
+// Provider-defined functions are supported by OpenTofu 1.7+ and Terraform
+// 1.8+. Calls to functions of other providers are mocked like any other
+// unknown function.
+func ProviderFunctions() map[string]function.Function {
+	providers := map[string]map[string]function.Function{
+		"aws": {
+			"arn_build":          ArnBuildFunc,
+			"arn_parse":          ArnParseFunc,
+			"trim_iam_role_path": TrimIAMRolePathFunc,
+		},
+		"google": {
+			"location_from_id": makeIDSegmentFunc("locations"),
+			"name_from_id":     NameFromIDFunc,
+			"project_from_id":  makeIDSegmentFunc("projects"),
+			"region_from_id":   makeIDSegmentFunc("regions"),
+			"region_from_zone": RegionFromZoneFunc,
+			"zone_from_id":     makeIDSegmentFunc("zones"),
+		},
+	}
+
+	funcs := make(map[string]function.Function)
+	for provider, providerFuncs := range providers {
+		for name, f := range providerFuncs {
+			funcs[fmt.Sprintf("provider::%s::%s", provider, name)] = f
+		}
+	}
+
+	return funcs
+}
+
+var arnType = cty.Object(map[string]cty.Type{
+	"partition":  cty.String,
+	"service":    cty.String,
+	"region":     cty.String,
+	"account_id": cty.String,
+	"resource":   cty.String,
+})
+
+// ArnParseFunc parses an AWS ARN into its parts, matching the arn_parse
+// function of the AWS provider.
+var ArnParseFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "arn",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(arnType),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		arn := args[0].AsString()
+
+		parts := strings.SplitN(arn, ":", 6)
+		if len(parts) != 6 || parts[0] != "arn" {
+			return cty.UnknownVal(retType), fmt.Errorf("invalid ARN %q", arn)
+		}
+
+		return cty.ObjectVal(map[string]cty.Value{
+			"partition":  cty.StringVal(parts[1]),
+			"service":    cty.StringVal(parts[2]),
+			"region":     cty.StringVal(parts[3]),
+			"account_id": cty.StringVal(parts[4]),
+			"resource":   cty.StringVal(parts[5]),
+		}), nil
+	},
+})
+
+// ArnBuildFunc builds an AWS ARN from its parts, matching the arn_build
+// function of the AWS provider.
+var ArnBuildFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "partition", Type: cty.String},
+		{Name: "service", Type: cty.String},
+		{Name: "region", Type: cty.String},
+		{Name: "account_id", Type: cty.String},
+		{Name: "resource", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		parts := make([]string, 0, len(args)+1)
+		parts = append(parts, "arn")
+		for _, arg := range args {
+			parts = append(parts, arg.AsString())
+		}
+
+		return cty.StringVal(strings.Join(parts, ":")), nil
+	},
+})
+
+// TrimIAMRolePathFunc returns the name of an IAM role from its ARN without
+// the path, matching the trim_iam_role_path function of the AWS provider.
+var TrimIAMRolePathFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "arn",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		arn := args[0].AsString()
+
+		parts := strings.SplitN(arn, ":", 6)
+		if len(parts) != 6 || !strings.HasPrefix(parts[5], "role/") {
+			return cty.UnknownVal(cty.String), fmt.Errorf("invalid IAM role ARN %q", arn)
+		}
+
+		resource := strings.Split(parts[5], "/")
+		parts[5] = "role/" + resource[len(resource)-1]
+
+		return cty.StringVal(strings.Join(parts, ":")), nil
+	},
+})
+
+// RegionFromZoneFunc returns the region of a GCP zone, matching the
+// region_from_zone function of the Google provider.
+var RegionFromZoneFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "zone",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		zone := args[0].AsString()
+
+		i := strings.LastIndex(zone, "-")
+		if i <= 0 {
+			return cty.UnknownVal(cty.String), fmt.Errorf("invalid zone %q", zone)
+		}
+
+		return cty.StringVal(zone[:i]), nil
+	},
+})
+
+// NameFromIDFunc returns the name from a GCP resource ID or self link,
+// matching the name_from_id function of the Google provider.
+var NameFromIDFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "id",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		parts := strings.Split(strings.TrimSuffix(args[0].AsString(), "/"), "/")
+		return cty.StringVal(parts[len(parts)-1]), nil
+	},
+})
+
+// makeIDSegmentFunc returns a function that returns the segment following
+// the collection name in a GCP resource ID or self link, e.g. the project of
+// projects/my-project/zones/us-central1-a/instances/vm.
+func makeIDSegmentFunc(collection string) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "id",
+				Type: cty.String,
+			},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			id := args[0].AsString()
+
+			parts := strings.Split(id, "/")
+			for i := 0; i < len(parts)-1; i++ {
+				if parts[i] == collection {
+					return cty.StringVal(parts[i+1]), nil
+				}
+			}
+
+			return cty.UnknownVal(cty.String), fmt.Errorf("%q does not contain %s", id, collection)
+		},
+	})
+}
@@ -0,0 +1,104 @@
+package funcs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestProviderFunctions(t *testing.T) {
+	fns := ProviderFunctions()
+
+	tests := []struct {
+		Name string
+		Args []cty.Value
+		Want cty.Value
+		Err  bool
+	}{
+		{
+			"provider::aws::arn_parse",
+			[]cty.Value{cty.StringVal("arn:aws:ec2:eu-west-1:123456789012:instance/i-123")},
+			cty.ObjectVal(map[string]cty.Value{
+				"partition":  cty.StringVal("aws"),
+				"service":    cty.StringVal("ec2"),
+				"region":     cty.StringVal("eu-west-1"),
+				"account_id": cty.StringVal("123456789012"),
+				"resource":   cty.StringVal("instance/i-123"),
+			}),
+			false,
+		},
+		{
+			"provider::aws::arn_parse",
+			[]cty.Value{cty.StringVal("not-an-arn")},
+			cty.NilVal,
+			true,
+		},
+		{
+			"provider::aws::arn_build",
+			[]cty.Value{cty.StringVal("aws"), cty.StringVal("s3"), cty.StringVal(""), cty.StringVal(""), cty.StringVal("bucket/key")},
+			cty.StringVal("arn:aws:s3:::bucket/key"),
+			false,
+		},
+		{
+			"provider::aws::trim_iam_role_path",
+			[]cty.Value{cty.StringVal("arn:aws:iam::123456789012:role/with/path/example")},
+			cty.StringVal("arn:aws:iam::123456789012:role/example"),
+			false,
+		},
+		{
+			"provider::google::region_from_zone",
+			[]cty.Value{cty.StringVal("europe-west1-b")},
+			cty.StringVal("europe-west1"),
+			false,
+		},
+		{
+			"provider::google::name_from_id",
+			[]cty.Value{cty.StringVal("projects/my-project/zones/us-central1-a/instances/vm")},
+			cty.StringVal("vm"),
+			false,
+		},
+		{
+			"provider::google::project_from_id",
+			[]cty.Value{cty.StringVal("https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instances/vm")},
+			cty.StringVal("my-project"),
+			false,
+		},
+		{
+			"provider::google::zone_from_id",
+			[]cty.Value{cty.StringVal("projects/my-project/zones/us-central1-a/instances/vm")},
+			cty.StringVal("us-central1-a"),
+			false,
+		},
+		{
+			"provider::google::region_from_id",
+			[]cty.Value{cty.StringVal("projects/my-project/zones/us-central1-a/instances/vm")},
+			cty.NilVal,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s(%#v)", test.Name, test.Args), func(t *testing.T) {
+			fn, ok := fns[test.Name]
+			if !ok {
+				t.Fatalf("function %s not found", test.Name)
+			}
+
+			got, err := fn.Call(test.Args)
+
+			if test.Err {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
@@ -56,7 +56,7 @@ func (c *Cache) lookupModule(key string, moduleCall *tfconfig.ModuleCall) (*Mani
 	var registrySource = ""
 	moduleAddr, submodulePath, err := splitModuleSubDir(moduleCall.Source)
 	if err == nil {
-		registryModuleAddr, err := normalizeRegistrySource(moduleAddr)
+		registryModuleAddr, err := normalizeRegistrySource(moduleAddr, c.disco.DefaultRegistryHost())
 		if err == nil {
 			registrySource = joinModuleSubDir(registryModuleAddr, submodulePath)
 		}
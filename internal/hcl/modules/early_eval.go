@@ -0,0 +1,297 @@
+package modules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// maxLocalsPasses limits the number of passes over the locals when resolving
+// locals that reference other locals.
+const maxLocalsPasses = 10
+
+var earlyEvalSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "variable", LabelNames: []string{"name"}},
+		{Type: "locals"},
+		{Type: "module", LabelNames: []string{"name"}},
+	},
+}
+
+var variableDefaultSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{{Name: "default"}},
+}
+
+var moduleSourceSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{{Name: "source"}, {Name: "version"}},
+}
+
+// earlyEvalFile holds the blocks of a file that are used for early evaluation.
+type earlyEvalFile struct {
+	variables []*hcl.Block
+	locals    []*hcl.Block
+	modules   []*hcl.Block
+}
+
+// earlyEvaluator evaluates the source and version of module calls that
+// reference variables or locals. OpenTofu allows this as long as the values
+// can be worked out before the modules are loaded, i.e. they only depend on
+// variable defaults, tfvars files, TF_VAR_ environment variables and locals
+// that use these.
+type earlyEvaluator struct {
+	dir       string
+	hclParser *SharedHCLParser
+	files     []earlyEvalFile
+}
+
+func newEarlyEvaluator(dir string, hclParser *SharedHCLParser) *earlyEvaluator {
+	return &earlyEvaluator{
+		dir:       dir,
+		hclParser: hclParser,
+	}
+}
+
+// addFile adds the blocks of the file and returns the diagnostics with any
+// errors from tfconfig about non-literal module sources and versions
+// removed, since these are evaluated by the earlyEvaluator instead.
+func (e *earlyEvaluator) addFile(f *hcl.File, diags hcl.Diagnostics) hcl.Diagnostics {
+	content, _, _ := f.Body.PartialContent(earlyEvalSchema)
+	if content == nil {
+		return diags
+	}
+
+	var file earlyEvalFile
+	var ranges []hcl.Range
+
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "variable":
+			file.variables = append(file.variables, block)
+		case "locals":
+			file.locals = append(file.locals, block)
+		case "module":
+			file.modules = append(file.modules, block)
+
+			attrs, _, _ := block.Body.PartialContent(moduleSourceSchema)
+			for _, attr := range attrs.Attributes {
+				if len(attr.Expr.Variables()) > 0 {
+					ranges = append(ranges, attr.Expr.Range())
+				}
+			}
+		}
+	}
+
+	e.files = append(e.files, file)
+
+	if len(ranges) == 0 {
+		return diags
+	}
+
+	var filtered hcl.Diagnostics
+	for _, d := range diags {
+		if d.Subject != nil && inRanges(*d.Subject, ranges) {
+			continue
+		}
+
+		filtered = append(filtered, d)
+	}
+
+	return filtered
+}
+
+// evaluateModuleCalls sets the source and version of the module calls that
+// reference variables or locals.
+func (e *earlyEvaluator) evaluateModuleCalls(mod *tfconfig.Module) error {
+	var ctx *hcl.EvalContext
+
+	for _, file := range e.files {
+		for _, block := range file.modules {
+			mc, ok := mod.ModuleCalls[block.Labels[0]]
+			if !ok {
+				continue
+			}
+
+			attrs, _, _ := block.Body.PartialContent(moduleSourceSchema)
+			for name, attr := range attrs.Attributes {
+				if len(attr.Expr.Variables()) == 0 {
+					continue
+				}
+
+				if ctx == nil {
+					ctx = e.evalContext()
+				}
+
+				val, diags := attr.Expr.Value(ctx)
+				if diags.HasErrors() {
+					return fmt.Errorf("failed to evaluate %s of module %s: %w", name, mc.Name, diags)
+				}
+
+				s, err := convert.Convert(val, cty.String)
+				if err != nil || !s.IsWhollyKnown() || s.IsNull() {
+					return fmt.Errorf("%s of module %s must be known before modules are loaded", name, mc.Name)
+				}
+
+				if name == "source" {
+					mc.Source = s.AsString()
+				} else {
+					mc.Version = s.AsString()
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// evalContext returns the context with the variables and locals that can be
+// evaluated early.
+func (e *earlyEvaluator) evalContext() *hcl.EvalContext {
+	vars := e.variableValues()
+
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var":   cty.ObjectVal(vars),
+			"local": cty.EmptyObjectVal,
+		},
+	}
+
+	var attrs []*hcl.Attribute
+	for _, file := range e.files {
+		for _, block := range file.locals {
+			blockAttrs, diags := block.Body.JustAttributes()
+			if diags.HasErrors() {
+				continue
+			}
+
+			for _, attr := range blockAttrs {
+				attrs = append(attrs, attr)
+			}
+		}
+	}
+
+	locals := map[string]cty.Value{}
+
+	// Locals can reference other locals so keep evaluating the remaining
+	// locals until no more can be resolved.
+	for i := 0; i < maxLocalsPasses && len(attrs) > 0; i++ {
+		var remaining []*hcl.Attribute
+
+		for _, attr := range attrs {
+			val, diags := attr.Expr.Value(ctx)
+			if diags.HasErrors() || !val.IsWhollyKnown() {
+				remaining = append(remaining, attr)
+				continue
+			}
+
+			locals[attr.Name] = val
+		}
+
+		if len(remaining) == len(attrs) {
+			break
+		}
+
+		attrs = remaining
+		ctx.Variables["local"] = cty.ObjectVal(locals)
+	}
+
+	return ctx
+}
+
+// variableValues returns the values of the variables using the same
+// precedence as Terraform and OpenTofu: the default, then TF_VAR_ environment
+// variables, then terraform.tfvars and finally *.auto.tfvars files.
+func (e *earlyEvaluator) variableValues() map[string]cty.Value {
+	vars := map[string]cty.Value{}
+
+	for _, file := range e.files {
+		for _, block := range file.variables {
+			name := block.Labels[0]
+			vars[name] = cty.DynamicVal
+
+			content, _, _ := block.Body.PartialContent(variableDefaultSchema)
+			if attr, ok := content.Attributes["default"]; ok {
+				if val, diags := attr.Expr.Value(nil); !diags.HasErrors() {
+					vars[name] = val
+				}
+			}
+		}
+	}
+
+	for name := range vars {
+		if v, ok := os.LookupEnv("TF_VAR_" + name); ok {
+			vars[name] = cty.StringVal(v)
+		}
+	}
+
+	for _, path := range e.varFiles() {
+		parseFunc := e.hclParser.ParseHCLFile
+		if strings.HasSuffix(path, ".json") {
+			parseFunc = e.hclParser.ParseJSONFile
+		}
+
+		f, diags := parseFunc(path)
+		if diags.HasErrors() || f == nil {
+			continue
+		}
+
+		attrs, diags := f.Body.JustAttributes()
+		if diags.HasErrors() {
+			continue
+		}
+
+		for name, attr := range attrs {
+			if _, ok := vars[name]; !ok {
+				continue
+			}
+
+			if val, diags := attr.Expr.Value(nil); !diags.HasErrors() {
+				vars[name] = val
+			}
+		}
+	}
+
+	return vars
+}
+
+// varFiles returns the var files that are loaded automatically in the order
+// they are applied.
+func (e *earlyEvaluator) varFiles() []string {
+	var files []string
+
+	for _, name := range []string{"terraform.tfvars", "terraform.tfvars.json"} {
+		path := filepath.Join(e.dir, name)
+		if _, err := os.Stat(path); err == nil {
+			files = append(files, path)
+		}
+	}
+
+	entries, err := os.ReadDir(e.dir)
+	if err != nil {
+		return files
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && (strings.HasSuffix(name, ".auto.tfvars") || strings.HasSuffix(name, ".auto.tfvars.json")) {
+			files = append(files, filepath.Join(e.dir, name))
+		}
+	}
+
+	return files
+}
+
+func inRanges(r hcl.Range, ranges []hcl.Range) bool {
+	for _, other := range ranges {
+		if r.Filename == other.Filename && r.Start.Byte >= other.Start.Byte && r.End.Byte <= other.End.Byte {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,41 @@
+package modules
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	intSync "github.com/infracost/infracost/internal/sync"
+)
+
+func TestLoadModuleFromPathEarlyEvaluation(t *testing.T) {
+	loader := NewModuleLoader(t.TempDir(), NewSharedHCLParser(), nil, config.TerraformSourceMap{}, zerolog.Nop(), &intSync.KeyMutex{})
+
+	mod, err := loader.loadModuleFromPath("testdata/opentofu_early_eval")
+	require.NoError(t, err)
+
+	require.Contains(t, mod.ModuleCalls, "network")
+	assert.Equal(t, "./modules/network", mod.ModuleCalls["network"].Source)
+
+	require.Contains(t, mod.ModuleCalls, "vpc")
+	assert.Equal(t, "terraform-aws-modules/vpc/aws", mod.ModuleCalls["vpc"].Source)
+	assert.Equal(t, "5.1.2", mod.ModuleCalls["vpc"].Version)
+
+	// ignored.tf is replaced by ignored.tofu
+	assert.NotContains(t, mod.ModuleCalls, "ignored")
+	assert.Contains(t, mod.ModuleCalls, "tofu")
+}
+
+func TestLoadModuleFromPathEarlyEvaluationEnv(t *testing.T) {
+	t.Setenv("TF_VAR_modules_path", "./shared")
+
+	loader := NewModuleLoader(t.TempDir(), NewSharedHCLParser(), nil, config.TerraformSourceMap{}, zerolog.Nop(), &intSync.KeyMutex{})
+
+	mod, err := loader.loadModuleFromPath("testdata/opentofu_early_eval")
+	require.NoError(t, err)
+
+	assert.Equal(t, "./shared/network", mod.ModuleCalls["network"].Source)
+}
@@ -0,0 +1,92 @@
+package modules
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	tfExt       = ".tf"
+	tfJSONExt   = ".tf.json"
+	tofuExt     = ".tofu"
+	tofuJSONExt = ".tofu.json"
+)
+
+// IsConfigFile returns true if the file name is a Terraform or OpenTofu
+// configuration file, i.e. it has a .tf, .tf.json, .tofu or .tofu.json
+// extension.
+func IsConfigFile(name string) bool {
+	return strings.HasSuffix(name, tfExt) ||
+		strings.HasSuffix(name, tfJSONExt) ||
+		strings.HasSuffix(name, tofuExt) ||
+		strings.HasSuffix(name, tofuJSONExt)
+}
+
+// IsJSONConfigFile returns true if the file name is a Terraform or OpenTofu
+// configuration file that uses the JSON syntax.
+func IsJSONConfigFile(name string) bool {
+	return strings.HasSuffix(name, tfJSONExt) || strings.HasSuffix(name, tofuJSONExt)
+}
+
+// ConfigFileNames returns the names of the configuration files in the
+// directory entries. OpenTofu ignores a .tf or .tf.json file if there is a
+// .tofu or .tofu.json file with the same name, so that modules can provide
+// OpenTofu specific configuration, so these files are excluded.
+func ConfigFileNames(entries []os.DirEntry) []string {
+	files := make(map[string]struct{}, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() && IsConfigFile(e.Name()) {
+			files[e.Name()] = struct{}{}
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for _, e := range entries {
+		name := e.Name()
+		if _, ok := files[name]; !ok {
+			continue
+		}
+
+		if hasTofuAlternative(name, files) {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func hasTofuAlternative(name string, files map[string]struct{}) bool {
+	var alt string
+
+	switch {
+	case strings.HasSuffix(name, tfJSONExt):
+		alt = strings.TrimSuffix(name, tfJSONExt) + tofuJSONExt
+	case strings.HasSuffix(name, tfExt):
+		alt = strings.TrimSuffix(name, tfExt) + tofuExt
+	default:
+		return false
+	}
+
+	_, ok := files[alt]
+	return ok
+}
+
+// IsOpenTofuDir returns true if the directory contains any OpenTofu specific
+// configuration files, i.e. files with a .tofu or .tofu.json extension.
+func IsOpenTofuDir(path string) bool {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return false
+	}
+
+	for _, e := range entries {
+		name := e.Name()
+		if !e.IsDir() && (strings.HasSuffix(name, tofuExt) || strings.HasSuffix(name, tofuJSONExt)) {
+			return true
+		}
+	}
+
+	return false
+}
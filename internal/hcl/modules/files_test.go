@@ -0,0 +1,32 @@
+package modules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFileNames(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"main.tf", "main.tofu", "vars.tf.json", "vars.tofu.json", "outputs.tf", "extra.tofu", "terraform.tfvars", "README.md"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte{}, 0600))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "modules.tf"), 0700))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"main.tofu", "vars.tofu.json", "outputs.tf", "extra.tofu"}, ConfigFileNames(entries))
+	assert.True(t, IsOpenTofuDir(dir))
+	assert.False(t, IsOpenTofuDir("testdata/nested_modules"))
+}
+
+func TestIsJSONConfigFile(t *testing.T) {
+	assert.True(t, IsJSONConfigFile("main.tf.json"))
+	assert.True(t, IsJSONConfigFile("main.tofu.json"))
+	assert.False(t, IsJSONConfigFile("main.tofu"))
+	assert.False(t, IsJSONConfigFile("tfvars.json"))
+}
@@ -14,7 +14,6 @@ import (
 	"sync"
 
 	getter "github.com/hashicorp/go-getter"
-	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/terraform-config-inspect/tfconfig"
 	"github.com/rs/zerolog"
 	"golang.org/x/sync/errgroup"
@@ -56,6 +55,7 @@ type ModuleLoader struct {
 
 	packageFetcher *PackageFetcher
 	registryLoader *RegistryLoader
+	disco          *Disco
 	logger         zerolog.Logger
 }
 
@@ -77,6 +77,7 @@ func NewModuleLoader(cachePath string, hclParser *SharedHCLParser, credentialsSo
 		hclParser:      hclParser,
 		sourceMap:      sourceMap,
 		packageFetcher: fetcher,
+		disco:          d,
 		logger:         logger,
 		sync:           moduleSync,
 	}
@@ -86,6 +87,12 @@ func NewModuleLoader(cachePath string, hclParser *SharedHCLParser, credentialsSo
 	return m
 }
 
+// SetDefaultRegistryHost sets the registry used for module sources that don't
+// specify a registry hostname, e.g. OpenTofuRegistryHost for OpenTofu projects.
+func (m *ModuleLoader) SetDefaultRegistryHost(host string) {
+	m.disco.defaultHost = host
+}
+
 // downloadDir returns the path to the directory where remote modules are downloaded relative to the current working directory
 func (m *ModuleLoader) downloadDir() string {
 	return filepath.Join(m.cachePath, downloadDir)
@@ -331,26 +338,15 @@ func (m *ModuleLoader) loadModuleFromPath(fullPath string) (*tfconfig.Module, er
 		return nil, err
 	}
 
-	for _, info := range fileInfos {
-		if info.IsDir() {
-			continue
-		}
+	early := newEarlyEvaluator(fullPath, m.hclParser)
 
-		var parseFunc func(filename string) (*hcl.File, hcl.Diagnostics)
-		if strings.HasSuffix(info.Name(), ".tf") {
-			parseFunc = m.hclParser.ParseHCLFile
-		}
-
-		if strings.HasSuffix(info.Name(), ".tf.json") {
+	for _, name := range ConfigFileNames(fileInfos) {
+		parseFunc := m.hclParser.ParseHCLFile
+		if IsJSONConfigFile(name) {
 			parseFunc = m.hclParser.ParseJSONFile
 		}
 
-		// this is not a file we can parse:
-		if parseFunc == nil {
-			continue
-		}
-
-		path := filepath.Join(fullPath, info.Name())
+		path := filepath.Join(fullPath, name)
 		f, fileDiag := parseFunc(path)
 		if fileDiag != nil && fileDiag.HasErrors() {
 			return nil, fmt.Errorf("failed to parse file %s diag: %w", path, fileDiag)
@@ -360,12 +356,17 @@ func (m *ModuleLoader) loadModuleFromPath(fullPath string) (*tfconfig.Module, er
 			continue
 		}
 
-		contentDiag := tfconfig.LoadModuleFromFile(f, mod)
+		contentDiag := early.addFile(f, tfconfig.LoadModuleFromFile(f, mod))
 		if contentDiag != nil && contentDiag.HasErrors() {
 			return nil, fmt.Errorf("failed to load module from file %s diag: %w", path, contentDiag)
 		}
 	}
 
+	err = early.evaluateModuleCalls(mod)
+	if err != nil {
+		return nil, err
+	}
+
 	return mod, nil
 }
 
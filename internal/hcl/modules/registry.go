@@ -24,6 +24,10 @@ import (
 
 var defaultRegistryHost = "registry.terraform.io"
 
+// OpenTofuRegistryHost is the registry OpenTofu uses for module sources that
+// don't specify a registry hostname.
+const OpenTofuRegistryHost = "registry.opentofu.org"
+
 // validRegistryName is a regexp that matches valid registry identifier for namespaces, module names and targets
 var validRegistryName = regexp.MustCompile("^[0-9A-Za-z-_]+$")
 
@@ -55,13 +59,27 @@ type Disco struct {
 	logger     zerolog.Logger
 	httpClient *retryablehttp.Client
 
+	// defaultHost is the registry used for module sources that don't specify
+	// a registry hostname.
+	defaultHost string
+
 	locks sync.Map
 }
 
 // NewDisco returns a Disco with the provided credentialsSource initialising the underlying Terraform Disco.
 // If Credentials are nil then all registry requests will be unauthed.
 func NewDisco(credentialsSource auth.CredentialsSource, logger zerolog.Logger) *Disco {
-	return &Disco{disco: disco.NewWithCredentialsSource(credentialsSource), logger: logger, httpClient: newRetryableClient()}
+	return &Disco{disco: disco.NewWithCredentialsSource(credentialsSource), logger: logger, httpClient: newRetryableClient(), defaultHost: defaultRegistryHost}
+}
+
+// DefaultRegistryHost returns the registry used for module sources that don't
+// specify a registry hostname.
+func (d *Disco) DefaultRegistryHost() string {
+	if d.defaultHost == "" {
+		return defaultRegistryHost
+	}
+
+	return d.defaultHost
 }
 
 // ModuleLocation performs a discovery lookup for the given source and returns a RegistryURL with the real
@@ -188,7 +206,7 @@ func NewRegistryLoader(packageFetcher *PackageFetcher, disco *Disco, logger zero
 // lookupModule lookups the matching version and download URL for the module.
 // It calls the registry versions endpoint and tries to find a matching version.
 func (r *RegistryLoader) lookupModule(moduleAddr string, versionConstraints string) (*RegistryLookupResult, error) {
-	registrySource, err := normalizeRegistrySource(moduleAddr)
+	registrySource, err := normalizeRegistrySource(moduleAddr, r.disco.DefaultRegistryHost())
 	if err != nil {
 		r.logger.Debug().Err(err).Msgf("module '%s' not detected as registry module", moduleAddr)
 		return &RegistryLookupResult{
@@ -346,8 +364,9 @@ func findLatestMatchingVersion(versions []string, constraints string) (string, e
 
 // normalizeRegistrySource validates a module source address and normalizes it into the host/namespace/module/target format
 // This does not mean that the module address is a registry module, it could still be a remote module.
-// To work that out we need to try looking up the module using the `lookupModule` function
-func normalizeRegistrySource(moduleAddr string) (string, error) {
+// To work that out we need to try looking up the module using the `lookupModule` function.
+// The defaultHost is used if the module address doesn't specify the registry.
+func normalizeRegistrySource(moduleAddr string, defaultHost string) (string, error) {
 	// Modules are in the format (registry)/namspace/module/target
 	// So we expect them to only have 3 or 4 parts depending on if they explicitly specify the registry
 	parts := strings.Split(moduleAddr, "/")
@@ -367,7 +386,7 @@ func normalizeRegistrySource(moduleAddr string) (string, error) {
 
 		parts = parts[1:]
 	} else {
-		host = defaultRegistryHost
+		host = defaultHost
 	}
 
 	// GitHub and BitBucket hosts aren't supported as registries
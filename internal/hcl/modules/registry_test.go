@@ -70,3 +70,30 @@ func TestNormalizeHost(t *testing.T) {
 		assert.Equal(t, test.expected, actual)
 	}
 }
+
+func TestNormalizeRegistrySource(t *testing.T) {
+	tests := []struct {
+		source      string
+		defaultHost string
+		expected    string
+		returnsErr  bool
+	}{
+		{"terraform-aws-modules/vpc/aws", defaultRegistryHost, "registry.terraform.io/terraform-aws-modules/vpc/aws", false},
+		{"terraform-aws-modules/vpc/aws", OpenTofuRegistryHost, "registry.opentofu.org/terraform-aws-modules/vpc/aws", false},
+		{"registry.terraform.io/terraform-aws-modules/vpc/aws", OpenTofuRegistryHost, "registry.terraform.io/terraform-aws-modules/vpc/aws", false},
+		{"app.terraform.io:443/org/vpc/aws", defaultRegistryHost, "app.terraform.io/org/vpc/aws", false},
+		{"github.com/org/repo/module", OpenTofuRegistryHost, "", true},
+		{"./local", defaultRegistryHost, "", true},
+	}
+
+	for _, test := range tests {
+		actual, err := normalizeRegistrySource(test.source, test.defaultHost)
+		if test.returnsErr {
+			assert.Error(t, err, test.source)
+			continue
+		}
+
+		assert.NoError(t, err, test.source)
+		assert.Equal(t, test.expected, actual)
+	}
+}
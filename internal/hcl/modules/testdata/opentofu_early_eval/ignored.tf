@@ -0,0 +1,3 @@
+module "ignored" {
+  source = "./modules/terraform"
+}
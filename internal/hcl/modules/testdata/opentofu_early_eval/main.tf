@@ -0,0 +1,23 @@
+variable "modules_path" {
+  type    = string
+  default = "./modules"
+}
+
+variable "vpc_version" {
+  type    = string
+  default = "5.0.0"
+}
+
+locals {
+  network_path = "${local.base_path}/network"
+  base_path    = var.modules_path
+}
+
+module "network" {
+  source = local.network_path
+}
+
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = var.vpc_version
+}
@@ -657,26 +657,13 @@ func loadDirectory(hclParser *modules.SharedHCLParser, logger zerolog.Logger, fu
 
 	files := make([]file, 0)
 
-	for _, info := range fileInfos {
-		if info.IsDir() {
-			continue
-		}
-
-		var parseFunc func(filename string) (*hcl.File, hcl.Diagnostics)
-		if strings.HasSuffix(info.Name(), ".tf") {
-			parseFunc = hclParser.ParseHCLFile
-		}
-
-		if strings.HasSuffix(info.Name(), ".tf.json") {
+	for _, name := range modules.ConfigFileNames(fileInfos) {
+		parseFunc := hclParser.ParseHCLFile
+		if modules.IsJSONConfigFile(name) {
 			parseFunc = hclParser.ParseJSONFile
 		}
 
-		// this is not a file we can parse:
-		if parseFunc == nil {
-			continue
-		}
-
-		path := filepath.Join(fullPath, info.Name())
+		path := filepath.Join(fullPath, name)
 		f, diag := parseFunc(path)
 		if diag != nil && diag.HasErrors() {
 			if stopOnHCLError {
@@ -1824,6 +1824,42 @@ resource "aws_instance" "example" {
 	)
 }
 
+func Test_OpenTofuFilesAndProviderFunctions(t *testing.T) {
+	path := createTestFile("main.tofu", `
+locals {
+	arn = "arn:aws:ec2:eu-west-2:123456789012:instance/i-123"
+}
+
+resource "aws_instance" "example" {
+	instance_type = "m5.large"
+	tags = {
+		region = provider::aws::arn_parse(local.arn).region
+		zone   = provider::google::region_from_zone("europe-west1-b")
+	}
+}
+`)
+	err := os.WriteFile(filepath.Join(filepath.Dir(path), "main.tf"), []byte(`
+resource "aws_instance" "example" {
+	instance_type = "t3.micro"
+}
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	logger := newDiscardLogger()
+	parser := NewParser(RootPath{Path: filepath.Dir(path)}, CreateEnvFileMatcher([]string{}), modules.NewModuleLoader(filepath.Dir(path), modules.NewSharedHCLParser(), nil, config.TerraformSourceMap{}, logger, &sync.KeyMutex{}), logger)
+	module, err := parser.ParseDirectory()
+	require.NoError(t, err)
+
+	resource := module.Blocks.Matching(BlockMatcher{Label: "aws_instance.example"})
+	require.NotNil(t, resource)
+	assertBlockEqualsJSON(
+		t,
+		`{"instance_type":"m5.large","tags":{"region":"eu-west-2","zone":"europe-west1"}}`,
+		resource.Values(),
+		"id", "arn", "self_link", "name",
+	)
+}
+
 func BenchmarkParserEvaluate(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		b.StopTimer()
@@ -18,6 +18,7 @@ import (
 	"github.com/zclconf/go-cty/cty/gocty"
 
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl/modules"
 )
 
 var (
@@ -1221,6 +1222,11 @@ func (p *ProjectLocator) walkPaths(fullPath string, level int) {
 		return
 	}
 
+	configFiles := make(map[string]struct{})
+	for _, name := range modules.ConfigFileNames(fileInfos) {
+		configFiles[name] = struct{}{}
+	}
+
 	for _, info := range fileInfos {
 		if info.IsDir() {
 			continue
@@ -1229,12 +1235,11 @@ func (p *ProjectLocator) walkPaths(fullPath string, level int) {
 		var parseFunc func(filename string) (*hcl.File, hcl.Diagnostics)
 		name := info.Name()
 
-		if strings.HasSuffix(name, ".tf") {
+		if _, ok := configFiles[name]; ok {
 			parseFunc = hclParser.ParseHCLFile
-		}
-
-		if strings.HasSuffix(name, ".tf.json") {
-			parseFunc = hclParser.ParseJSONFile
+			if modules.IsJSONConfigFile(name) {
+				parseFunc = hclParser.ParseJSONFile
+			}
 		}
 
 		if p.isTerraformVarFile(name) {
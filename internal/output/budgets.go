@@ -0,0 +1,217 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/clierror"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// BudgetCheck holds the results of evaluating the budgets in the config file
+// against the output.
+type BudgetCheck struct {
+	Enabled  bool
+	Failures BudgetFailures
+	Passed   []string
+}
+
+// HasFailed returns if any of the budgets were exceeded.
+func (b BudgetCheck) HasFailed() bool {
+	return len(b.Failures) > 0
+}
+
+// BudgetFailures defines a list of exceeded budgets.
+type BudgetFailures []string
+
+// Error implements the Error interface returning the failures as a single message that can be used in stderr.
+func (b BudgetFailures) Error() string {
+	if len(b) == 0 {
+		return ""
+	}
+
+	out := &strings.Builder{}
+	out.WriteString("Budget check failed:\n\n")
+
+	for _, f := range b {
+		out.WriteString(" - " + f + "\n")
+	}
+
+	return out.String()
+}
+
+// ExitCode implements the clierror.ExitCoder interface so exceeded budgets
+// exit with a different code than other errors.
+func (b BudgetFailures) ExitCode() int {
+	return clierror.ExitCodeBudgetExceeded
+}
+
+// CheckBudgets evaluates the budgets against the total monthly cost and the
+// change in the total monthly cost of the matching projects. Budgets without
+// a path or project are evaluated against the overall totals.
+func CheckBudgets(out Root, budgets config.Budgets) BudgetCheck {
+	check := BudgetCheck{Enabled: len(budgets) > 0}
+
+	for _, b := range budgets {
+		if b.IsOverall() {
+			check.add(b, "", out.PastTotalMonthlyCost, out.TotalMonthlyCost, out.Currency)
+			continue
+		}
+
+		matched := false
+		for _, p := range out.Projects {
+			var path string
+			if p.Metadata != nil {
+				path = p.Metadata.Path
+			}
+
+			if !b.Matches(path, p.Name) {
+				continue
+			}
+
+			matched = true
+
+			var pastCost, cost *decimal.Decimal
+			if p.PastBreakdown != nil {
+				pastCost = p.PastBreakdown.TotalMonthlyCost
+			}
+			if p.Breakdown != nil {
+				cost = p.Breakdown.TotalMonthlyCost
+			}
+
+			check.add(b, p.LabelWithMetadata(), pastCost, cost, out.Currency)
+		}
+
+		if !matched {
+			check.Passed = append(check.Passed, fmt.Sprintf("%s: no matching projects", b))
+		}
+	}
+
+	return check
+}
+
+func (b *BudgetCheck) add(budget *config.Budget, project string, pastCost, cost *decimal.Decimal, currency string) {
+	label := budget.String()
+	if project != "" {
+		label = fmt.Sprintf("%s (project %s)", label, project)
+	}
+
+	if cost == nil {
+		cost = decimalPtr(decimal.Zero)
+	}
+
+	diff := *cost
+	if pastCost != nil {
+		diff = cost.Sub(*pastCost)
+	}
+
+	var failures []string
+
+	if budget.MaxMonthlyCost != nil {
+		limit := decimal.NewFromFloat(*budget.MaxMonthlyCost)
+		if cost.GreaterThan(limit) {
+			failures = append(failures, fmt.Sprintf("monthly cost %s is over the %s limit", formatCost(currency, cost), formatCost(currency, &limit)))
+		}
+	}
+
+	if budget.MaxMonthlyCostIncrease != nil {
+		limit := decimal.NewFromFloat(*budget.MaxMonthlyCostIncrease)
+		if diff.GreaterThan(limit) {
+			failures = append(failures, fmt.Sprintf("monthly cost increase %s is over the %s limit", formatCostChange(currency, &diff), formatCost(currency, &limit)))
+		}
+	}
+
+	if budget.MaxMonthlyCostIncreasePercent != nil && pastCost != nil && !pastCost.IsZero() {
+		limit := decimal.NewFromFloat(*budget.MaxMonthlyCostIncreasePercent)
+		percent := diff.Div(*pastCost).Mul(decimal.NewFromInt(100))
+		if percent.GreaterThan(limit) {
+			failures = append(failures, fmt.Sprintf("monthly cost increase %s is over the %s%% limit", formatPercentChange(pastCost, cost), limit.String()))
+		}
+	}
+
+	if len(failures) > 0 {
+		b.Failures = append(b.Failures, fmt.Sprintf("%s: %s", label, strings.Join(failures, ", ")))
+		return
+	}
+
+	b.Passed = append(b.Passed, fmt.Sprintf("%s: monthly cost %s is within budget", label, formatCost(currency, cost)))
+}
+
+// AddBudgetCheck adds the results of the budget check to the policy output so
+// they are shown in the policies section of the output.
+func (p *PolicyOutput) AddBudgetCheck(bc BudgetCheck) {
+	if !bc.Enabled {
+		return
+	}
+
+	if len(bc.Failures) > 0 {
+		p.HasFailures = true
+		p.Checks = append(p.Checks, PolicyCheckOutput{
+			Name:    "Budget exceeded",
+			Failure: true,
+			Details: bc.Failures,
+		})
+	}
+
+	if len(bc.Passed) > 0 {
+		p.Checks = append(p.Checks, PolicyCheckOutput{
+			Name:    "Budget passed",
+			Details: bc.Passed,
+		})
+	}
+}
+
+// policyOutputText renders the policy output for the table and diff formats.
+// The markdown and message formats render it in their own templates.
+func policyOutputText(po PolicyOutput) string {
+	if len(po.Checks) == 0 {
+		return ""
+	}
+
+	s := "\n\n──────────────────────────────────\n"
+
+	switch {
+	case po.HasFailures:
+		s += ui.BoldString("Policies failed")
+	case po.HasWarnings:
+		s += ui.BoldString("Policies warning")
+	default:
+		s += ui.BoldString("Policies passed")
+	}
+
+	for _, check := range po.Checks {
+		s += "\n\n" + check.Name
+		if check.Message != "" {
+			s += "\n" + check.Message
+		}
+
+		for _, d := range check.Details {
+			s += "\n - " + d
+		}
+
+		for _, r := range check.ResourceDetails {
+			s += "\n - " + r.Address
+			if r.Path != "" {
+				s += " at " + r.Path
+				if r.Line > 0 {
+					s += fmt.Sprintf(":%d", r.Line)
+				}
+			}
+
+			for _, v := range r.Violations {
+				for _, d := range v.Details {
+					s += "\n   - " + d
+				}
+			}
+		}
+
+		if check.TruncatedCount > 0 {
+			s += fmt.Sprintf("\n ... and %d more", check.TruncatedCount)
+		}
+	}
+
+	return s
+}
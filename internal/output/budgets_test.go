@@ -0,0 +1,131 @@
+package output
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/clierror"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func budgetTestProject(name, path string, pastCost, cost int64) Project {
+	return Project{
+		Name:          name,
+		Metadata:      &schema.ProjectMetadata{Path: path},
+		PastBreakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(pastCost))},
+		Breakdown:     &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(cost))},
+	}
+}
+
+func TestCheckBudgets(t *testing.T) {
+	out := Root{
+		Currency:             "USD",
+		PastTotalMonthlyCost: decimalPtr(decimal.NewFromInt(1100)),
+		TotalMonthlyCost:     decimalPtr(decimal.NewFromInt(1400)),
+		Projects: []Project{
+			budgetTestProject("prod", "infra/prod", 1000, 1200),
+			budgetTestProject("dev", "infra/dev", 100, 200),
+		},
+	}
+
+	tests := []struct {
+		name       string
+		budget     config.Budget
+		wantFailed []string
+		wantPassed []string
+	}{
+		{
+			name:       "max monthly cost exceeded",
+			budget:     config.Budget{Path: "infra/prod", MaxMonthlyCost: floatPtr(1000)},
+			wantFailed: []string{"Budget for path infra/prod (project prod): monthly cost $1,200 is over the $1,000 limit"},
+		},
+		{
+			name:       "max monthly cost increase exceeded",
+			budget:     config.Budget{Name: "Dev", Project: "dev", MaxMonthlyCostIncrease: floatPtr(50)},
+			wantFailed: []string{"Dev (project dev): monthly cost increase +$100 is over the $50 limit"},
+		},
+		{
+			name:       "max monthly cost increase percent exceeded",
+			budget:     config.Budget{Path: "infra/*", MaxMonthlyCostIncreasePercent: floatPtr(50)},
+			wantFailed: []string{"Budget for path infra/* (project dev): monthly cost increase +100% is over the 50% limit"},
+			wantPassed: []string{"Budget for path infra/* (project prod): monthly cost $1,200 is within budget"},
+		},
+		{
+			name:   "overall budget",
+			budget: config.Budget{MaxMonthlyCost: floatPtr(1000), MaxMonthlyCostIncrease: floatPtr(200)},
+			wantFailed: []string{
+				"Overall budget: monthly cost $1,400 is over the $1,000 limit, monthly cost increase +$300 is over the $200 limit",
+			},
+		},
+		{
+			name:       "within budget",
+			budget:     config.Budget{Path: "infra/prod", MaxMonthlyCost: floatPtr(2000), MaxMonthlyCostIncreasePercent: floatPtr(25)},
+			wantPassed: []string{"Budget for path infra/prod (project prod): monthly cost $1,200 is within budget"},
+		},
+		{
+			name:       "no matching projects",
+			budget:     config.Budget{Path: "infra/staging", MaxMonthlyCost: floatPtr(1)},
+			wantPassed: []string{"Budget for path infra/staging: no matching projects"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget := tt.budget
+			check := CheckBudgets(out, config.Budgets{&budget})
+
+			assert.True(t, check.Enabled)
+			assert.Equal(t, tt.wantFailed, []string(check.Failures))
+			assert.Equal(t, tt.wantPassed, check.Passed)
+		})
+	}
+}
+
+func TestCheckBudgetsPercentWithoutPastCost(t *testing.T) {
+	out := Root{
+		Currency: "USD",
+		Projects: []Project{budgetTestProject("new", "infra/new", 0, 500)},
+	}
+
+	check := CheckBudgets(out, config.Budgets{{Path: "infra/new", MaxMonthlyCostIncreasePercent: floatPtr(10)}})
+	assert.False(t, check.HasFailed())
+}
+
+func TestBudgetFailuresExitCode(t *testing.T) {
+	var err error = BudgetFailures{"Overall budget: monthly cost $10 is over the $5 limit"}
+
+	assert.Equal(t, clierror.ExitCodeBudgetExceeded, clierror.ExitCode(err))
+	assert.Equal(t, clierror.ExitCodeError, clierror.ExitCode(errors.New("other")))
+	assert.Equal(t, "Budget check failed:\n\n - Overall budget: monthly cost $10 is over the $5 limit\n", err.Error())
+}
+
+func TestPolicyOutputAddBudgetCheck(t *testing.T) {
+	var po PolicyOutput
+	po.AddBudgetCheck(BudgetCheck{})
+	assert.Empty(t, po.Checks)
+
+	po.AddBudgetCheck(BudgetCheck{Enabled: true, Failures: BudgetFailures{"failed"}, Passed: []string{"passed"}})
+	assert.True(t, po.HasFailures)
+	assert.Equal(t, []PolicyCheckOutput{
+		{Name: "Budget exceeded", Failure: true, Details: []string{"failed"}},
+		{Name: "Budget passed", Details: []string{"passed"}},
+	}, po.Checks)
+}
+
+func TestPolicyOutputText(t *testing.T) {
+	assert.Equal(t, "", policyOutputText(PolicyOutput{}))
+
+	var po PolicyOutput
+	po.AddBudgetCheck(BudgetCheck{Enabled: true, Failures: BudgetFailures{"Overall budget: monthly cost $10 is over the $5 limit"}})
+
+	assert.Equal(t, "\n\n──────────────────────────────────\nPolicies failed\n\nBudget exceeded\n - Overall budget: monthly cost $10 is over the $5 limit", ui.StripColor(policyOutputText(po)))
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
@@ -24,9 +24,10 @@ import (
 )
 
 var (
-	minOutputVersion     = "0.2"
-	maxOutputVersion     = "0.2"
-	GitHubMaxMessageSize = 262144 // bytes
+	minOutputVersion             = "0.2"
+	maxOutputVersion             = "0.2"
+	GitHubMaxMessageSize         = 262144 // bytes
+	GitHubCheckRunMaxSummarySize = 65535  // bytes
 )
 
 type ReportInput struct {
@@ -186,9 +187,15 @@ func CompareTo(c *config.Config, current, prior Root) (Root, error) {
 	for i := range out.Projects {
 		if v, ok := currentProjects[out.Projects[i].LabelWithMetadata()]; ok {
 			out.Projects[i].Summary = v.Summary
+			out.Projects[i].Recommendations = v.Recommendations
 			out.Projects[i].fullSummary = v.fullSummary
+			copyMonthlyCostRanges(out.Projects[i].Breakdown, v.Breakdown)
+			copyForecasts(out.Projects[i].Breakdown, v.Breakdown)
 		}
 	}
+	out.TotalMonthlySavings = projectsTotalMonthlySavings(out.Projects)
+	out.MonthlyCostRange = totalMonthlyCostRange(out.Projects)
+	out.Forecast = totalForecast(out.Projects)
 
 	out.Summary = current.Summary
 	out.FullSummary = current.FullSummary
@@ -245,6 +252,7 @@ func Combine(inputs []ReportInput) (Root, error) {
 	var diffTotalMonthlyCost *decimal.Decimal
 
 	projects := make([]Project, 0)
+	var tagPolicies []TagPolicyResult
 	summaries := make([]*Summary, 0, len(inputs))
 	currency := ""
 
@@ -259,6 +267,7 @@ func Combine(inputs []ReportInput) (Root, error) {
 		}
 
 		projects = append(projects, input.Root.Projects...)
+		tagPolicies = append(tagPolicies, input.Root.TagPolicies...)
 
 		summaries = append(summaries, input.Root.Summary)
 
@@ -327,6 +336,11 @@ func Combine(inputs []ReportInput) (Root, error) {
 	combined.PastTotalMonthlyCost = pastTotalMonthlyCost
 	combined.DiffTotalHourlyCost = diffTotalHourlyCost
 	combined.DiffTotalMonthlyCost = diffTotalMonthlyCost
+	combined.TotalListMonthlyCost = projectsTotalListMonthlyCost(projects)
+	combined.TotalMonthlySavings = projectsTotalMonthlySavings(projects)
+	combined.MonthlyCostRange = totalMonthlyCostRange(projects)
+	combined.Forecast = totalForecast(projects)
+	combined.TagPolicies = mergeTagPolicyResults(tagPolicies)
 	combined.TimeGenerated = lastestGeneratedAt
 	combined.Summary = MergeSummaries(summaries)
 	combined.Metadata = metadata
@@ -385,6 +399,8 @@ func FormatOutput(format string, r Root, opts Options) ([]byte, error) {
 		b, err = ToHTML(r, opts)
 	case "diff":
 		b, err = ToDiff(r, opts)
+		b = append(b, usageProfilesText(r)...)
+		b = append(b, policyOutputText(opts.PolicyOutput)...)
 	case "github-comment":
 		out, error := ToMarkdown(r, opts, MarkdownOptions{MaxMessageSize: GitHubMaxMessageSize})
 		b, err = out.Msg, error
@@ -399,8 +415,19 @@ func FormatOutput(format string, r Root, opts Options) ([]byte, error) {
 		b, err = out.Msg, error
 	case "slack-message":
 		b, err = ToSlackMessage(r, opts)
+	case "teams-message":
+		b, err = ToTeamsMessage(r, opts)
+	case "csv":
+		b, err = ToCSV(r, opts)
+	case "ndjson":
+		b, err = ToNDJSON(r, opts)
+	case "focus":
+		b, err = ToFOCUS(r, opts)
+	case "template":
+		b, err = ToTemplate(r, opts)
 	default:
 		b, err = ToTable(r, opts)
+		b = append(b, policyOutputText(opts.PolicyOutput)...)
 	}
 
 	if err != nil {
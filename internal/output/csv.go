@@ -0,0 +1,330 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// FlatRow is a single cost component of a resource flattened together with
+// the project and resource it belongs to. It is used by the csv and ndjson
+// output formats so costs can be loaded into spreadsheets and data warehouses.
+type FlatRow struct {
+	Project             string            `json:"project"`
+	ProjectPath         string            `json:"projectPath"`
+	ModulePath          string            `json:"modulePath,omitempty"`
+	ResourceAddress     string            `json:"resourceAddress"`
+	ResourceType        string            `json:"resourceType"`
+	SubResource         string            `json:"subResource,omitempty"`
+	Tags                map[string]string `json:"tags,omitempty"`
+	CostComponent       string            `json:"costComponent"`
+	Unit                string            `json:"unit"`
+	MonthlyQuantity     *decimal.Decimal  `json:"monthlyQuantity"`
+	Price               *decimal.Decimal  `json:"price"`
+	HourlyCost          *decimal.Decimal  `json:"hourlyCost"`
+	MonthlyCost         *decimal.Decimal  `json:"monthlyCost"`
+	PastMonthlyQuantity *decimal.Decimal  `json:"pastMonthlyQuantity"`
+	PastPrice           *decimal.Decimal  `json:"pastPrice"`
+	PastHourlyCost      *decimal.Decimal  `json:"pastHourlyCost"`
+	PastMonthlyCost     *decimal.Decimal  `json:"pastMonthlyCost"`
+	DiffHourlyCost      *decimal.Decimal  `json:"diffHourlyCost"`
+	DiffMonthlyCost     *decimal.Decimal  `json:"diffMonthlyCost"`
+	Currency            string            `json:"currency"`
+
+	listPrice       *decimal.Decimal
+	listMonthlyCost *decimal.Decimal
+	service         string
+	region          string
+}
+
+var flatRowHeaders = []string{
+	"project",
+	"projectPath",
+	"modulePath",
+	"resourceAddress",
+	"resourceType",
+	"subResource",
+	"tags",
+	"costComponent",
+	"unit",
+	"monthlyQuantity",
+	"price",
+	"hourlyCost",
+	"monthlyCost",
+	"pastMonthlyQuantity",
+	"pastPrice",
+	"pastHourlyCost",
+	"pastMonthlyCost",
+	"diffHourlyCost",
+	"diffMonthlyCost",
+	"currency",
+}
+
+func (r FlatRow) csvRecord() []string {
+	return []string{
+		r.Project,
+		r.ProjectPath,
+		r.ModulePath,
+		r.ResourceAddress,
+		r.ResourceType,
+		r.SubResource,
+		formatFlatTags(r.Tags),
+		r.CostComponent,
+		r.Unit,
+		formatFlatDecimal(r.MonthlyQuantity),
+		formatFlatDecimal(r.Price),
+		formatFlatDecimal(r.HourlyCost),
+		formatFlatDecimal(r.MonthlyCost),
+		formatFlatDecimal(r.PastMonthlyQuantity),
+		formatFlatDecimal(r.PastPrice),
+		formatFlatDecimal(r.PastHourlyCost),
+		formatFlatDecimal(r.PastMonthlyCost),
+		formatFlatDecimal(r.DiffHourlyCost),
+		formatFlatDecimal(r.DiffMonthlyCost),
+		r.Currency,
+	}
+}
+
+// ToCSV returns the cost components of all projects as CSV, with one row per
+// cost component.
+func ToCSV(out Root, opts Options) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	w := csv.NewWriter(buf)
+
+	err := w.Write(flatRowHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range FlattenRoot(out) {
+		err = w.Write(row.csvRecord())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// ToNDJSON returns the cost components of all projects as newline-delimited
+// JSON, with one object per cost component.
+func ToNDJSON(out Root, opts Options) ([]byte, error) {
+	lines := make([][]byte, 0)
+
+	for _, row := range FlattenRoot(out) {
+		b, err := json.Marshal(row)
+		if err != nil {
+			return nil, err
+		}
+
+		lines = append(lines, b)
+	}
+
+	return bytes.Join(lines, []byte("\n")), nil
+}
+
+// FlattenRoot flattens the projects, resources, sub resources and cost
+// components of the Root into one FlatRow per cost component. If a project has
+// a past breakdown the past and diff values are populated by matching cost
+// components on their resource address, sub resource and name. Cost components
+// that only exist in the past breakdown are added after the current ones.
+func FlattenRoot(out Root) []FlatRow {
+	rows := make([]FlatRow, 0)
+
+	for _, p := range out.Projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		base := FlatRow{Project: p.Name, Currency: out.Currency}
+		if p.Metadata != nil {
+			base.ProjectPath = p.Metadata.Path
+		}
+
+		current := flattenResources(base, p.Breakdown.Resources)
+		if p.PastBreakdown == nil {
+			for _, c := range current {
+				rows = append(rows, c.row)
+			}
+
+			continue
+		}
+
+		past := flattenResources(base, p.PastBreakdown.Resources)
+		pastByKey := make(map[string]FlatRow, len(past))
+		for _, c := range past {
+			pastByKey[c.key] = c.row
+		}
+
+		seen := make(map[string]bool, len(current))
+		for _, c := range current {
+			seen[c.key] = true
+
+			row := c.row
+			if pastRow, ok := pastByKey[c.key]; ok {
+				row.PastMonthlyQuantity = pastRow.MonthlyQuantity
+				row.PastPrice = pastRow.Price
+				row.PastHourlyCost = pastRow.HourlyCost
+				row.PastMonthlyCost = pastRow.MonthlyCost
+			}
+
+			rows = append(rows, withFlatDiff(row))
+		}
+
+		for _, c := range past {
+			if seen[c.key] {
+				continue
+			}
+
+			row := c.row
+			row.PastMonthlyQuantity, row.MonthlyQuantity = row.MonthlyQuantity, nil
+			row.PastPrice, row.Price = row.Price, nil
+			row.PastHourlyCost, row.HourlyCost = row.HourlyCost, nil
+			row.PastMonthlyCost, row.MonthlyCost = row.MonthlyCost, nil
+
+			rows = append(rows, withFlatDiff(row))
+		}
+	}
+
+	return rows
+}
+
+type keyedFlatRow struct {
+	key string
+	row FlatRow
+}
+
+func flattenResources(base FlatRow, resources []Resource) []keyedFlatRow {
+	rows := make([]keyedFlatRow, 0)
+
+	var add func(r Resource, resourceType string, address string, tags *map[string]string, subResource []string)
+	add = func(r Resource, resourceType string, address string, tags *map[string]string, subResource []string) {
+		// Use a counter so cost components with the same name can still be
+		// matched against their past values.
+		counts := map[string]int{}
+
+		for _, c := range r.CostComponents {
+			price := c.Price
+
+			row := base
+			row.ModulePath = flatModulePath(address)
+			row.ResourceAddress = address
+			row.ResourceType = resourceType
+			row.SubResource = strings.Join(subResource, ".")
+			row.CostComponent = c.Name
+			row.Unit = c.Unit
+			row.MonthlyQuantity = c.MonthlyQuantity
+			row.Price = &price
+			row.HourlyCost = c.HourlyCost
+			row.MonthlyCost = c.MonthlyCost
+			row.listPrice = c.ListPrice
+			row.listMonthlyCost = c.ListMonthlyCost
+			row.service = c.service
+			row.region = c.region
+			if tags != nil {
+				row.Tags = *tags
+			}
+
+			key := fmt.Sprintf("%s\x00%s\x00%s\x00%d", address, row.SubResource, c.Name, counts[c.Name])
+			counts[c.Name]++
+
+			rows = append(rows, keyedFlatRow{key: key, row: row})
+		}
+
+		for _, s := range r.SubResources {
+			add(s, resourceType, address, tags, append(subResource[:len(subResource):len(subResource)], s.Name))
+		}
+	}
+
+	for _, r := range resources {
+		// Sub resources don't have their own tags so use the tags of the
+		// top-level resource.
+		add(r, r.ResourceType, r.Name, r.Tags, nil)
+	}
+
+	return rows
+}
+
+// flatModulePath returns the address of the module that the resource address
+// is in, e.g. module.vpc.module.subnets for
+// module.vpc.module.subnets.aws_subnet.private[0]. It is empty for resources in
+// the root module.
+func flatModulePath(address string) string {
+	// Split on the dots that aren't quoted in the index keys of the address.
+	quoted := false
+	parts := strings.FieldsFunc(address, func(r rune) bool {
+		if r == '"' {
+			quoted = !quoted
+		}
+		return !quoted && r == '.'
+	})
+
+	var modulePath []string
+	for i := 0; i+1 < len(parts) && parts[i] == "module"; i += 2 {
+		modulePath = append(modulePath, parts[i], parts[i+1])
+	}
+
+	return strings.Join(modulePath, ".")
+}
+
+func withFlatDiff(row FlatRow) FlatRow {
+	row.DiffHourlyCost = diffDecimalPtr(row.PastHourlyCost, row.HourlyCost)
+	row.DiffMonthlyCost = diffDecimalPtr(row.PastMonthlyCost, row.MonthlyCost)
+
+	return row
+}
+
+func diffDecimalPtr(past, current *decimal.Decimal) *decimal.Decimal {
+	if past == nil && current == nil {
+		return nil
+	}
+
+	p := decimal.Zero
+	if past != nil {
+		p = *past
+	}
+
+	c := decimal.Zero
+	if current != nil {
+		c = *current
+	}
+
+	return decimalPtr(c.Sub(p))
+}
+
+func formatFlatDecimal(d *decimal.Decimal) string {
+	if d == nil {
+		return ""
+	}
+
+	return d.String()
+}
+
+func formatFlatTags(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = fmt.Sprintf("%s=%s", k, tags[k])
+	}
+
+	return strings.Join(pairs, ";")
+}
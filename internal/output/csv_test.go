@@ -0,0 +1,89 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestFlattenRoot(t *testing.T) {
+	component := func(name string, cost int64) CostComponent {
+		return CostComponent{
+			Name:        name,
+			Unit:        "hours",
+			Price:       decimal.NewFromInt(1),
+			MonthlyCost: decimalPtr(decimal.NewFromInt(cost)),
+		}
+	}
+
+	out := Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				Name:     "proj",
+				Metadata: &schema.ProjectMetadata{Path: "infra"},
+				PastBreakdown: &Breakdown{Resources: []Resource{
+					{
+						Name:           "aws_instance.web",
+						ResourceType:   "aws_instance",
+						CostComponents: []CostComponent{component("Instance usage", 10), component("Removed", 3)},
+					},
+				}},
+				Breakdown: &Breakdown{Resources: []Resource{
+					{
+						Name:           "aws_instance.web",
+						ResourceType:   "aws_instance",
+						Tags:           &map[string]string{"team": "a", "env": "prod"},
+						CostComponents: []CostComponent{component("Instance usage", 15)},
+						SubResources: []Resource{
+							{Name: "root_block_device", CostComponents: []CostComponent{component("Storage", 5)}},
+						},
+					},
+				}},
+			},
+		},
+	}
+
+	rows := FlattenRoot(out)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, "Instance usage", rows[0].CostComponent)
+	assert.Equal(t, "10", rows[0].PastMonthlyCost.String())
+	assert.Equal(t, "5", rows[0].DiffMonthlyCost.String())
+
+	assert.Equal(t, "root_block_device", rows[1].SubResource)
+	assert.Equal(t, "aws_instance", rows[1].ResourceType)
+	assert.Nil(t, rows[1].PastMonthlyCost)
+	assert.Equal(t, "5", rows[1].DiffMonthlyCost.String())
+
+	assert.Equal(t, "Removed", rows[2].CostComponent)
+	assert.Nil(t, rows[2].MonthlyCost)
+	assert.Equal(t, "-3", rows[2].DiffMonthlyCost.String())
+
+	b, err := ToCSV(out, Options{})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "proj,infra,,aws_instance.web,aws_instance,,env=prod;team=a,Instance usage,hours,,1,,15,,1,,10,,5,USD")
+}
+
+func TestFlatModulePath(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{address: "aws_instance.web", want: ""},
+		{address: "data.aws_ami.ubuntu", want: ""},
+		{address: "module.vpc.aws_subnet.private[0]", want: "module.vpc"},
+		{address: "module.vpc.module.subnets.aws_subnet.private[0]", want: "module.vpc.module.subnets"},
+		{address: `module.app["eu.west"].data.aws_ami.ubuntu`, want: `module.app["eu.west"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			assert.Equal(t, tt.want, flatModulePath(tt.address))
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// focusHeaders are the columns of the FOCUS (FinOps Open Cost and Usage
+// Specification) export. Columns that are specific to Infracost use the x_
+// prefix that FOCUS reserves for custom columns.
+var focusHeaders = []string{
+	"BillingCurrency",
+	"ChargePeriodStart",
+	"ChargePeriodEnd",
+	"ChargeCategory",
+	"ChargeDescription",
+	"ProviderName",
+	"ServiceName",
+	"ResourceId",
+	"ResourceName",
+	"ResourceType",
+	"Region",
+	"PricingUnit",
+	"PricingQuantity",
+	"ListUnitPrice",
+	"ListCost",
+	"EffectiveCost",
+	"Tags",
+	"x_ProjectName",
+}
+
+var focusProviderNames = map[string]string{
+	"aws":     "AWS",
+	"azurerm": "Microsoft",
+	"google":  "Google Cloud",
+}
+
+// ToFOCUS returns the estimated monthly costs as a CSV that follows the FOCUS
+// column names, with one row per cost component. This allows the estimates to
+// be compared with actual billing exports in the same tool. The charge period
+// is the calendar month the output was generated in.
+//
+// ServiceName and Region are taken from the prices of the cost components, so
+// they are only available when the output is generated directly from a run.
+// Otherwise ServiceName falls back to the resource type and Region is empty.
+func ToFOCUS(out Root, opts Options) ([]byte, error) {
+	generated := out.TimeGenerated
+	if generated.IsZero() {
+		generated = time.Now().UTC()
+	}
+	periodStart := time.Date(generated.Year(), generated.Month(), 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 1, 0)
+
+	buf := bytes.NewBuffer(nil)
+	w := csv.NewWriter(buf)
+
+	err := w.Write(focusHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range FlattenRoot(out) {
+		// Rows that only exist in the past breakdown have been removed so
+		// they aren't part of the estimate.
+		if row.Price == nil {
+			continue
+		}
+
+		record, err := focusRecord(row, periodStart, periodEnd)
+		if err != nil {
+			return nil, err
+		}
+
+		err = w.Write(record)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func focusRecord(row FlatRow, periodStart, periodEnd time.Time) ([]string, error) {
+	tags := "{}"
+	if len(row.Tags) > 0 {
+		b, err := json.Marshal(row.Tags)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = string(b)
+	}
+
+	serviceName := row.service
+	if serviceName == "" {
+		serviceName = row.ResourceType
+	}
+
+	resourceName := row.ResourceAddress
+	if row.SubResource != "" {
+		resourceName += "." + row.SubResource
+	}
+
+	listUnitPrice := row.Price
+	if row.listPrice != nil {
+		listUnitPrice = row.listPrice
+	}
+
+	effectiveCost := row.MonthlyCost
+	if effectiveCost == nil {
+		effectiveCost = decimalPtr(decimal.Zero)
+	}
+
+	listCost := effectiveCost
+	if row.listMonthlyCost != nil {
+		listCost = row.listMonthlyCost
+	}
+
+	return []string{
+		row.Currency,
+		periodStart.Format(time.RFC3339),
+		periodEnd.Format(time.RFC3339),
+		"Usage",
+		row.CostComponent,
+		focusProviderName(row.ResourceType),
+		serviceName,
+		row.ResourceAddress,
+		resourceName,
+		row.ResourceType,
+		row.region,
+		row.Unit,
+		formatFlatDecimal(row.MonthlyQuantity),
+		formatFlatDecimal(listUnitPrice),
+		listCost.String(),
+		effectiveCost.String(),
+		tags,
+		row.Project,
+	}, nil
+}
+
+func focusProviderName(resourceType string) string {
+	prefix, _, _ := strings.Cut(resourceType, "_")
+	if name, ok := focusProviderNames[prefix]; ok {
+		return name
+	}
+
+	return prefix
+}
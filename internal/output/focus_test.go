@@ -0,0 +1,68 @@
+package output
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToFOCUS(t *testing.T) {
+	out := Root{
+		Currency:      "USD",
+		TimeGenerated: time.Date(2024, 2, 14, 10, 0, 0, 0, time.UTC),
+		Projects: []Project{
+			{
+				Name: "proj",
+				Breakdown: &Breakdown{Resources: []Resource{
+					{
+						Name:         "aws_instance.web",
+						ResourceType: "aws_instance",
+						Tags:         &map[string]string{"env": "prod"},
+						CostComponents: []CostComponent{
+							{
+								Name:            "Instance usage",
+								Unit:            "hours",
+								MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+								Price:           decimal.RequireFromString("0.07"),
+								MonthlyCost:     decimalPtr(decimal.RequireFromString("51.1")),
+								ListPrice:       decimalPtr(decimal.RequireFromString("0.1")),
+								ListMonthlyCost: decimalPtr(decimal.NewFromInt(73)),
+								service:         "AmazonEC2",
+								region:          "eu-west-1",
+							},
+						},
+					},
+				}},
+			},
+		},
+	}
+
+	b, err := ToFOCUS(out, Options{})
+	require.NoError(t, err)
+
+	lines := strings.Split(string(b), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, strings.Join(focusHeaders, ","), lines[0])
+	assert.Equal(t, `USD,2024-02-01T00:00:00Z,2024-03-01T00:00:00Z,Usage,Instance usage,AWS,AmazonEC2,aws_instance.web,aws_instance.web,aws_instance,eu-west-1,hours,730,0.1,73,51.1,"{""env"":""prod""}",proj`, lines[1])
+
+	// The service and region aren't part of Infracost JSON, so the resource
+	// type is used as the service for outputs loaded from it.
+	j, err := json.Marshal(out)
+	require.NoError(t, err)
+	assert.NotContains(t, string(j), "AmazonEC2")
+
+	var loaded Root
+	require.NoError(t, json.Unmarshal(j, &loaded))
+
+	b, err = ToFOCUS(loaded, Options{})
+	require.NoError(t, err)
+
+	lines = strings.Split(string(b), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, `USD,2024-02-01T00:00:00Z,2024-03-01T00:00:00Z,Usage,Instance usage,AWS,aws_instance,aws_instance.web,aws_instance.web,aws_instance,,hours,730,0.1,73,51.1,"{""env"":""prod""}",proj`, lines[1])
+}
@@ -0,0 +1,277 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// sparklineLevels are the characters used to draw the trend of a forecast,
+// from the lowest to the highest monthly cost.
+var sparklineLevels = []rune("▁▂▃▄▅▆▇█")
+
+// maxSparklineWidth is the maximum number of characters of the trend of a
+// forecast. Longer forecasts are drawn with the average of groups of months.
+const maxSparklineWidth = 12
+
+// Forecast is the monthly costs of a resource or project for each month of a
+// cost forecast, with the usage growing by the growth rates in the usage file.
+type Forecast struct {
+	// MonthlyCosts are the costs of each month of the forecast, starting with
+	// the current month.
+	MonthlyCosts []*decimal.Decimal `json:"monthlyCosts"`
+	// TotalCostOfOwnership is the total cost over all the months of the
+	// forecast.
+	TotalCostOfOwnership *decimal.Decimal `json:"totalCostOfOwnership"`
+}
+
+func newForecast(costs []*decimal.Decimal) *Forecast {
+	total := decimal.Zero
+	for _, c := range costs {
+		if c != nil {
+			total = total.Add(*c)
+		}
+	}
+
+	return &Forecast{MonthlyCosts: costs, TotalCostOfOwnership: decimalPtr(total)}
+}
+
+// hasGrowth returns true if the monthly cost changes during the forecast.
+func (f *Forecast) hasGrowth() bool {
+	for _, c := range f.MonthlyCosts {
+		if !decimalPtrEqual(c, f.MonthlyCosts[0]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func decimalPtrEqual(a, b *decimal.Decimal) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+// addForecasts sets the forecasts of the breakdown and its resources from the
+// resources built with the usage of each month of the forecast.
+func addForecasts(breakdown *Breakdown, months []*schema.ForecastMonth) {
+	if breakdown == nil || len(months) == 0 {
+		return
+	}
+
+	monthResources := make([]map[string]*schema.Resource, len(months))
+	totals := make([]*decimal.Decimal, len(months))
+
+	for i, m := range months {
+		monthResources[i] = make(map[string]*schema.Resource, len(m.Resources))
+		total := decimal.Zero
+
+		for _, r := range m.Resources {
+			monthResources[i][r.Name] = r
+
+			if !r.IsSkipped && r.MonthlyCost != nil {
+				total = total.Add(*r.MonthlyCost)
+			}
+		}
+
+		totals[i] = decimalPtr(total)
+	}
+
+	for i := range breakdown.Resources {
+		costs := make([]*decimal.Decimal, len(months))
+		for j := range months {
+			if r, ok := monthResources[j][breakdown.Resources[i].Name]; ok && !r.IsSkipped {
+				costs[j] = r.MonthlyCost
+			}
+		}
+
+		breakdown.Resources[i].Forecast = newForecast(costs)
+	}
+
+	breakdown.Forecast = newForecast(totals)
+}
+
+// totalForecast returns the forecast of the total monthly cost of the
+// projects, or nil if none of the projects have a forecast. Projects without
+// a forecast contribute their total monthly cost to each month.
+func totalForecast(projects []Project) *Forecast {
+	months := 0
+	for _, p := range projects {
+		if p.Breakdown != nil && p.Breakdown.Forecast != nil && len(p.Breakdown.Forecast.MonthlyCosts) > months {
+			months = len(p.Breakdown.Forecast.MonthlyCosts)
+		}
+	}
+
+	if months == 0 {
+		return nil
+	}
+
+	totals := make([]*decimal.Decimal, months)
+	for i := range totals {
+		total := decimal.Zero
+
+		for _, p := range projects {
+			if p.Breakdown == nil {
+				continue
+			}
+
+			cost := p.Breakdown.TotalMonthlyCost
+			if p.Breakdown.Forecast != nil {
+				cost = nil
+				if i < len(p.Breakdown.Forecast.MonthlyCosts) {
+					cost = p.Breakdown.Forecast.MonthlyCosts[i]
+				}
+			}
+
+			if cost != nil {
+				total = total.Add(*cost)
+			}
+		}
+
+		totals[i] = decimalPtr(total)
+	}
+
+	return newForecast(totals)
+}
+
+// copyForecasts copies the forecasts of the breakdown and its resources from
+// another breakdown of the same project.
+func copyForecasts(dst *Breakdown, src *Breakdown) {
+	if dst == nil || src == nil || src.Forecast == nil {
+		return
+	}
+
+	dst.Forecast = src.Forecast
+
+	forecasts := make(map[string]*Forecast, len(src.Resources))
+	for _, r := range src.Resources {
+		forecasts[r.Name] = r.Forecast
+	}
+
+	for i := range dst.Resources {
+		dst.Resources[i].Forecast = forecasts[dst.Resources[i].Name]
+	}
+}
+
+// sparkline draws the trend of the monthly costs. Forecasts that are longer
+// than maxSparklineWidth months are drawn with the average cost of each group
+// of months.
+func sparkline(costs []*decimal.Decimal) string {
+	if len(costs) == 0 {
+		return ""
+	}
+
+	groupSize := (len(costs) + maxSparklineWidth - 1) / maxSparklineWidth
+
+	var points []decimal.Decimal
+	for i := 0; i < len(costs); i += groupSize {
+		end := i + groupSize
+		if end > len(costs) {
+			end = len(costs)
+		}
+
+		sum := decimal.Zero
+		for _, c := range costs[i:end] {
+			if c != nil {
+				sum = sum.Add(*c)
+			}
+		}
+
+		points = append(points, sum.Div(decimal.NewFromInt(int64(end-i))))
+	}
+
+	minPoint, maxPoint := points[0], points[0]
+	for _, p := range points {
+		minPoint = decimal.Min(minPoint, p)
+		maxPoint = decimal.Max(maxPoint, p)
+	}
+
+	var b strings.Builder
+	spread := maxPoint.Sub(minPoint)
+	for _, p := range points {
+		level := 0
+		if spread.IsPositive() {
+			level = int(p.Sub(minPoint).Div(spread).Mul(decimal.NewFromInt(int64(len(sparklineLevels) - 1))).Round(0).IntPart())
+		}
+
+		b.WriteRune(sparklineLevels[level])
+	}
+
+	return b.String()
+}
+
+// forecastText renders the forecasts of the projects and of the resources
+// whose monthly cost changes during the forecast for the table format.
+func forecastText(out Root) string {
+	if out.Forecast == nil || len(out.Forecast.MonthlyCosts) == 0 {
+		return ""
+	}
+
+	months := len(out.Forecast.MonthlyCosts)
+
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString("Name"),
+		ui.UnderlineString("Month 1"),
+		ui.UnderlineString(fmt.Sprintf("Month %d", months)),
+		ui.UnderlineString("Trend"),
+		ui.UnderlineString("Total"),
+	})
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 2, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 3, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 4, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 5, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	appendRow := func(name string, f *Forecast) {
+		t.AppendRow(table.Row{
+			name,
+			FormatCost2DP(out.Currency, f.MonthlyCosts[0]),
+			FormatCost2DP(out.Currency, f.MonthlyCosts[len(f.MonthlyCosts)-1]),
+			sparkline(f.MonthlyCosts),
+			FormatCost2DP(out.Currency, f.TotalCostOfOwnership),
+		})
+	}
+
+	for _, p := range out.Projects {
+		if p.Breakdown == nil || p.Breakdown.Forecast == nil || len(p.Breakdown.Forecast.MonthlyCosts) == 0 {
+			continue
+		}
+
+		appendRow(ui.BoldString(p.Label()), p.Breakdown.Forecast)
+
+		for _, res := range p.Breakdown.Resources {
+			if res.Forecast == nil || !res.Forecast.hasGrowth() {
+				continue
+			}
+
+			appendRow("  "+res.Name, res.Forecast)
+		}
+	}
+
+	s := "\n──────────────────────────────────\n"
+	s += ui.BoldString(fmt.Sprintf("%d-month forecast, total cost of ownership %s",
+		months,
+		FormatCost2DP(out.Currency, out.Forecast.TotalCostOfOwnership),
+	))
+	s += "\n\n" + t.Render()
+
+	return s
+}
@@ -0,0 +1,98 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func forecastTestMonths(lambdaCosts ...int64) []*schema.ForecastMonth {
+	months := make([]*schema.ForecastMonth, len(lambdaCosts))
+	for i, c := range lambdaCosts {
+		months[i] = &schema.ForecastMonth{Month: i, Resources: usageProfileTestResources(c, 5)}
+	}
+
+	return months
+}
+
+func decimalPtrs(values ...int64) []*decimal.Decimal {
+	l := make([]*decimal.Decimal, len(values))
+	for i, v := range values {
+		l[i] = decimalPtr(decimal.NewFromInt(v))
+	}
+
+	return l
+}
+
+func TestAddForecasts(t *testing.T) {
+	breakdown := &Breakdown{Resources: []Resource{
+		{Name: "aws_lambda_function.api", MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+		{Name: "aws_s3_bucket.logs", MonthlyCost: decimalPtr(decimal.NewFromInt(5))},
+	}}
+
+	addForecasts(breakdown, nil)
+	assert.Nil(t, breakdown.Forecast)
+
+	addForecasts(breakdown, forecastTestMonths(10, 20, 40))
+
+	require.NotNil(t, breakdown.Forecast)
+	assert.Equal(t, decimalPtrs(15, 25, 45), breakdown.Forecast.MonthlyCosts)
+	assert.Equal(t, "85", breakdown.Forecast.TotalCostOfOwnership.String())
+
+	lambda := breakdown.Resources[0].Forecast
+	assert.Equal(t, "70", lambda.TotalCostOfOwnership.String())
+	assert.True(t, lambda.hasGrowth())
+	assert.False(t, breakdown.Resources[1].Forecast.hasGrowth())
+}
+
+func TestTotalForecast(t *testing.T) {
+	assert.Nil(t, totalForecast([]Project{{Name: "a", Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(1))}}}))
+
+	total := totalForecast([]Project{
+		{Name: "a", Breakdown: &Breakdown{
+			TotalMonthlyCost: decimalPtr(decimal.NewFromInt(10)),
+			Forecast:         newForecast(decimalPtrs(10, 20, 30)),
+		}},
+		{Name: "b", Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(100))}},
+	})
+
+	assert.Equal(t, decimalPtrs(110, 120, 130), total.MonthlyCosts)
+	assert.Equal(t, "360", total.TotalCostOfOwnership.String())
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "", sparkline(nil))
+	assert.Equal(t, "▁▁▁", sparkline(decimalPtrs(5, 5, 5)))
+	assert.Equal(t, "▁▅█", sparkline(decimalPtrs(0, 50, 100)))
+
+	// Long forecasts are drawn with the average of each group of months.
+	costs := make([]*decimal.Decimal, 36)
+	for i := range costs {
+		costs[i] = decimalPtr(decimal.NewFromInt(int64(i / 3)))
+	}
+	assert.Equal(t, "▁▂▂▃▄▄▅▅▆▇▇█", sparkline(costs))
+}
+
+func TestForecastText(t *testing.T) {
+	assert.Equal(t, "", forecastText(Root{Projects: []Project{{Name: "a"}}}))
+
+	breakdown := &Breakdown{Resources: []Resource{
+		{Name: "aws_lambda_function.api", MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+		{Name: "aws_s3_bucket.logs", MonthlyCost: decimalPtr(decimal.NewFromInt(5))},
+	}}
+	addForecasts(breakdown, forecastTestMonths(10, 20, 40))
+
+	out := Root{Currency: "USD", Projects: []Project{{Name: "a", Breakdown: breakdown}}}
+	out.Forecast = totalForecast(out.Projects)
+
+	assert.Equal(t, "\n──────────────────────────────────\n3-month forecast, total cost of ownership $85.00\n\n"+
+		" Name                       Month 1  Month 3  Trend   Total \n"+
+		" a                           $15.00   $45.00  ▁▃█    $85.00 \n"+
+		"   aws_lambda_function.api   $10.00   $40.00  ▁▃█    $70.00 ",
+		ui.StripColor(forecastText(out)))
+}
@@ -0,0 +1,193 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+)
+
+// ResourceAnnotation describes the cost change of a resource at the location
+// it is defined in the source code. It is used to annotate files in platforms
+// that support it, e.g. GitHub check runs.
+type ResourceAnnotation struct {
+	Path      string
+	StartLine int
+	EndLine   int
+	// Block is the name of the block at the location, i.e. the resource block
+	// or the module call in the root module.
+	Block             string
+	Address           string
+	Title             string
+	Message           string
+	PastMonthlyCost   *decimal.Decimal
+	MonthlyCost       *decimal.Decimal
+	MonthlyCostChange decimal.Decimal
+}
+
+// CostChangeTitle returns a short title summarizing the change in the total
+// monthly cost, e.g. "+$240/mo (+12%)".
+func CostChangeTitle(out Root) string {
+	cost := out.TotalMonthlyCost
+	if cost == nil {
+		cost = decimalPtr(decimal.Zero)
+	}
+
+	if out.PastTotalMonthlyCost != nil && out.PastTotalMonthlyCost.Equals(*cost) {
+		return fmt.Sprintf("%s/mo (no change)", formatWholeDecimalCurrency(out.Currency, decimal.Zero))
+	}
+
+	diff := *cost
+	if out.PastTotalMonthlyCost != nil {
+		diff = cost.Sub(*out.PastTotalMonthlyCost)
+	}
+
+	title := formatCostChange(out.Currency, &diff) + "/mo"
+
+	if percent := formatPercentChange(out.PastTotalMonthlyCost, cost); percent != "" {
+		title += fmt.Sprintf(" (%s)", percent)
+	}
+
+	return title
+}
+
+// ResourceAnnotations returns an annotation for each resource with a cost
+// change that has a known source location. Resources defined in a module are
+// annotated at the module call in the root module, since the module source
+// might not be part of the repository.
+func ResourceAnnotations(out Root) []ResourceAnnotation {
+	var annotations []ResourceAnnotation
+
+	for _, project := range out.Projects {
+		if project.Diff == nil {
+			continue
+		}
+
+		current := resourcesByName(project.Breakdown)
+		past := resourcesByName(project.PastBreakdown)
+
+		for _, diff := range project.Diff.Resources {
+			if diff.MonthlyCost == nil || diff.MonthlyCost.IsZero() {
+				continue
+			}
+
+			var pastCost, cost *decimal.Decimal
+
+			r, ok := current[diff.Name]
+			if ok {
+				cost = r.MonthlyCost
+			}
+
+			if p, pastOk := past[diff.Name]; pastOk {
+				pastCost = p.MonthlyCost
+				if !ok {
+					r = p
+				}
+			}
+
+			path, startLine, endLine, block := resourceLocation(r)
+			if path == "" {
+				continue
+			}
+
+			if block == "" {
+				block = diff.Name
+			}
+
+			annotations = append(annotations, ResourceAnnotation{
+				Path:              path,
+				StartLine:         startLine,
+				EndLine:           endLine,
+				Block:             block,
+				Address:           diff.Name,
+				Title:             fmt.Sprintf("%s %s/mo", diff.Name, formatCostChange(out.Currency, diff.MonthlyCost)),
+				Message:           resourceAnnotationMessage(out.Currency, pastCost, cost, diff.MonthlyCost),
+				PastMonthlyCost:   pastCost,
+				MonthlyCost:       cost,
+				MonthlyCostChange: *diff.MonthlyCost,
+			})
+		}
+	}
+
+	sort.SliceStable(annotations, func(i, j int) bool {
+		if annotations[i].Path != annotations[j].Path {
+			return annotations[i].Path < annotations[j].Path
+		}
+
+		return annotations[i].StartLine < annotations[j].StartLine
+	})
+
+	return annotations
+}
+
+func resourcesByName(breakdown *Breakdown) map[string]Resource {
+	m := make(map[string]Resource)
+	if breakdown == nil {
+		return m
+	}
+
+	for _, r := range breakdown.Resources {
+		m[r.Name] = r
+	}
+
+	return m
+}
+
+// resourceLocation returns the file, lines and block name of the resource
+// from the metadata added by the HCL parser.
+func resourceLocation(r Resource) (string, int, int, string) {
+	if len(r.Metadata) == 0 {
+		return "", 0, 0, ""
+	}
+
+	b, err := json.Marshal(r.Metadata)
+	if err != nil {
+		return "", 0, 0, ""
+	}
+
+	md := gjson.ParseBytes(b)
+
+	location := md
+	var block string
+	if calls := md.Get("calls").Array(); len(calls) > 0 {
+		block = calls[0].Get("blockName").String()
+		if len(calls) > 1 {
+			location = calls[0]
+		}
+	}
+
+	path := strings.TrimPrefix(location.Get("filename").String(), "./")
+	startLine := int(location.Get("startLine").Int())
+	endLine := int(location.Get("endLine").Int())
+
+	if startLine <= 0 {
+		startLine = 1
+	}
+
+	if endLine < startLine {
+		endLine = startLine
+	}
+
+	return path, startLine, endLine, block
+}
+
+func resourceAnnotationMessage(currency string, pastCost, cost, diff *decimal.Decimal) string {
+	if pastCost == nil {
+		return fmt.Sprintf("Monthly cost will increase by %s", formatCost(currency, cost))
+	}
+
+	if cost == nil {
+		return fmt.Sprintf("Monthly cost will decrease by %s", formatCost(currency, pastCost))
+	}
+
+	abs := diff.Abs()
+	direction := "increase"
+	if diff.IsNegative() {
+		direction = "decrease"
+	}
+
+	return fmt.Sprintf("Monthly cost will %s by %s%s", direction, formatCost(currency, &abs), formatCostChangeDetails(currency, pastCost, cost))
+}
@@ -0,0 +1,110 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCostChangeTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		pastCost *decimal.Decimal
+		cost     *decimal.Decimal
+		want     string
+	}{
+		{name: "increase", pastCost: decimalPtr(decimal.NewFromInt(2000)), cost: decimalPtr(decimal.NewFromInt(2240)), want: "+$240/mo (+12%)"},
+		{name: "decrease", pastCost: decimalPtr(decimal.NewFromInt(200)), cost: decimalPtr(decimal.NewFromInt(150)), want: "-$50/mo (-25%)"},
+		{name: "no past cost", cost: decimalPtr(decimal.NewFromInt(100)), want: "+$100/mo"},
+		{name: "no change", pastCost: decimalPtr(decimal.NewFromInt(100)), cost: decimalPtr(decimal.NewFromInt(100)), want: "$0/mo (no change)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CostChangeTitle(Root{Currency: "USD", PastTotalMonthlyCost: tt.pastCost, TotalMonthlyCost: tt.cost})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResourceAnnotations(t *testing.T) {
+	out := Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				PastBreakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.changed", MonthlyCost: decimalPtr(decimal.NewFromInt(10)), Metadata: map[string]interface{}{"filename": "main.tf", "startLine": 1, "endLine": 5}},
+						{Name: "aws_instance.removed", MonthlyCost: decimalPtr(decimal.NewFromInt(20)), Metadata: map[string]interface{}{"filename": "old.tf", "startLine": 3}},
+					},
+				},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.changed", MonthlyCost: decimalPtr(decimal.NewFromInt(15)), Metadata: map[string]interface{}{"filename": "main.tf", "startLine": 1, "endLine": 5}},
+						{
+							Name:        "module.app.aws_instance.added",
+							MonthlyCost: decimalPtr(decimal.NewFromInt(30)),
+							Metadata: map[string]interface{}{
+								"filename":  "modules/app/main.tf",
+								"startLine": 2,
+								"endLine":   4,
+								"calls": []interface{}{
+									map[string]interface{}{"filename": "./main.tf", "blockName": "module.app", "startLine": 10, "endLine": 12},
+									map[string]interface{}{"filename": "modules/app/main.tf", "blockName": "aws_instance.added", "startLine": 2, "endLine": 4},
+								},
+							},
+						},
+						{Name: "aws_instance.no_location", MonthlyCost: decimalPtr(decimal.NewFromInt(5))},
+					},
+				},
+				Diff: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.changed", MonthlyCost: decimalPtr(decimal.NewFromInt(5))},
+						{Name: "aws_instance.removed", MonthlyCost: decimalPtr(decimal.NewFromInt(-20))},
+						{Name: "module.app.aws_instance.added", MonthlyCost: decimalPtr(decimal.NewFromInt(30))},
+						{Name: "aws_instance.no_location", MonthlyCost: decimalPtr(decimal.NewFromInt(5))},
+						{Name: "aws_instance.no_cost_change", MonthlyCost: decimalPtr(decimal.Zero)},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []ResourceAnnotation{
+		{
+			Path:              "main.tf",
+			StartLine:         1,
+			EndLine:           5,
+			Block:             "aws_instance.changed",
+			Address:           "aws_instance.changed",
+			Title:             "aws_instance.changed +$5/mo",
+			Message:           "Monthly cost will increase by $5 ($10 → $15)",
+			PastMonthlyCost:   decimalPtr(decimal.NewFromInt(10)),
+			MonthlyCost:       decimalPtr(decimal.NewFromInt(15)),
+			MonthlyCostChange: decimal.NewFromInt(5),
+		},
+		{
+			Path:              "main.tf",
+			StartLine:         10,
+			EndLine:           12,
+			Block:             "module.app",
+			Address:           "module.app.aws_instance.added",
+			Title:             "module.app.aws_instance.added +$30/mo",
+			Message:           "Monthly cost will increase by $30",
+			MonthlyCost:       decimalPtr(decimal.NewFromInt(30)),
+			MonthlyCostChange: decimal.NewFromInt(30),
+		},
+		{
+			Path:              "old.tf",
+			StartLine:         3,
+			EndLine:           3,
+			Block:             "aws_instance.removed",
+			Address:           "aws_instance.removed",
+			Title:             "aws_instance.removed -$20/mo",
+			Message:           "Monthly cost will decrease by $20",
+			PastMonthlyCost:   decimalPtr(decimal.NewFromInt(20)),
+			MonthlyCostChange: decimal.NewFromInt(-20),
+		},
+	}, ResourceAnnotations(out))
+}
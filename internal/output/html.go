@@ -7,13 +7,7 @@ import (
 	"html/template"
 	"strings"
 
-	"github.com/shopspring/decimal"
-
-	"github.com/infracost/infracost/internal/ui"
-
 	"github.com/Masterminds/sprig"
-
-	"github.com/rs/zerolog/log"
 )
 
 func ToHTML(out Root, opts Options) ([]byte, error) {
@@ -22,6 +16,7 @@ func ToHTML(out Root, opts Options) ([]byte, error) {
 
 	tmpl := template.New("html.tmpl")
 	tmpl.Funcs(sprig.FuncMap())
+	tmpl.Funcs(templateFuncs(out))
 	tmpl.Funcs(template.FuncMap{
 		"safeHTML": func(s interface{}) template.HTML {
 			return template.HTML(fmt.Sprint(s)) // nolint:gosec
@@ -31,31 +26,6 @@ func ToHTML(out Root, opts Options) ([]byte, error) {
 			safe = strings.ReplaceAll(safe, "\n", "<br />")
 			return template.HTML(safe) // nolint:gosec
 		},
-		"stripColor": ui.StripColor,
-		"contains":   contains,
-		"hasCost": func(cc []CostComponent, sr []Resource, resourceName string) bool {
-			if len(cc) > 0 || len(sr) > 0 {
-				return true
-			}
-
-			log.Info().Msgf("Hiding resource with no usage: %s", resourceName)
-			return false
-		},
-		"filterZeroValComponents": filterZeroValComponents,
-		"filterZeroValResources":  filterZeroValResources,
-		"formatCost2DP":           func(d *decimal.Decimal) string { return FormatCost2DP(out.Currency, d) },
-		"formatPrice":             func(d decimal.Decimal) string { return formatPrice(out.Currency, d) },
-		"formatTitleWithCurrency": func(title string) string { return formatTitleWithCurrency(title, out.Currency) },
-		"formatQuantity":          formatQuantity,
-		"projectLabel": func(p Project) string {
-			return p.Label()
-		},
-		"projectModulePath": func(p Project) string {
-			return p.Metadata.TerraformModulePath
-		},
-		"projectWorkspace": func(p Project) string {
-			return p.Metadata.WorkspaceLabel()
-		},
 	})
 	_, err := tmpl.ParseFS(templatesFS, "templates/html.tmpl")
 	if err != nil {
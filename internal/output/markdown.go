@@ -246,8 +246,11 @@ func ToMarkdown(out Root, opts Options, markdownOpts MarkdownOptions) (MarkdownO
 			}
 			return placeholders
 		},
-		"stringsJoin":    strings.Join,
-		"truncateMiddle": truncateMiddle,
+		"recommendations":   out.recommendationRows,
+		"stringsJoin":       strings.Join,
+		"truncateMiddle":    truncateMiddle,
+		"usageProfileNames": out.usageProfileNames,
+		"usageProfileRows":  out.usageProfileRows,
 	})
 	_, err := tmpl.ParseFS(templatesFS, "templates/"+filename)
 	if err != nil {
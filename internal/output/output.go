@@ -35,10 +35,26 @@ type Root struct {
 	PastTotalMonthlyCost *decimal.Decimal `json:"pastTotalMonthlyCost"`
 	DiffTotalHourlyCost  *decimal.Decimal `json:"diffTotalHourlyCost"`
 	DiffTotalMonthlyCost *decimal.Decimal `json:"diffTotalMonthlyCost"`
-	TimeGenerated        time.Time        `json:"timeGenerated"`
-	Summary              *Summary         `json:"summary"`
-	FullSummary          *Summary         `json:"-"`
-	IsCIRun              bool             `json:"-"`
+	// TotalListMonthlyCost is the total monthly cost at list prices. This is
+	// only set if pricing overrides changed any of the prices.
+	TotalListMonthlyCost *decimal.Decimal `json:"totalListMonthlyCost,omitempty"`
+	// TotalMonthlySavings is the total estimated monthly savings of the
+	// recommendations of all projects. This is only set if there are
+	// recommendations.
+	TotalMonthlySavings *decimal.Decimal `json:"totalMonthlySavings,omitempty"`
+	// MonthlyCostRange is the range of the total monthly cost for the usage
+	// profiles in the usage files. This is only set if any of the projects
+	// have usage profiles.
+	MonthlyCostRange *MonthlyCostRange `json:"monthlyCostRange,omitempty"`
+	// Forecast is the forecast of the total monthly cost, including the total
+	// cost of ownership. This is only set if a forecast was requested.
+	Forecast *Forecast `json:"forecast,omitempty"`
+	// TagPolicies are the results of the tag policies in the config file.
+	TagPolicies   []TagPolicyResult `json:"tagPolicies,omitempty"`
+	TimeGenerated time.Time         `json:"timeGenerated"`
+	Summary       *Summary          `json:"summary"`
+	FullSummary   *Summary          `json:"-"`
+	IsCIRun       bool              `json:"-"`
 }
 
 // HasUnsupportedResources returns if the summary has any unsupported resources.
@@ -63,7 +79,10 @@ type Project struct {
 	Breakdown     *Breakdown              `json:"breakdown"`
 	Diff          *Breakdown              `json:"diff"`
 	Summary       *Summary                `json:"summary"`
-	fullSummary   *Summary
+	// Recommendations are the cost saving recommendations for the resources
+	// of the project.
+	Recommendations []Recommendation `json:"recommendations,omitempty"`
+	fullSummary     *Summary
 }
 
 // ToSchemaProject generates a schema.Project from a Project. The created schema.Project is not suitable to be
@@ -131,6 +150,10 @@ func convertCostComponents(outComponents []CostComponent) []*schema.CostComponen
 			MonthlyQuantity: c.MonthlyQuantity,
 		}
 		sc.SetPrice(c.Price)
+		if c.ListPrice != nil {
+			sc.SetListPrice(c.ListPrice)
+			sc.ListMonthlyCost = c.ListMonthlyCost
+		}
 
 		components[i] = sc
 	}
@@ -237,6 +260,16 @@ type Breakdown struct {
 	FreeResources    []Resource       `json:"freeResources,omitempty"`
 	TotalHourlyCost  *decimal.Decimal `json:"totalHourlyCost"`
 	TotalMonthlyCost *decimal.Decimal `json:"totalMonthlyCost"`
+	// TotalListMonthlyCost is the total monthly cost at list prices. This is
+	// only set if pricing overrides changed any of the prices.
+	TotalListMonthlyCost *decimal.Decimal `json:"totalListMonthlyCost,omitempty"`
+	// MonthlyCostRange is the range of the total monthly cost for the usage
+	// profiles in the usage file. This is only set if the usage file defines
+	// usage profiles.
+	MonthlyCostRange *MonthlyCostRange `json:"monthlyCostRange,omitempty"`
+	// Forecast is the forecast of the total monthly cost. This is only set if
+	// a forecast was requested.
+	Forecast *Forecast `json:"forecast,omitempty"`
 }
 
 // HasResources returns true if the breakdown has any resources or free resources.
@@ -253,6 +286,17 @@ type CostComponent struct {
 	Price           decimal.Decimal  `json:"price"`
 	HourlyCost      *decimal.Decimal `json:"hourlyCost"`
 	MonthlyCost     *decimal.Decimal `json:"monthlyCost"`
+	// ListPrice and ListMonthlyCost are the price and monthly cost before
+	// pricing overrides were applied. These are only set if a pricing override
+	// changed the price.
+	ListPrice       *decimal.Decimal `json:"listPrice,omitempty"`
+	ListMonthlyCost *decimal.Decimal `json:"listMonthlyCost,omitempty"`
+
+	// service and region are taken from the product filter of the cost
+	// component. They are not part of the JSON output so are only set when
+	// the output is generated directly from a run.
+	service string
+	region  string
 }
 
 type ActualCosts struct {
@@ -272,6 +316,13 @@ type Resource struct {
 	CostComponents []CostComponent        `json:"costComponents,omitempty"`
 	ActualCosts    []ActualCosts          `json:"actualCosts,omitempty"`
 	SubResources   []Resource             `json:"subresources,omitempty"`
+	// MonthlyCostRange is the range of the monthly cost for the usage
+	// profiles in the usage file. This is only set if the usage file defines
+	// usage profiles.
+	MonthlyCostRange *MonthlyCostRange `json:"monthlyCostRange,omitempty"`
+	// Forecast is the forecast of the monthly cost. This is only set if a
+	// forecast was requested.
+	Forecast *Forecast `json:"forecast,omitempty"`
 }
 
 type Summary struct {
@@ -309,6 +360,7 @@ type Options struct {
 	diffMsg           string
 	originalSize      int
 	CurrencyFormat    string
+	TemplatePath      string
 }
 
 // PolicyOutput holds normalized PolicyCheck and TagPolicyCheck data so it can be output in
@@ -356,6 +408,15 @@ func NewPolicyOutput(pc PolicyCheck) PolicyOutput {
 		})
 	}
 
+	if pc.Enabled && len(pc.Warnings) > 0 {
+		po.HasWarnings = true
+		po.Checks = append(po.Checks, PolicyCheckOutput{
+			Name:    "Cost policy warning",
+			Warning: true,
+			Details: pc.Warnings,
+		})
+	}
+
 	if pc.Enabled && len(pc.Passed) > 0 {
 		po.Checks = append(po.Checks, PolicyCheckOutput{
 			Name:    "Cost policy passed",
@@ -371,6 +432,7 @@ func NewPolicyOutput(pc PolicyCheck) PolicyOutput {
 type PolicyCheck struct {
 	Enabled  bool
 	Failures PolicyCheckFailures
+	Warnings []string
 	Passed   []string
 }
 
@@ -448,7 +510,7 @@ func outputBreakdown(c *config.Config, resources []*schema.Resource) *Breakdown
 
 	for _, r := range resources {
 		if r.IsSkipped {
-			if c.TagPoliciesEnabled && r.Tags != nil {
+			if (c.TagPoliciesEnabled || len(c.TagPolicies) > 0) && r.Tags != nil {
 				freeResources = append(freeResources, newResource(r, nil, nil, nil))
 			}
 
@@ -463,10 +525,11 @@ func outputBreakdown(c *config.Config, resources []*schema.Resource) *Breakdown
 	totalMonthlyCost, totalHourlyCost := calculateTotalCosts(supportedResources)
 
 	return &Breakdown{
-		Resources:        supportedResources,
-		FreeResources:    freeResources,
-		TotalHourlyCost:  totalMonthlyCost,
-		TotalMonthlyCost: totalHourlyCost,
+		Resources:            supportedResources,
+		FreeResources:        freeResources,
+		TotalHourlyCost:      totalMonthlyCost,
+		TotalMonthlyCost:     totalHourlyCost,
+		TotalListMonthlyCost: calculateTotalListMonthlyCost(supportedResources),
 	}
 }
 func outputResource(r *schema.Resource) Resource {
@@ -504,6 +567,16 @@ func newResource(r *schema.Resource, comps []CostComponent, actualCosts []Actual
 func outputCostComponents(costComponents []*schema.CostComponent) []CostComponent {
 	comps := make([]CostComponent, 0, len(costComponents))
 	for _, c := range costComponents {
+		var service, region string
+		if c.ProductFilter != nil {
+			if c.ProductFilter.Service != nil {
+				service = *c.ProductFilter.Service
+			}
+			if c.ProductFilter.Region != nil {
+				region = *c.ProductFilter.Region
+			}
+		}
+
 		comps = append(comps, CostComponent{
 			Name:            c.Name,
 			Unit:            c.Unit,
@@ -512,6 +585,10 @@ func outputCostComponents(costComponents []*schema.CostComponent) []CostComponen
 			Price:           c.UnitMultiplierPrice(),
 			HourlyCost:      c.HourlyCost,
 			MonthlyCost:     c.MonthlyCost,
+			ListPrice:       c.UnitMultiplierListPrice(),
+			ListMonthlyCost: c.ListMonthlyCost,
+			service:         service,
+			region:          region,
 		})
 	}
 	return comps
@@ -543,6 +620,8 @@ func ToOutputFormat(c *config.Config, projects []*schema.Project) (Root, error)
 		var pastBreakdown, breakdown, diff *Breakdown
 
 		breakdown = outputBreakdown(c, project.Resources)
+		addMonthlyCostRanges(breakdown, project.UsageProfiles)
+		addForecasts(breakdown, project.Forecast)
 
 		if breakdown != nil {
 			if breakdown.TotalHourlyCost != nil {
@@ -620,13 +699,14 @@ func ToOutputFormat(c *config.Config, projects []*schema.Project) (Root, error)
 		fullSummaries = append(fullSummaries, fullSummary)
 
 		outProjects = append(outProjects, Project{
-			Name:          project.Name,
-			Metadata:      project.Metadata,
-			PastBreakdown: pastBreakdown,
-			Breakdown:     breakdown,
-			Diff:          diff,
-			Summary:       summary,
-			fullSummary:   fullSummary,
+			Name:            project.Name,
+			Metadata:        project.Metadata,
+			PastBreakdown:   pastBreakdown,
+			Breakdown:       breakdown,
+			Diff:            diff,
+			Summary:         summary,
+			Recommendations: outputRecommendations(project.Recommendations),
+			fullSummary:     fullSummary,
 		})
 	}
 
@@ -639,6 +719,10 @@ func ToOutputFormat(c *config.Config, projects []*schema.Project) (Root, error)
 		PastTotalMonthlyCost: pastTotalMonthlyCost,
 		DiffTotalHourlyCost:  diffTotalHourlyCost,
 		DiffTotalMonthlyCost: diffTotalMonthlyCost,
+		TotalListMonthlyCost: projectsTotalListMonthlyCost(outProjects),
+		TotalMonthlySavings:  projectsTotalMonthlySavings(outProjects),
+		MonthlyCostRange:     totalMonthlyCostRange(outProjects),
+		Forecast:             totalForecast(outProjects),
 		TimeGenerated:        time.Now().UTC(),
 		Summary:              MergeSummaries(summaries),
 		FullSummary:          MergeSummaries(fullSummaries),
@@ -935,6 +1019,66 @@ func calculateTotalCosts(resources []Resource) (*decimal.Decimal, *decimal.Decim
 	return totalHourlyCost, totalMonthlyCost
 }
 
+// calculateTotalListMonthlyCost returns the total monthly cost of the resources
+// at list prices, or nil if no pricing overrides changed any of the prices.
+func calculateTotalListMonthlyCost(resources []Resource) *decimal.Decimal {
+	total := decimal.Zero
+	hasListCost := false
+
+	var add func(r Resource)
+	add = func(r Resource) {
+		for _, c := range r.CostComponents {
+			if c.ListMonthlyCost != nil {
+				hasListCost = true
+				total = total.Add(*c.ListMonthlyCost)
+			} else if c.MonthlyCost != nil {
+				total = total.Add(*c.MonthlyCost)
+			}
+		}
+
+		for _, s := range r.SubResources {
+			add(s)
+		}
+	}
+
+	for _, r := range resources {
+		add(r)
+	}
+
+	if !hasListCost {
+		return nil
+	}
+
+	return &total
+}
+
+// projectsTotalListMonthlyCost returns the total monthly cost of the projects
+// at list prices, or nil if no pricing overrides changed any of the prices.
+// Projects without overrides contribute their total monthly cost.
+func projectsTotalListMonthlyCost(projects []Project) *decimal.Decimal {
+	total := decimal.Zero
+	hasListCost := false
+
+	for _, p := range projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		if p.Breakdown.TotalListMonthlyCost != nil {
+			hasListCost = true
+			total = total.Add(*p.Breakdown.TotalListMonthlyCost)
+		} else if p.Breakdown.TotalMonthlyCost != nil {
+			total = total.Add(*p.Breakdown.TotalMonthlyCost)
+		}
+	}
+
+	if !hasListCost {
+		return nil
+	}
+
+	return &total
+}
+
 func sortResources(resources []Resource, groupKey string) {
 	sort.Slice(resources, func(i, j int) bool {
 		// If an empty group key is passed just sort by name
@@ -0,0 +1,113 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// Recommendation is a suggested change to a resource that follows cloud cost
+// best practices.
+type Recommendation struct {
+	ResourceName string `json:"resourceName"`
+	ResourceType string `json:"resourceType"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	// MonthlySavings is the estimated monthly savings of the change. It is nil
+	// if the savings depend on usage that can't be estimated.
+	MonthlySavings *decimal.Decimal `json:"monthlySavings"`
+}
+
+func outputRecommendations(recommendations []*schema.Recommendation) []Recommendation {
+	if len(recommendations) == 0 {
+		return nil
+	}
+
+	out := make([]Recommendation, 0, len(recommendations))
+	for _, r := range recommendations {
+		out = append(out, Recommendation{
+			ResourceName:   r.ResourceName,
+			ResourceType:   r.ResourceType,
+			Title:          r.Title,
+			Description:    r.Description,
+			MonthlySavings: r.MonthlySavings,
+		})
+	}
+
+	return out
+}
+
+// projectsTotalMonthlySavings returns the sum of the estimated monthly savings
+// of the recommendations of the projects, or nil if there are no
+// recommendations.
+func projectsTotalMonthlySavings(projects []Project) *decimal.Decimal {
+	var total *decimal.Decimal
+
+	for _, p := range projects {
+		for _, r := range p.Recommendations {
+			if total == nil {
+				total = decimalPtr(decimal.Zero)
+			}
+
+			if r.MonthlySavings != nil {
+				total = decimalPtr(total.Add(*r.MonthlySavings))
+			}
+		}
+	}
+
+	return total
+}
+
+// recommendationRow is a recommendation with the label of its project, so the
+// recommendations of all projects can be listed together.
+type recommendationRow struct {
+	Recommendation
+	// Project is only set if there is more than one project.
+	Project string
+}
+
+func (r *Root) recommendationRows() []recommendationRow {
+	var rows []recommendationRow
+
+	for _, p := range r.Projects {
+		var project string
+		if len(r.Projects) > 1 {
+			project = p.Label()
+		}
+
+		for _, rec := range p.Recommendations {
+			rows = append(rows, recommendationRow{Recommendation: rec, Project: project})
+		}
+	}
+
+	return rows
+}
+
+// recommendationsText renders the recommendations for the table format.
+func recommendationsText(out Root) string {
+	rows := out.recommendationRows()
+	if len(rows) == 0 {
+		return ""
+	}
+
+	s := "\n──────────────────────────────────\n"
+	s += ui.BoldString(fmt.Sprintf("Recommendations, potential monthly savings %s", FormatCost2DP(out.Currency, out.TotalMonthlySavings)))
+
+	for _, r := range rows {
+		name := r.ResourceName
+		if r.Project != "" {
+			name = fmt.Sprintf("%s (%s)", name, r.Project)
+		}
+
+		s += fmt.Sprintf("\n\n - %s: %s", name, r.Title)
+		if r.MonthlySavings != nil {
+			s += fmt.Sprintf(", saves %s/month", FormatCost2DP(out.Currency, r.MonthlySavings))
+		}
+		s += "\n   " + ui.FaintString(r.Description)
+	}
+
+	return s
+}
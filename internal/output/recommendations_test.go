@@ -0,0 +1,52 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func TestProjectsTotalMonthlySavings(t *testing.T) {
+	assert.Nil(t, projectsTotalMonthlySavings([]Project{{Name: "a"}}))
+
+	total := projectsTotalMonthlySavings([]Project{
+		{Name: "a", Recommendations: []Recommendation{
+			{ResourceName: "aws_ebs_volume.a", MonthlySavings: decimalPtr(decimal.RequireFromString("2.5"))},
+			{ResourceName: "aws_s3_bucket.a"},
+		}},
+		{Name: "b", Recommendations: []Recommendation{
+			{ResourceName: "aws_instance.b", MonthlySavings: decimalPtr(decimal.NewFromInt(10))},
+		}},
+	})
+	assert.Equal(t, "12.5", total.String())
+
+	total = projectsTotalMonthlySavings([]Project{
+		{Name: "a", Recommendations: []Recommendation{{ResourceName: "aws_s3_bucket.a"}}},
+	})
+	assert.Equal(t, "0", total.String())
+}
+
+func TestRecommendationsText(t *testing.T) {
+	assert.Equal(t, "", recommendationsText(Root{Projects: []Project{{Name: "a"}}}))
+
+	out := Root{
+		Currency:            "USD",
+		TotalMonthlySavings: decimalPtr(decimal.RequireFromString("2.5")),
+		Projects: []Project{
+			{Name: "a", Recommendations: []Recommendation{
+				{ResourceName: "aws_ebs_volume.a", Title: "Use gp3", Description: "gp3 is cheaper.", MonthlySavings: decimalPtr(decimal.RequireFromString("2.5"))},
+			}},
+			{Name: "b", Recommendations: []Recommendation{
+				{ResourceName: "aws_s3_bucket.b", Title: "Add lifecycle rules", Description: "Savings depend on usage."},
+			}},
+		},
+	}
+
+	assert.Equal(t, "\n──────────────────────────────────\nRecommendations, potential monthly savings $2.50"+
+		"\n\n - aws_ebs_volume.a (a): Use gp3, saves $2.50/month\n   gp3 is cheaper."+
+		"\n\n - aws_s3_bucket.b (b): Add lifecycle rules\n   Savings depend on usage.",
+		ui.StripColor(recommendationsText(out)))
+}
@@ -0,0 +1,82 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// ReviewComment is an inline comment on the block that defines one or more
+// resources whose monthly cost changed, e.g. a resource block with count or a
+// module call.
+type ReviewComment struct {
+	// Key identifies the block so the comment can be found and kept in sync
+	// when the output changes.
+	Key       string
+	Path      string
+	StartLine int
+	EndLine   int
+	Body      string
+}
+
+// ToReviewComments groups the resource annotations by the block they are
+// defined in and returns a markdown review comment for each block. Resources
+// whose absolute monthly cost change is below the threshold are ignored.
+func ToReviewComments(out Root, threshold decimal.Decimal) []ReviewComment {
+	var keys []string
+	groups := make(map[string][]ResourceAnnotation)
+
+	for _, a := range ResourceAnnotations(out) {
+		if a.MonthlyCostChange.Abs().LessThan(threshold) {
+			continue
+		}
+
+		key := fmt.Sprintf("%s:%s", a.Path, a.Block)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], a)
+	}
+
+	comments := make([]ReviewComment, 0, len(keys))
+	for _, key := range keys {
+		annotations := groups[key]
+
+		total := decimal.Zero
+		for _, a := range annotations {
+			total = total.Add(a.MonthlyCostChange)
+		}
+
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "💰 **Infracost**: %s\n\n", formatCostChangeSentence(out.Currency, decimalPtr(decimal.Zero), &total, true))
+		buf.WriteString("| Resource | Monthly cost change | Previous | New |\n")
+		buf.WriteString("| --- | ---: | ---: | ---: |\n")
+		for _, a := range annotations {
+			fmt.Fprintf(&buf, "| `%s` | %s | %s | %s |\n",
+				a.Address,
+				formatCostChange(out.Currency, &a.MonthlyCostChange),
+				formatReviewCost(out.Currency, a.PastMonthlyCost),
+				formatReviewCost(out.Currency, a.MonthlyCost),
+			)
+		}
+
+		comments = append(comments, ReviewComment{
+			Key:       key,
+			Path:      annotations[0].Path,
+			StartLine: annotations[0].StartLine,
+			EndLine:   annotations[0].EndLine,
+			Body:      buf.String(),
+		})
+	}
+
+	return comments
+}
+
+func formatReviewCost(currency string, d *decimal.Decimal) string {
+	if d == nil {
+		return "-"
+	}
+
+	return formatCost(currency, d)
+}
@@ -0,0 +1,61 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToReviewComments(t *testing.T) {
+	instanceMetadata := map[string]interface{}{
+		"filename":  "main.tf",
+		"startLine": 1,
+		"endLine":   5,
+		"calls":     []interface{}{map[string]interface{}{"filename": "main.tf", "blockName": "aws_instance.web", "startLine": 1, "endLine": 5}},
+	}
+
+	out := Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				PastBreakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web[0]", MonthlyCost: decimalPtr(decimal.NewFromInt(10)), Metadata: instanceMetadata},
+					},
+				},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web[0]", MonthlyCost: decimalPtr(decimal.NewFromInt(20)), Metadata: instanceMetadata},
+						{Name: "aws_instance.web[1]", MonthlyCost: decimalPtr(decimal.NewFromInt(20)), Metadata: instanceMetadata},
+						{Name: "aws_s3_bucket.logs", MonthlyCost: decimalPtr(decimal.NewFromFloat(0.5)), Metadata: map[string]interface{}{"filename": "s3.tf", "startLine": 3, "endLine": 6}},
+					},
+				},
+				Diff: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web[0]", MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+						{Name: "aws_instance.web[1]", MonthlyCost: decimalPtr(decimal.NewFromInt(20))},
+						{Name: "aws_s3_bucket.logs", MonthlyCost: decimalPtr(decimal.NewFromFloat(0.5))},
+					},
+				},
+			},
+		},
+	}
+
+	comments := ToReviewComments(out, decimal.NewFromInt(1))
+	assert.Equal(t, []ReviewComment{
+		{
+			Key:       "main.tf:aws_instance.web",
+			Path:      "main.tf",
+			StartLine: 1,
+			EndLine:   5,
+			Body: "💰 **Infracost**: Monthly cost will increase by $30 📈\n\n" +
+				"| Resource | Monthly cost change | Previous | New |\n" +
+				"| --- | ---: | ---: | ---: |\n" +
+				"| `aws_instance.web[0]` | +$10 | $10 | $20 |\n" +
+				"| `aws_instance.web[1]` | +$20 | - | $20 |\n",
+		},
+	}, comments)
+
+	assert.Len(t, ToReviewComments(out, decimal.Zero), 2)
+}
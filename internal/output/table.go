@@ -74,6 +74,14 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 		fmt.Sprintf("%*s ", padding, totalOut), // pad based on the last line length
 	)
 
+	if out.TotalListMonthlyCost != nil {
+		s += fmt.Sprintf("\n%s", ui.FaintString(fmt.Sprintf("Pricing overrides applied, the total at list prices is %s", FormatCost2DP(out.Currency, out.TotalListMonthlyCost))))
+	}
+
+	s += usageProfilesText(out)
+	s += forecastText(out)
+	s += recommendationsText(out)
+
 	summaryMsg := out.summaryMessage(opts.ShowSkipped)
 
 	if summaryMsg != "" {
@@ -246,7 +254,7 @@ func buildCostComponentRows(t table.Writer, currency string, costComponents []Co
 
 		if c.MonthlyCost == nil {
 			price := fmt.Sprintf("Monthly cost depends on usage: %s per %s",
-				formatPrice(currency, c.Price),
+				formatComponentPrice(currency, c),
 				c.Unit,
 			)
 
@@ -262,7 +270,7 @@ func buildCostComponentRows(t table.Writer, currency string, costComponents []Co
 			tableRow = append(tableRow, label)
 
 			if contains(fields, "price") {
-				tableRow = append(tableRow, formatPrice(currency, c.Price))
+				tableRow = append(tableRow, formatComponentPrice(currency, c))
 			}
 			if contains(fields, "monthlyQuantity") {
 				tableRow = append(tableRow, formatQuantity(c.MonthlyQuantity))
@@ -282,6 +290,16 @@ func buildCostComponentRows(t table.Writer, currency string, costComponents []Co
 	}
 }
 
+// formatComponentPrice formats the price of the cost component, including the
+// list price if a pricing override changed the price.
+func formatComponentPrice(currency string, c CostComponent) string {
+	if c.ListPrice == nil {
+		return formatPrice(currency, c.Price)
+	}
+
+	return fmt.Sprintf("%s (list %s)", formatPrice(currency, c.Price), formatPrice(currency, *c.ListPrice))
+}
+
 func buildActualCostRows(t table.Writer, currency string, actualCosts []ActualCosts, prefix string, fields []string) {
 	for i, ac := range actualCosts {
 		labelPrefix := prefix + "├─"
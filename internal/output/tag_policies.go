@@ -0,0 +1,254 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+// maxTagPolicyResourceDetails is the maximum number of resources that are
+// listed for each tag policy in the policy output, so comments don't get too
+// long.
+const maxTagPolicyResourceDetails = 50
+
+// TagPolicyResult holds the result of evaluating a tag policy from the config
+// file against the resources of the output.
+type TagPolicyResult struct {
+	Name string `json:"name"`
+	// Warning is true if the failing resources are reported as a warning
+	// rather than failing the run.
+	Warning bool `json:"warning"`
+	// ResourceCount is the number of taggable resources the tag policy was
+	// evaluated against.
+	ResourceCount    int                       `json:"resourceCount"`
+	FailingResources []TagPolicyResourceResult `json:"failingResources"`
+}
+
+// TagPolicyResourceResult is a resource that doesn't meet the requirements
+// of a tag policy.
+type TagPolicyResourceResult struct {
+	Address      string   `json:"address"`
+	ResourceType string   `json:"resourceType"`
+	ProjectName  string   `json:"projectName"`
+	Path         string   `json:"path,omitempty"`
+	Line         int      `json:"line,omitempty"`
+	Violations   []string `json:"violations"`
+}
+
+// TagPolicyFailures defines a list of tag policies that have failing
+// resources.
+type TagPolicyFailures []string
+
+// Error implements the Error interface returning the failures as a single message that can be used in stderr.
+func (t TagPolicyFailures) Error() string {
+	if len(t) == 0 {
+		return ""
+	}
+
+	out := &strings.Builder{}
+	out.WriteString("Tag policy check failed:\n\n")
+
+	for _, f := range t {
+		out.WriteString(" - " + f + "\n")
+	}
+
+	return out.String()
+}
+
+// CheckTagPolicies evaluates the tag policies against the taggable resources
+// of the projects, including free resources. Resources without tags support
+// are not evaluated.
+func CheckTagPolicies(out Root, policies config.TagPolicies) []TagPolicyResult {
+	if len(policies) == 0 {
+		return nil
+	}
+
+	results := make([]TagPolicyResult, 0, len(policies))
+	for _, tp := range policies {
+		result := TagPolicyResult{
+			Name:             tp.String(),
+			Warning:          tp.Warning,
+			FailingResources: []TagPolicyResourceResult{},
+		}
+
+		for _, p := range out.Projects {
+			if p.Breakdown == nil {
+				continue
+			}
+
+			var path string
+			if p.Metadata != nil {
+				if p.Metadata.HasErrors() {
+					continue
+				}
+
+				path = p.Metadata.Path
+			}
+
+			if !tp.MatchesPath(path) {
+				continue
+			}
+
+			resources := append(append([]Resource{}, p.Breakdown.Resources...), p.Breakdown.FreeResources...)
+			for _, r := range resources {
+				if r.Tags == nil || !tp.MatchesResourceType(r.ResourceType) {
+					continue
+				}
+
+				result.ResourceCount++
+
+				violations := tp.Violations(*r.Tags)
+				if len(violations) == 0 {
+					continue
+				}
+
+				filename, line, _, _ := resourceLocation(r)
+				if filename == "" {
+					line = 0
+				}
+
+				result.FailingResources = append(result.FailingResources, TagPolicyResourceResult{
+					Address:      r.Name,
+					ResourceType: r.ResourceType,
+					ProjectName:  p.Label(),
+					Path:         filename,
+					Line:         line,
+					Violations:   violations,
+				})
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// NewTagPolicyFailures returns the failures of the tag policies that have
+// failing resources and are not warnings.
+func NewTagPolicyFailures(results []TagPolicyResult) TagPolicyFailures {
+	var failures TagPolicyFailures
+	for _, r := range results {
+		if !r.Warning && len(r.FailingResources) > 0 {
+			failures = append(failures, tagPolicyMessage(r))
+		}
+	}
+
+	return failures
+}
+
+func tagPolicyMessage(r TagPolicyResult) string {
+	return fmt.Sprintf("%s: %d of %d resources don't meet the tag requirements", r.Name, len(r.FailingResources), r.ResourceCount)
+}
+
+// AddTagPolicyResults adds the results of the tag policies to the policy
+// output so they are shown in the policies section of the output.
+func (p *PolicyOutput) AddTagPolicyResults(results []TagPolicyResult) {
+	var passed []string
+
+	for _, r := range results {
+		if len(r.FailingResources) == 0 {
+			passed = append(passed, fmt.Sprintf("%s: %d resources meet the tag requirements", r.Name, r.ResourceCount))
+			continue
+		}
+
+		check := PolicyCheckOutput{
+			Name:    "Tag policy failed",
+			Failure: true,
+			Message: tagPolicyMessage(r),
+		}
+		if r.Warning {
+			check.Name = "Tag policy warning"
+			check.Failure = false
+			check.Warning = true
+			p.HasWarnings = true
+		} else {
+			p.HasFailures = true
+		}
+
+		check.ResourceDetails, check.TruncatedCount = tagPolicyResourceDetails(r.FailingResources)
+		p.Checks = append(p.Checks, check)
+	}
+
+	if len(passed) > 0 {
+		p.Checks = append(p.Checks, PolicyCheckOutput{
+			Name:    "Tag policy passed",
+			Details: passed,
+		})
+	}
+}
+
+// mergeTagPolicyResults merges the results of tag policies with the same name,
+// e.g. from multiple Infracost JSON files.
+func mergeTagPolicyResults(results []TagPolicyResult) []TagPolicyResult {
+	var merged []TagPolicyResult
+	index := make(map[string]int)
+
+	for _, r := range results {
+		i, ok := index[r.Name]
+		if !ok {
+			index[r.Name] = len(merged)
+			r.FailingResources = append([]TagPolicyResourceResult{}, r.FailingResources...)
+			merged = append(merged, r)
+			continue
+		}
+
+		merged[i].ResourceCount += r.ResourceCount
+		merged[i].FailingResources = append(merged[i].FailingResources, r.FailingResources...)
+	}
+
+	return merged
+}
+
+// tagPolicyResourceDetails groups the failing resources by their address and
+// location, so a resource that fails in multiple projects is only listed once.
+func tagPolicyResourceDetails(resources []TagPolicyResourceResult) ([]PolicyCheckResourceDetails, int) {
+	var details []PolicyCheckResourceDetails
+	index := make(map[string]int)
+
+	for _, r := range resources {
+		key := fmt.Sprintf("%s\x00%s\x00%d", r.Address, r.Path, r.Line)
+		i, ok := index[key]
+		if !ok {
+			i = len(details)
+			index[key] = i
+			details = append(details, PolicyCheckResourceDetails{
+				Address:      r.Address,
+				ResourceType: r.ResourceType,
+				Path:         r.Path,
+				Line:         r.Line,
+			})
+		}
+
+		violations := &details[i].Violations
+		merged := false
+		for j := range *violations {
+			if strings.Join((*violations)[j].Details, "\n") == strings.Join(r.Violations, "\n") {
+				(*violations)[j].ProjectNames = append((*violations)[j].ProjectNames, r.ProjectName)
+				merged = true
+				break
+			}
+		}
+
+		if !merged {
+			*violations = append(*violations, PolicyCheckViolations{
+				Details:      r.Violations,
+				ProjectNames: []string{r.ProjectName},
+			})
+		}
+	}
+
+	sort.SliceStable(details, func(i, j int) bool {
+		return details[i].Address < details[j].Address
+	})
+
+	truncated := 0
+	if len(details) > maxTagPolicyResourceDetails {
+		truncated = len(details) - maxTagPolicyResourceDetails
+		details = details[:maxTagPolicyResourceDetails]
+	}
+
+	return details, truncated
+}
@@ -0,0 +1,144 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func tagPolicyTestResource(name, resourceType string, tags map[string]string) Resource {
+	r := Resource{Name: name, ResourceType: resourceType}
+	if tags != nil {
+		r.Tags = &tags
+	}
+
+	return r
+}
+
+func TestCheckTagPolicies(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{
+				Name:     "prod",
+				Metadata: &schema.ProjectMetadata{Path: "infra/prod"},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						tagPolicyTestResource("aws_instance.web", "aws_instance", map[string]string{"Owner": "web"}),
+						tagPolicyTestResource("aws_instance.untagged", "aws_instance", map[string]string{}),
+						tagPolicyTestResource("aws_eip.unsupported", "aws_eip", nil),
+					},
+					FreeResources: []Resource{
+						tagPolicyTestResource("aws_s3_bucket.logs", "aws_s3_bucket", map[string]string{"Owner": "ops"}),
+					},
+				},
+			},
+			{
+				Name:     "dev",
+				Metadata: &schema.ProjectMetadata{Path: "infra/dev"},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						tagPolicyTestResource("aws_instance.web", "aws_instance", map[string]string{}),
+					},
+				},
+			},
+		},
+	}
+
+	assert.Nil(t, CheckTagPolicies(out, nil))
+
+	results := CheckTagPolicies(out, config.TagPolicies{
+		{Name: "Owners", Tags: []config.TagRequirement{{Key: "Owner"}}},
+		{Path: "infra/prod", ResourceTypes: []string{"aws_s3_*"}, Warning: true, Tags: []config.TagRequirement{{Key: "Owner", AllowedValues: []string{"web"}}}},
+	})
+
+	assert.Equal(t, []TagPolicyResult{
+		{
+			Name:          "Owners",
+			ResourceCount: 4,
+			FailingResources: []TagPolicyResourceResult{
+				{Address: "aws_instance.untagged", ResourceType: "aws_instance", ProjectName: "prod", Violations: []string{"Missing required tag Owner"}},
+				{Address: "aws_instance.web", ResourceType: "aws_instance", ProjectName: "dev", Violations: []string{"Missing required tag Owner"}},
+			},
+		},
+		{
+			Name:          "Tag policy for resource types aws_s3_*, path infra/prod",
+			Warning:       true,
+			ResourceCount: 1,
+			FailingResources: []TagPolicyResourceResult{
+				{Address: "aws_s3_bucket.logs", ResourceType: "aws_s3_bucket", ProjectName: "prod", Violations: []string{"Tag Owner has value \"ops\", allowed values are web"}},
+			},
+		},
+	}, results)
+
+	assert.Equal(t, TagPolicyFailures{"Owners: 2 of 4 resources don't meet the tag requirements"}, NewTagPolicyFailures(results))
+}
+
+func TestTagPolicyFailuresError(t *testing.T) {
+	assert.Equal(t, "", TagPolicyFailures{}.Error())
+	assert.Equal(t, "Tag policy check failed:\n\n - Owners: 1 of 2 resources don't meet the tag requirements\n", TagPolicyFailures{"Owners: 1 of 2 resources don't meet the tag requirements"}.Error())
+}
+
+func TestPolicyOutputAddTagPolicyResults(t *testing.T) {
+	var po PolicyOutput
+	po.AddTagPolicyResults([]TagPolicyResult{
+		{
+			Name:          "Owners",
+			ResourceCount: 3,
+			FailingResources: []TagPolicyResourceResult{
+				{Address: "aws_instance.web", ProjectName: "prod", Path: "main.tf", Line: 10, Violations: []string{"Missing required tag Owner"}},
+				{Address: "aws_instance.web", ProjectName: "dev", Path: "main.tf", Line: 10, Violations: []string{"Missing required tag Owner"}},
+			},
+		},
+		{
+			Name:          "Environments",
+			Warning:       true,
+			ResourceCount: 3,
+			FailingResources: []TagPolicyResourceResult{
+				{Address: "aws_instance.db", ProjectName: "prod", Violations: []string{"Missing required tag Environment"}},
+			},
+		},
+		{Name: "Cost centers", ResourceCount: 3},
+	})
+
+	assert.True(t, po.HasFailures)
+	assert.True(t, po.HasWarnings)
+	assert.Equal(t, []PolicyCheckOutput{
+		{
+			Name:    "Tag policy failed",
+			Failure: true,
+			Message: "Owners: 2 of 3 resources don't meet the tag requirements",
+			ResourceDetails: []PolicyCheckResourceDetails{
+				{Address: "aws_instance.web", Path: "main.tf", Line: 10, Violations: []PolicyCheckViolations{
+					{Details: []string{"Missing required tag Owner"}, ProjectNames: []string{"prod", "dev"}},
+				}},
+			},
+		},
+		{
+			Name:    "Tag policy warning",
+			Warning: true,
+			Message: "Environments: 1 of 3 resources don't meet the tag requirements",
+			ResourceDetails: []PolicyCheckResourceDetails{
+				{Address: "aws_instance.db", Violations: []PolicyCheckViolations{
+					{Details: []string{"Missing required tag Environment"}, ProjectNames: []string{"prod"}},
+				}},
+			},
+		},
+		{Name: "Tag policy passed", Details: []string{"Cost centers: 3 resources meet the tag requirements"}},
+	}, po.Checks)
+}
+
+func TestMergeTagPolicyResults(t *testing.T) {
+	merged := mergeTagPolicyResults([]TagPolicyResult{
+		{Name: "Owners", ResourceCount: 2, FailingResources: []TagPolicyResourceResult{{Address: "a"}}},
+		{Name: "Environments", ResourceCount: 1, FailingResources: []TagPolicyResourceResult{}},
+		{Name: "Owners", ResourceCount: 3, FailingResources: []TagPolicyResourceResult{{Address: "b"}}},
+	})
+
+	assert.Equal(t, []TagPolicyResult{
+		{Name: "Owners", ResourceCount: 5, FailingResources: []TagPolicyResourceResult{{Address: "a"}, {Address: "b"}}},
+		{Name: "Environments", ResourceCount: 1, FailingResources: []TagPolicyResourceResult{}},
+	}, merged)
+}
@@ -0,0 +1,298 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+	adaptiveCardSchema      = "http://adaptivecards.io/schemas/adaptive-card.json"
+	adaptiveCardVersion     = "1.5"
+)
+
+// TeamsMessage is the payload of a Microsoft Teams incoming webhook message
+// that contains a single Adaptive Card.
+type TeamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []TeamsAttachment `json:"attachments"`
+}
+
+type TeamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+type AdaptiveCard struct {
+	Schema  string                `json:"$schema"`
+	Type    string                `json:"type"`
+	Version string                `json:"version"`
+	MSTeams map[string]string     `json:"msteams,omitempty"`
+	Body    []AdaptiveCardElement `json:"body"`
+	Actions []AdaptiveCardAction  `json:"actions,omitempty"`
+}
+
+// AdaptiveCardElement is a body element of an Adaptive Card. Only the fields
+// used by the elements we output, i.e. TextBlock, Container and Table, are
+// supported.
+type AdaptiveCardElement struct {
+	Type                string                `json:"type"`
+	Text                string                `json:"text,omitempty"`
+	Weight              string                `json:"weight,omitempty"`
+	Size                string                `json:"size,omitempty"`
+	Color               string                `json:"color,omitempty"`
+	IsSubtle            bool                  `json:"isSubtle,omitempty"`
+	Wrap                bool                  `json:"wrap,omitempty"`
+	Separator           bool                  `json:"separator,omitempty"`
+	HorizontalAlignment string                `json:"horizontalAlignment,omitempty"`
+	Items               []AdaptiveCardElement `json:"items,omitempty"`
+	Columns             []AdaptiveCardColumn  `json:"columns,omitempty"`
+	FirstRowAsHeader    bool                  `json:"firstRowAsHeader,omitempty"`
+	Rows                []AdaptiveCardRow     `json:"rows,omitempty"`
+}
+
+type AdaptiveCardColumn struct {
+	Width int `json:"width"`
+}
+
+type AdaptiveCardRow struct {
+	Type  string             `json:"type"`
+	Cells []AdaptiveCardCell `json:"cells"`
+}
+
+type AdaptiveCardCell struct {
+	Type  string                `json:"type"`
+	Items []AdaptiveCardElement `json:"items"`
+}
+
+type AdaptiveCardAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+func teamsTextBlock(text string) AdaptiveCardElement {
+	return AdaptiveCardElement{
+		Type: "TextBlock",
+		Text: text,
+		Wrap: true,
+	}
+}
+
+func teamsTableRow(cells ...string) AdaptiveCardRow {
+	row := AdaptiveCardRow{Type: "TableRow"}
+	for i, text := range cells {
+		block := teamsTextBlock(text)
+		if i > 0 {
+			block.HorizontalAlignment = "Right"
+		}
+
+		row.Cells = append(row.Cells, AdaptiveCardCell{
+			Type:  "TableCell",
+			Items: []AdaptiveCardElement{block},
+		})
+	}
+
+	return row
+}
+
+func teamsSummaryRow(name string, currency string, pastCost, cost *decimal.Decimal) AdaptiveCardRow {
+	formatCostOrZero := func(d *decimal.Decimal) string {
+		if d == nil || d.IsZero() {
+			return formatWholeDecimalCurrency(currency, decimal.Zero)
+		}
+		return formatCost(currency, d)
+	}
+
+	if cost == nil {
+		cost = decimalPtr(decimal.Zero)
+	}
+
+	return teamsTableRow(
+		truncateMiddle(name, 64, "..."),
+		formatCostOrZero(pastCost),
+		formatCostOrZero(cost),
+		formatMarkdownCostChange(currency, pastCost, cost, false),
+	)
+}
+
+func teamsProjectsTable(out Root, opts Options) (AdaptiveCardElement, int) {
+	table := AdaptiveCardElement{
+		Type:             "Table",
+		Columns:          []AdaptiveCardColumn{{Width: 3}, {Width: 2}, {Width: 2}, {Width: 2}},
+		FirstRowAsHeader: true,
+		Rows:             []AdaptiveCardRow{teamsTableRow("Project", "Previous", "New", "Diff")},
+	}
+
+	hiddenProjectCount := 0
+	for _, project := range out.Projects {
+		if len(out.Projects) != 1 && !showProject(project, opts, false) {
+			hiddenProjectCount++
+			continue
+		}
+
+		var pastCost, cost *decimal.Decimal
+		if project.PastBreakdown != nil {
+			pastCost = project.PastBreakdown.TotalMonthlyCost
+		}
+		if project.Breakdown != nil {
+			cost = project.Breakdown.TotalMonthlyCost
+		}
+
+		table.Rows = append(table.Rows, teamsSummaryRow(project.Label(), out.Currency, pastCost, cost))
+	}
+
+	if len(out.Projects) > 1 {
+		table.Rows = append(table.Rows, teamsSummaryRow("All projects", out.Currency, out.PastTotalMonthlyCost, out.TotalMonthlyCost))
+	}
+
+	return table, hiddenProjectCount
+}
+
+func teamsPolicyContainer(policyOutput PolicyOutput) AdaptiveCardElement {
+	container := AdaptiveCardElement{
+		Type:      "Container",
+		Separator: true,
+	}
+
+	if !policyOutput.HasFailures && !policyOutput.HasWarnings {
+		container.Items = append(container.Items, AdaptiveCardElement{
+			Type:   "TextBlock",
+			Text:   "✅ Policies passed",
+			Weight: "Bolder",
+			Color:  "Good",
+			Wrap:   true,
+		})
+		return container
+	}
+
+	title := AdaptiveCardElement{
+		Type:   "TextBlock",
+		Text:   "⚠️ Policies warning",
+		Weight: "Bolder",
+		Color:  "Warning",
+		Wrap:   true,
+	}
+	if policyOutput.HasFailures {
+		title.Text = "❌ Policies failed (needs action)"
+		title.Color = "Attention"
+	}
+	container.Items = append(container.Items, title)
+
+	for _, check := range policyOutput.Checks {
+		if !check.Failure && !check.Warning {
+			continue
+		}
+
+		name := fmt.Sprintf("⚠️ **%s** (warning)", check.Name)
+		if check.Failure {
+			name = fmt.Sprintf("❌ **%s** (needs action)", check.Name)
+		}
+		container.Items = append(container.Items, teamsTextBlock(name))
+
+		if check.Message != "" {
+			container.Items = append(container.Items, teamsTextBlock(check.Message))
+		}
+
+		for _, detail := range check.Details {
+			container.Items = append(container.Items, teamsTextBlock(detail))
+		}
+
+		for _, resource := range check.ResourceDetails {
+			var lines []string
+
+			address := fmt.Sprintf("**%s**", resource.Address)
+			if resource.Path != "" {
+				address += " at " + resource.Path
+				if resource.Line > 0 {
+					address += fmt.Sprintf(":%d", resource.Line)
+				}
+			}
+			lines = append(lines, address)
+
+			for _, violation := range resource.Violations {
+				for _, detail := range violation.Details {
+					lines = append(lines, "- "+detail)
+				}
+			}
+
+			container.Items = append(container.Items, teamsTextBlock(strings.Join(lines, "\n")))
+		}
+
+		if check.TruncatedCount > 0 {
+			container.Items = append(container.Items, teamsTextBlock(fmt.Sprintf("... and %d more.", check.TruncatedCount)))
+		}
+	}
+
+	return container
+}
+
+// ToTeamsMessage returns a Microsoft Teams incoming webhook message with an
+// Adaptive Card that summarizes the cost changes of the projects.
+func ToTeamsMessage(out Root, opts Options) ([]byte, error) {
+	body := []AdaptiveCardElement{
+		{
+			Type:   "TextBlock",
+			Text:   "Infracost report",
+			Weight: "Bolder",
+			Size:   "Medium",
+			Wrap:   true,
+		},
+		teamsTextBlock(fmt.Sprintf("💰 Infracost estimate: **%s**", formatCostChangeSentence(out.Currency, out.PastTotalMonthlyCost, out.TotalMonthlyCost, true))),
+	}
+
+	table, hiddenProjectCount := teamsProjectsTable(out, opts)
+	body = append(body, table)
+
+	if hiddenProjectCount == 1 {
+		body = append(body, teamsTextBlock("1 project has no cost estimate changes."))
+	} else if hiddenProjectCount > 0 {
+		body = append(body, teamsTextBlock(fmt.Sprintf("%d projects have no cost estimate changes.", hiddenProjectCount)))
+	}
+
+	if out.TotalListMonthlyCost != nil {
+		body = append(body, teamsTextBlock(fmt.Sprintf("Pricing overrides were applied, the new monthly cost at list prices is %s.", formatCost(out.Currency, out.TotalListMonthlyCost))))
+	}
+
+	if summary := out.summaryMessage(opts.ShowSkipped); summary != "" {
+		summaryBlock := teamsTextBlock(strings.ReplaceAll(summary, "\n", "\n\n"))
+		summaryBlock.IsSubtle = true
+		summaryBlock.Separator = true
+		body = append(body, summaryBlock)
+	}
+
+	if len(opts.PolicyOutput.Checks) > 0 {
+		body = append(body, teamsPolicyContainer(opts.PolicyOutput))
+	}
+
+	card := AdaptiveCard{
+		Schema:  adaptiveCardSchema,
+		Type:    "AdaptiveCard",
+		Version: adaptiveCardVersion,
+		MSTeams: map[string]string{"width": "Full"},
+		Body:    body,
+	}
+
+	if out.ShareURL != "" {
+		card.Actions = append(card.Actions, AdaptiveCardAction{
+			Type:  "Action.OpenUrl",
+			Title: "View cost estimate",
+			URL:   out.ShareURL,
+		})
+	}
+
+	msg := TeamsMessage{
+		Type: "message",
+		Attachments: []TeamsAttachment{
+			{
+				ContentType: adaptiveCardContentType,
+				Content:     card,
+			},
+		},
+	}
+
+	return json.Marshal(msg)
+}
@@ -0,0 +1,90 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestToTeamsMessage(t *testing.T) {
+	project := func(name string, pastCost, cost int64) Project {
+		return Project{
+			Name:          name,
+			Metadata:      &schema.ProjectMetadata{Path: name},
+			PastBreakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(pastCost))},
+			Breakdown:     &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(cost))},
+			Diff: &Breakdown{
+				TotalMonthlyCost: decimalPtr(decimal.NewFromInt(cost - pastCost)),
+				Resources:        []Resource{{Name: "aws_instance.web"}},
+			},
+		}
+	}
+
+	unchanged := project("unchanged", 10, 10)
+	unchanged.Diff.Resources = nil
+
+	out := Root{
+		Currency:             "USD",
+		ShareURL:             "https://dashboard.infracost.io/share/1234",
+		PastTotalMonthlyCost: decimalPtr(decimal.NewFromInt(110)),
+		TotalMonthlyCost:     decimalPtr(decimal.NewFromInt(160)),
+		Projects:             []Project{project("changed", 100, 150), unchanged},
+	}
+
+	opts := Options{
+		PolicyOutput: PolicyOutput{
+			HasFailures: true,
+			Checks: []PolicyCheckOutput{
+				{Name: "Cost policy", Failure: true, Details: []string{"Monthly cost is too high"}},
+			},
+		},
+	}
+
+	b, err := ToTeamsMessage(out, opts)
+	require.NoError(t, err)
+
+	var msg TeamsMessage
+	require.NoError(t, json.Unmarshal(b, &msg))
+	require.Len(t, msg.Attachments, 1)
+	assert.Equal(t, "application/vnd.microsoft.card.adaptive", msg.Attachments[0].ContentType)
+
+	card := msg.Attachments[0].Content
+	assert.Equal(t, "AdaptiveCard", card.Type)
+	assert.Equal(t, "💰 Infracost estimate: **Monthly cost will increase by $50 📈**", card.Body[1].Text)
+
+	table := card.Body[2]
+	assert.Equal(t, "Table", table.Type)
+	require.Len(t, table.Rows, 3)
+	assert.Equal(t, []string{"changed", "$100", "$150", "+$50 (+50%)"}, teamsRowText(table.Rows[1]))
+	assert.Equal(t, []string{"All projects", "$110", "$160", "+$50 (+45%)"}, teamsRowText(table.Rows[2]))
+
+	assert.Equal(t, "1 project has no cost estimate changes.", card.Body[3].Text)
+
+	policies := card.Body[4]
+	assert.Equal(t, "Container", policies.Type)
+	assert.Equal(t, "❌ Policies failed (needs action)", policies.Items[0].Text)
+	assert.Equal(t, "❌ **Cost policy** (needs action)", policies.Items[1].Text)
+	assert.Equal(t, "Monthly cost is too high", policies.Items[2].Text)
+
+	require.Len(t, card.Actions, 1)
+	assert.Equal(t, out.ShareURL, card.Actions[0].URL)
+
+	opts.ShowAllProjects = true
+	b, err = ToTeamsMessage(out, opts)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &msg))
+	assert.Len(t, msg.Attachments[0].Content.Body[2].Rows, 4)
+}
+
+func teamsRowText(row AdaptiveCardRow) []string {
+	var text []string
+	for _, cell := range row.Cells {
+		text = append(text, cell.Items[0].Text)
+	}
+	return text
+}
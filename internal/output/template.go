@@ -0,0 +1,122 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// TemplateCtx holds the information that is available to a user-supplied
+// template, e.g. {{ .Root.TotalMonthlyCost }}.
+type TemplateCtx struct {
+	Root             Root
+	SummaryMessage   string
+	DiffOutput       string
+	Options          Options
+	RunQuotaExceeded bool
+	RunQuotaMsg      string
+}
+
+// templateFuncs returns the helper functions that are shared by the built-in
+// templates and user-supplied templates.
+func templateFuncs(out Root) map[string]interface{} {
+	return map[string]interface{}{
+		"stripColor": ui.StripColor,
+		"contains":   contains,
+		"hasCost": func(cc []CostComponent, sr []Resource, resourceName string) bool {
+			if len(cc) > 0 || len(sr) > 0 {
+				return true
+			}
+
+			log.Info().Msgf("Hiding resource with no usage: %s", resourceName)
+			return false
+		},
+		"filterZeroValComponents": filterZeroValComponents,
+		"filterZeroValResources":  filterZeroValResources,
+		"formatCost2DP":           func(d *decimal.Decimal) string { return FormatCost2DP(out.Currency, d) },
+		"formatPrice":             func(d decimal.Decimal) string { return formatPrice(out.Currency, d) },
+		"formatTitleWithCurrency": func(title string) string { return formatTitleWithCurrency(title, out.Currency) },
+		"formatQuantity":          formatQuantity,
+		"projectLabel": func(p Project) string {
+			return p.Label()
+		},
+		"projectModulePath": func(p Project) string {
+			return p.Metadata.TerraformModulePath
+		},
+		"projectWorkspace": func(p Project) string {
+			return p.Metadata.WorkspaceLabel()
+		},
+	}
+}
+
+// ToTemplate renders the output through the Go template at opts.TemplatePath.
+// The template has access to the sprig functions, the helper functions used by
+// the built-in HTML and markdown templates and the fields of TemplateCtx.
+func ToTemplate(out Root, opts Options) ([]byte, error) {
+	if opts.TemplatePath == "" {
+		return nil, fmt.Errorf("--template-path is required when using the template format")
+	}
+
+	src, err := os.ReadFile(opts.TemplatePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", opts.TemplatePath, err)
+	}
+
+	tmpl := template.New(filepath.Base(opts.TemplatePath))
+	tmpl.Funcs(sprig.TxtFuncMap())
+	tmpl.Funcs(templateFuncs(out))
+	tmpl.Funcs(template.FuncMap{
+		"formatCost": func(d *decimal.Decimal) string {
+			if d == nil || d.IsZero() {
+				return formatWholeDecimalCurrency(out.Currency, decimal.Zero)
+			}
+			return formatCost(out.Currency, d)
+		},
+		"formatCostChange": func(pastCost, cost *decimal.Decimal) string {
+			return formatMarkdownCostChange(out.Currency, pastCost, cost, false)
+		},
+		"formatCostChangeSentence": formatCostChangeSentence,
+		"stringsJoin":              strings.Join,
+		"truncateMiddle":           truncateMiddle,
+	})
+
+	_, err = tmpl.Parse(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", opts.TemplatePath, err)
+	}
+
+	diff, err := ToDiff(out, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate diff: %w", err)
+	}
+
+	runQuotaMsg, exceeded := out.Projects.IsRunQuotaExceeded()
+
+	var buf bytes.Buffer
+	bufw := bufio.NewWriter(&buf)
+
+	err = tmpl.Execute(bufw, TemplateCtx{
+		Root:             out,
+		SummaryMessage:   out.summaryMessage(opts.ShowSkipped),
+		DiffOutput:       ui.StripColor(string(diff)),
+		Options:          opts,
+		RunQuotaExceeded: exceeded,
+		RunQuotaMsg:      runQuotaMsg,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bufw.Flush()
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,68 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestToTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	err := os.WriteFile(path, []byte(`{{- range .Root.Projects }}{{ projectLabel . }}: {{ formatCost2DP .Breakdown.TotalMonthlyCost }}
+{{- range filterZeroValResources .Breakdown.Resources "" }}
+- {{ .Name }} {{ formatCost .MonthlyCost }}
+{{- end }}
+{{ end -}}
+{{ .Root.Currency | lower }}`), 0600)
+	require.NoError(t, err)
+
+	out := Root{
+		Currency: "EUR",
+		Projects: []Project{
+			{
+				Name:     "proj",
+				Metadata: &schema.ProjectMetadata{Path: "infra"},
+				Breakdown: &Breakdown{
+					TotalMonthlyCost: decimalPtr(decimal.NewFromFloat(12.5)),
+					Resources: []Resource{
+						{
+							Name:        "aws_instance.web",
+							MonthlyCost: decimalPtr(decimal.NewFromFloat(12.5)),
+							CostComponents: []CostComponent{
+								{Name: "Instance usage", MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)), MonthlyCost: decimalPtr(decimal.NewFromFloat(12.5))},
+							},
+						},
+						{
+							Name:           "aws_instance.free",
+							CostComponents: []CostComponent{{Name: "Instance usage", MonthlyQuantity: decimalPtr(decimal.Zero)}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	b, err := ToTemplate(out, Options{TemplatePath: path})
+	require.NoError(t, err)
+	assert.Equal(t, "proj: €12.50\n- aws_instance.web €13\neur", string(b))
+}
+
+func TestToTemplateErrors(t *testing.T) {
+	_, err := ToTemplate(Root{}, Options{})
+	assert.ErrorContains(t, err, "--template-path is required")
+
+	_, err = ToTemplate(Root{}, Options{TemplatePath: filepath.Join(t.TempDir(), "missing.tmpl")})
+	assert.ErrorContains(t, err, "failed to read template")
+
+	path := filepath.Join(t.TempDir(), "invalid.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte("{{ .Root.Currency "), 0600))
+
+	_, err = ToTemplate(Root{}, Options{TemplatePath: path})
+	assert.ErrorContains(t, err, "failed to parse template")
+}
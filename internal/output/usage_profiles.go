@@ -0,0 +1,297 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// MonthlyCostRange is the range of monthly costs of a resource or project for
+// the usage profiles in the usage file.
+type MonthlyCostRange struct {
+	Min *decimal.Decimal `json:"min"`
+	// Expected is the monthly cost with the expected usage, which is the cost
+	// shown in the rest of the output.
+	Expected *decimal.Decimal `json:"expected"`
+	Max      *decimal.Decimal `json:"max"`
+	// Profiles are the monthly costs for each usage profile in the order they
+	// are defined in the usage file.
+	Profiles []UsageProfileCost `json:"profiles"`
+}
+
+// UsageProfileCost is the monthly cost with the usage of a usage profile.
+type UsageProfileCost struct {
+	Name        string           `json:"name"`
+	MonthlyCost *decimal.Decimal `json:"monthlyCost"`
+}
+
+// newMonthlyCostRange returns the range of the monthly costs of the usage
+// profiles. Profiles without a monthly cost are ignored for the min and max.
+func newMonthlyCostRange(profiles []UsageProfileCost) *MonthlyCostRange {
+	r := &MonthlyCostRange{Profiles: profiles}
+
+	for _, p := range profiles {
+		if p.Name == schema.ExpectedUsageProfile {
+			r.Expected = p.MonthlyCost
+		}
+
+		if p.MonthlyCost == nil {
+			continue
+		}
+
+		if r.Min == nil || p.MonthlyCost.LessThan(*r.Min) {
+			r.Min = p.MonthlyCost
+		}
+		if r.Max == nil || p.MonthlyCost.GreaterThan(*r.Max) {
+			r.Max = p.MonthlyCost
+		}
+	}
+
+	return r
+}
+
+// profileCost returns the monthly cost of the usage profile with the given
+// name, or the expected monthly cost if the range doesn't include the profile.
+func (r *MonthlyCostRange) profileCost(name string) *decimal.Decimal {
+	for _, p := range r.Profiles {
+		if p.Name == name {
+			return p.MonthlyCost
+		}
+	}
+
+	return r.Expected
+}
+
+// hasRange returns true if the monthly cost is different for any of the
+// usage profiles.
+func (r *MonthlyCostRange) hasRange() bool {
+	if r.Min == nil || r.Max == nil {
+		return false
+	}
+
+	return !r.Min.Equal(*r.Max)
+}
+
+// addMonthlyCostRanges sets the monthly cost ranges of the breakdown and its
+// resources from the resources built with the usage of each usage profile.
+func addMonthlyCostRanges(breakdown *Breakdown, profiles []*schema.UsageProfile) {
+	if breakdown == nil || len(profiles) == 0 {
+		return
+	}
+
+	profileResources := make([]map[string]*schema.Resource, len(profiles))
+	totals := make([]UsageProfileCost, len(profiles))
+
+	for i, p := range profiles {
+		profileResources[i] = make(map[string]*schema.Resource, len(p.Resources))
+		total := decimal.Zero
+
+		for _, r := range p.Resources {
+			profileResources[i][r.Name] = r
+
+			if !r.IsSkipped && r.MonthlyCost != nil {
+				total = total.Add(*r.MonthlyCost)
+			}
+		}
+
+		totals[i] = UsageProfileCost{Name: p.Name, MonthlyCost: decimalPtr(total)}
+	}
+
+	for i := range breakdown.Resources {
+		costs := make([]UsageProfileCost, len(profiles))
+		for j, p := range profiles {
+			costs[j] = UsageProfileCost{Name: p.Name}
+
+			if r, ok := profileResources[j][breakdown.Resources[i].Name]; ok && !r.IsSkipped {
+				costs[j].MonthlyCost = r.MonthlyCost
+			}
+		}
+
+		breakdown.Resources[i].MonthlyCostRange = newMonthlyCostRange(costs)
+	}
+
+	breakdown.MonthlyCostRange = newMonthlyCostRange(totals)
+}
+
+// usageProfileNames returns the names of the usage profiles of the projects in
+// the order they are first defined.
+func usageProfileNames(projects []Project) []string {
+	var names []string
+	seen := make(map[string]bool)
+
+	for _, p := range projects {
+		if p.Breakdown == nil || p.Breakdown.MonthlyCostRange == nil {
+			continue
+		}
+
+		for _, c := range p.Breakdown.MonthlyCostRange.Profiles {
+			if !seen[c.Name] {
+				seen[c.Name] = true
+				names = append(names, c.Name)
+			}
+		}
+	}
+
+	return names
+}
+
+// totalMonthlyCostRange returns the range of the total monthly cost of the
+// projects for each usage profile, or nil if none of the projects have usage
+// profiles. Projects without usage profiles contribute their total monthly
+// cost to each usage profile.
+func totalMonthlyCostRange(projects []Project) *MonthlyCostRange {
+	names := usageProfileNames(projects)
+	if len(names) == 0 {
+		return nil
+	}
+
+	totals := make([]UsageProfileCost, len(names))
+	for i, name := range names {
+		total := decimal.Zero
+
+		for _, p := range projects {
+			if p.Breakdown == nil {
+				continue
+			}
+
+			cost := p.Breakdown.TotalMonthlyCost
+			if p.Breakdown.MonthlyCostRange != nil {
+				cost = p.Breakdown.MonthlyCostRange.profileCost(name)
+			}
+
+			if cost != nil {
+				total = total.Add(*cost)
+			}
+		}
+
+		totals[i] = UsageProfileCost{Name: name, MonthlyCost: decimalPtr(total)}
+	}
+
+	return newMonthlyCostRange(totals)
+}
+
+// copyMonthlyCostRanges copies the monthly cost ranges of the breakdown and its
+// resources from another breakdown of the same project.
+func copyMonthlyCostRanges(dst *Breakdown, src *Breakdown) {
+	if dst == nil || src == nil || src.MonthlyCostRange == nil {
+		return
+	}
+
+	dst.MonthlyCostRange = src.MonthlyCostRange
+
+	ranges := make(map[string]*MonthlyCostRange, len(src.Resources))
+	for _, r := range src.Resources {
+		ranges[r.Name] = r.MonthlyCostRange
+	}
+
+	for i := range dst.Resources {
+		dst.Resources[i].MonthlyCostRange = ranges[dst.Resources[i].Name]
+	}
+}
+
+// usageProfileRow is a row of the usage profiles table with the monthly costs
+// in the same order as the usage profile names of the output.
+type usageProfileRow struct {
+	Name      string
+	IsProject bool
+	Costs     []*decimal.Decimal
+}
+
+// usageProfileRows returns a row for each project with usage profiles,
+// followed by a row for each of its resources whose monthly cost depends on
+// the usage profile.
+func (r *Root) usageProfileRows() []usageProfileRow {
+	names := usageProfileNames(r.Projects)
+
+	var rows []usageProfileRow
+	for _, p := range r.Projects {
+		if p.Breakdown == nil || p.Breakdown.MonthlyCostRange == nil {
+			continue
+		}
+
+		rows = append(rows, usageProfileRow{
+			Name:      p.Label(),
+			IsProject: true,
+			Costs:     usageProfileCosts(p.Breakdown.MonthlyCostRange, names),
+		})
+
+		for _, res := range p.Breakdown.Resources {
+			if res.MonthlyCostRange == nil || !res.MonthlyCostRange.hasRange() {
+				continue
+			}
+
+			rows = append(rows, usageProfileRow{
+				Name:  res.Name,
+				Costs: usageProfileCosts(res.MonthlyCostRange, names),
+			})
+		}
+	}
+
+	return rows
+}
+
+func (r *Root) usageProfileNames() []string {
+	return usageProfileNames(r.Projects)
+}
+
+func usageProfileCosts(r *MonthlyCostRange, names []string) []*decimal.Decimal {
+	costs := make([]*decimal.Decimal, len(names))
+	for i, name := range names {
+		costs[i] = r.profileCost(name)
+	}
+
+	return costs
+}
+
+// usageProfilesText renders the monthly costs of the usage profiles for the
+// table and diff formats.
+func usageProfilesText(out Root) string {
+	if out.MonthlyCostRange == nil {
+		return ""
+	}
+
+	names := out.usageProfileNames()
+
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	headers := table.Row{ui.UnderlineString("Name")}
+	columns := []table.ColumnConfig{{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft}}
+	for i, name := range names {
+		headers = append(headers, ui.UnderlineString(name))
+		columns = append(columns, table.ColumnConfig{Number: i + 2, Align: text.AlignRight, AlignHeader: text.AlignRight})
+	}
+	t.AppendHeader(headers)
+	t.SetColumnConfigs(columns)
+
+	for _, row := range out.usageProfileRows() {
+		name := "  " + row.Name
+		if row.IsProject {
+			name = ui.BoldString(row.Name)
+		}
+
+		tableRow := table.Row{name}
+		for _, c := range row.Costs {
+			tableRow = append(tableRow, FormatCost2DP(out.Currency, c))
+		}
+		t.AppendRow(tableRow)
+	}
+
+	s := "\n──────────────────────────────────\n"
+	s += ui.BoldString(fmt.Sprintf("Monthly cost by usage profile, %s to %s",
+		FormatCost2DP(out.Currency, out.MonthlyCostRange.Min),
+		FormatCost2DP(out.Currency, out.MonthlyCostRange.Max),
+	))
+	s += "\n\n" + t.Render()
+
+	return s
+}
@@ -0,0 +1,101 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func usageProfileTestResources(lambdaCost, bucketCost int64) []*schema.Resource {
+	return []*schema.Resource{
+		{Name: "aws_lambda_function.api", MonthlyCost: decimalPtr(decimal.NewFromInt(lambdaCost))},
+		{Name: "aws_s3_bucket.logs", MonthlyCost: decimalPtr(decimal.NewFromInt(bucketCost))},
+		{Name: "aws_iam_role.api", IsSkipped: true},
+	}
+}
+
+func TestAddMonthlyCostRanges(t *testing.T) {
+	breakdown := &Breakdown{Resources: []Resource{
+		{Name: "aws_lambda_function.api", MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+		{Name: "aws_s3_bucket.logs", MonthlyCost: decimalPtr(decimal.NewFromInt(5))},
+	}}
+
+	addMonthlyCostRanges(breakdown, nil)
+	assert.Nil(t, breakdown.MonthlyCostRange)
+
+	addMonthlyCostRanges(breakdown, []*schema.UsageProfile{
+		{Name: "low", Resources: usageProfileTestResources(1, 5)},
+		{Name: "expected", Resources: usageProfileTestResources(10, 5)},
+		{Name: "peak", Resources: usageProfileTestResources(100, 5)},
+	})
+
+	require.NotNil(t, breakdown.MonthlyCostRange)
+	assert.Equal(t, "6", breakdown.MonthlyCostRange.Min.String())
+	assert.Equal(t, "15", breakdown.MonthlyCostRange.Expected.String())
+	assert.Equal(t, "105", breakdown.MonthlyCostRange.Max.String())
+
+	lambda := breakdown.Resources[0].MonthlyCostRange
+	assert.Equal(t, "1", lambda.Min.String())
+	assert.Equal(t, "10", lambda.Expected.String())
+	assert.Equal(t, "100", lambda.Max.String())
+	assert.True(t, lambda.hasRange())
+	assert.False(t, breakdown.Resources[1].MonthlyCostRange.hasRange())
+}
+
+func TestTotalMonthlyCostRange(t *testing.T) {
+	assert.Nil(t, totalMonthlyCostRange([]Project{{Name: "a", Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(1))}}}))
+
+	total := totalMonthlyCostRange([]Project{
+		{Name: "a", Breakdown: &Breakdown{
+			TotalMonthlyCost: decimalPtr(decimal.NewFromInt(15)),
+			MonthlyCostRange: newMonthlyCostRange([]UsageProfileCost{
+				{Name: "expected", MonthlyCost: decimalPtr(decimal.NewFromInt(15))},
+				{Name: "peak", MonthlyCost: decimalPtr(decimal.NewFromInt(105))},
+			}),
+		}},
+		{Name: "b", Breakdown: &Breakdown{
+			TotalMonthlyCost: decimalPtr(decimal.NewFromInt(20)),
+			MonthlyCostRange: newMonthlyCostRange([]UsageProfileCost{
+				{Name: "low", MonthlyCost: decimalPtr(decimal.NewFromInt(2))},
+				{Name: "expected", MonthlyCost: decimalPtr(decimal.NewFromInt(20))},
+			}),
+		}},
+		{Name: "c", Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(100))}},
+	})
+
+	assert.Equal(t, []UsageProfileCost{
+		{Name: "expected", MonthlyCost: decimalPtr(decimal.NewFromInt(135))},
+		{Name: "peak", MonthlyCost: decimalPtr(decimal.NewFromInt(225))},
+		{Name: "low", MonthlyCost: decimalPtr(decimal.NewFromInt(117))},
+	}, total.Profiles)
+	assert.Equal(t, "117", total.Min.String())
+	assert.Equal(t, "135", total.Expected.String())
+	assert.Equal(t, "225", total.Max.String())
+}
+
+func TestUsageProfilesText(t *testing.T) {
+	assert.Equal(t, "", usageProfilesText(Root{Projects: []Project{{Name: "a"}}}))
+
+	breakdown := &Breakdown{Resources: []Resource{
+		{Name: "aws_lambda_function.api", MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+		{Name: "aws_s3_bucket.logs", MonthlyCost: decimalPtr(decimal.NewFromInt(5))},
+	}}
+	addMonthlyCostRanges(breakdown, []*schema.UsageProfile{
+		{Name: "low", Resources: usageProfileTestResources(1, 5)},
+		{Name: "expected", Resources: usageProfileTestResources(10, 5)},
+	})
+
+	out := Root{Currency: "USD", Projects: []Project{{Name: "a", Breakdown: breakdown}}}
+	out.MonthlyCostRange = totalMonthlyCostRange(out.Projects)
+
+	assert.Equal(t, "\n──────────────────────────────────\nMonthly cost by usage profile, $6.00 to $15.00\n\n"+
+		" Name                         low  expected \n"+
+		" a                          $6.00    $15.00 \n"+
+		"   aws_lambda_function.api  $1.00    $10.00 ",
+		ui.StripColor(usageProfilesText(out)))
+}
@@ -0,0 +1,181 @@
+// Package policy evaluates Rego policies against the output of Infracost runs
+// and the planned resources, so cost and resource policies can be enforced
+// without Infracost Cloud.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const (
+	// denyRule is the name of the rules that fail the policy check.
+	denyRule = "deny"
+	// warnRule is the name of the rules that add a warning to the policy check
+	// without failing it.
+	warnRule = "warn"
+)
+
+// Input is the input document of the policies. It is the Infracost JSON
+// output with the planned resources added under the resources key.
+type Input struct {
+	output.Root
+	// Resources are the planned resources with their attributes. These are only
+	// available when policies are evaluated as part of a run, since they are not
+	// included in the Infracost JSON output.
+	Resources []Resource `json:"resources"`
+}
+
+// Resource is a planned resource and its attributes.
+type Resource struct {
+	Address string            `json:"address"`
+	Type    string            `json:"type"`
+	Project string            `json:"project"`
+	Tags    map[string]string `json:"tags"`
+	// Values are the planned attributes of the resource, e.g.
+	// {"instance_type": "m5.large", "root_block_device": [{"volume_type": "gp2"}]}.
+	Values interface{} `json:"values"`
+	// References contains the addresses of the resources referenced by each
+	// attribute of the resource.
+	References map[string][]string `json:"references"`
+}
+
+// NewInput creates the input for the policies from the output of a run and
+// the projects the output was created from. projects can be nil if the output
+// was loaded from Infracost JSON.
+func NewInput(out output.Root, projects []*schema.Project) Input {
+	input := Input{
+		Root:      out,
+		Resources: []Resource{},
+	}
+
+	for _, project := range projects {
+		for _, partial := range project.PartialResources {
+			r := Resource{
+				Address:    partial.Address,
+				Type:       partial.Type,
+				Project:    project.Name,
+				Tags:       map[string]string{},
+				Values:     partial.RawValues.Value(),
+				References: partial.References,
+			}
+
+			if partial.Tags != nil {
+				r.Tags = *partial.Tags
+			}
+
+			if r.References == nil {
+				r.References = map[string][]string{}
+			}
+
+			input.Resources = append(input.Resources, r)
+		}
+	}
+
+	return input
+}
+
+// Evaluate evaluates the deny and warn rules of the infracost package in the
+// policy files at paths against the input. Each rule returns either a message
+// that is reported as a failure or warning, or an object of the form
+// {"msg": string, "failed": bool} so passing rules can also be reported.
+func Evaluate(ctx context.Context, paths []string, input Input) (output.PolicyCheck, error) {
+	checks := output.PolicyCheck{
+		Enabled: true,
+	}
+
+	inputValue, err := ast.InterfaceToValue(input)
+	if err != nil {
+		return checks, fmt.Errorf("Unable to process Infracost output into Rego input: %s", err.Error())
+	}
+
+	r := rego.New(
+		rego.Query("data.infracost"),
+		rego.ParsedInput(inputValue),
+		rego.Load(paths, func(abspath string, info os.FileInfo, depth int) bool {
+			return false
+		}),
+	)
+	pq, err := r.PrepareForEval(ctx)
+	if err != nil {
+		return checks, fmt.Errorf("Unable to query provided policies: %s", err.Error())
+	}
+
+	res, err := pq.Eval(ctx)
+	if err != nil {
+		return checks, err
+	}
+
+	var rules map[string]interface{}
+	if len(res) > 0 && len(res[0].Expressions) > 0 {
+		rules, _ = res[0].Expressions[0].Value.(map[string]interface{})
+	}
+
+	_, hasDeny := rules[denyRule]
+	_, hasWarn := rules[warnRule]
+	if !hasDeny && !hasWarn {
+		return checks, fmt.Errorf("The provided policies returned no valid data.infracost.deny or data.infracost.warn rules. Please check that the policies are formatted correctly.")
+	}
+
+	readRule(rules[denyRule], false, &checks)
+	readRule(rules[warnRule], true, &checks)
+
+	return checks, nil
+}
+
+// readRule reads the results of a deny or warn rule into checks. A rule can
+// be a set of results, or a single result if it is a complete rule.
+func readRule(v interface{}, warn bool, checks *output.PolicyCheck) {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, result := range v {
+			readResult(result, warn, checks)
+		}
+	case nil:
+	default:
+		readResult(v, warn, checks)
+	}
+}
+
+func readResult(v interface{}, warn bool, checks *output.PolicyCheck) {
+	switch v := v.(type) {
+	case string:
+		addFailed(v, warn, checks)
+	case map[string]interface{}:
+		msg, ok := v["msg"].(string)
+		if !ok {
+			checks.Failures = append(checks.Failures, "Policy rule invalid as it did not contain {msg: string} property in output object. Please edit rule output object.")
+			return
+		}
+
+		if _, ok := v["failed"]; !ok {
+			checks.Failures = append(checks.Failures, fmt.Sprintf("Policy rule: [%s] did not contain {failed: bool} output property. Please edit rule output object.", msg))
+			return
+		}
+
+		if isFailed, _ := v["failed"].(bool); isFailed {
+			addFailed(msg, warn, checks)
+			return
+		}
+
+		checks.Passed = append(checks.Passed, msg)
+	default:
+		checks.Failures = append(checks.Failures, fmt.Sprintf("Policy rule returned an invalid result %v. Rules must return a string or an object with {msg: string, failed: bool} properties.", v))
+	}
+}
+
+func addFailed(msg string, warn bool, checks *output.PolicyCheck) {
+	if warn {
+		checks.Warnings = append(checks.Warnings, msg)
+		return
+	}
+
+	checks.Failures = append(checks.Failures, msg)
+}
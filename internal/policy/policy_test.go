@@ -0,0 +1,100 @@
+package policy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func testInput() Input {
+	diff := decimal.NewFromInt(150)
+
+	return NewInput(output.Root{DiffTotalMonthlyCost: &diff}, []*schema.Project{
+		{
+			Name: "infracost/infracost/prod",
+			PartialResources: []*schema.PartialResource{
+				{
+					Address:   "aws_ebs_volume.gp2",
+					Type:      "aws_ebs_volume",
+					Tags:      &map[string]string{"team": "platform"},
+					RawValues: gjson.Parse(`{"type": "gp2", "size": 100}`),
+				},
+				{
+					Address:    "aws_instance.web",
+					Type:       "aws_instance",
+					RawValues:  gjson.Parse(`{"instance_type": "m5.large"}`),
+					References: map[string][]string{"subnet_id": {"aws_subnet.private"}},
+				},
+			},
+		},
+	})
+}
+
+func TestEvaluate(t *testing.T) {
+	checks, err := Evaluate(context.Background(), []string{"./testdata/policy.rego"}, testInput())
+	require.NoError(t, err)
+
+	assert.True(t, checks.Enabled)
+	assert.ElementsMatch(t, []string{
+		"Total monthly cost diff must be less than $100.00 (actual diff is $150)",
+		"aws_ebs_volume.gp2 uses gp2, use gp3 volumes instead",
+	}, []string(checks.Failures))
+	assert.ElementsMatch(t, []string{
+		"aws_instance.web is missing the team tag",
+		"aws_instance.web references aws_subnet.private",
+	}, checks.Warnings)
+	assert.Empty(t, checks.Passed)
+}
+
+func TestEvaluatePassed(t *testing.T) {
+	diff := decimal.NewFromInt(50)
+
+	checks, err := Evaluate(context.Background(), []string{"./testdata/policy.rego"}, NewInput(output.Root{DiffTotalMonthlyCost: &diff}, nil))
+	require.NoError(t, err)
+
+	assert.Empty(t, checks.Failures)
+	assert.Empty(t, checks.Warnings)
+	assert.Equal(t, []string{"Total monthly cost diff must be less than $100.00 (actual diff is $50)"}, checks.Passed)
+}
+
+func TestEvaluateNoRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.rego")
+	require.NoError(t, os.WriteFile(path, []byte("package infracost\n\nallow = true\n"), 0600))
+
+	_, err := Evaluate(context.Background(), []string{path}, testInput())
+	assert.EqualError(t, err, "The provided policies returned no valid data.infracost.deny or data.infracost.warn rules. Please check that the policies are formatted correctly.")
+}
+
+func TestEvaluateInvalidResult(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.rego")
+	require.NoError(t, os.WriteFile(path, []byte("package infracost\n\ndeny[out] {\n\tout := {\"msg\": \"missing failed\"}\n}\n"), 0600))
+
+	checks, err := Evaluate(context.Background(), []string{path}, testInput())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Policy rule: [missing failed] did not contain {failed: bool} output property. Please edit rule output object."}, []string(checks.Failures))
+}
+
+func TestNewInput(t *testing.T) {
+	input := testInput()
+
+	require.Len(t, input.Resources, 2)
+	assert.Equal(t, Resource{
+		Address:    "aws_ebs_volume.gp2",
+		Type:       "aws_ebs_volume",
+		Project:    "infracost/infracost/prod",
+		Tags:       map[string]string{"team": "platform"},
+		Values:     map[string]interface{}{"type": "gp2", "size": float64(100)},
+		References: map[string][]string{},
+	}, input.Resources[0])
+	assert.Equal(t, map[string]string{}, input.Resources[1].Tags)
+}
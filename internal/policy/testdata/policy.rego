@@ -0,0 +1,37 @@
+package infracost
+
+deny[out] {
+	maxDiff := 100.0
+
+	msg := sprintf(
+		"Total monthly cost diff must be less than $%.2f (actual diff is $%v)",
+		[maxDiff, to_number(input.diffTotalMonthlyCost)],
+	)
+
+	out := {
+		"msg": msg,
+		"failed": to_number(input.diffTotalMonthlyCost) >= maxDiff,
+	}
+}
+
+deny[msg] {
+	r := input.resources[_]
+	r.type == "aws_ebs_volume"
+	r.values.type == "gp2"
+
+	msg := sprintf("%s uses gp2, use gp3 volumes instead", [r.address])
+}
+
+warn[msg] {
+	r := input.resources[_]
+	not r.tags.team
+
+	msg := sprintf("%s is missing the team tag", [r.address])
+}
+
+warn[msg] {
+	r := input.resources[_]
+	count(r.references.subnet_id) > 0
+
+	msg := sprintf("%s references %s", [r.address, r.references.subnet_id[0]])
+}
@@ -0,0 +1,70 @@
+package prices
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// PopulateAlternativePrices populates the prices of resources that are not
+// part of a project, e.g. the alternatives suggested by recommendations.
+// Unlike PopulatePrices, missing prices are not logged as warnings since an
+// alternative might not be available in every region. Cost components without
+// a price are left without a price hash so callers can check for them.
+func PopulateAlternativePrices(ctx *config.RunContext, source PriceSource, resources []*schema.Resource) error {
+	c := currency(ctx)
+
+	keys := apiclient.PriceQueryKeys(resources)
+	for i := 0; i < len(keys); i += batchSize {
+		end := i + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		results, err := source.GetPrices(keys[i:end])
+		if err != nil {
+			return err
+		}
+
+		for _, r := range results {
+			setAlternativePrice(c, r.Resource, r.CostComponent, r.Result)
+		}
+	}
+
+	applyPricingOverrides(ctx.Config.PricingOverrides, resources)
+
+	return nil
+}
+
+func setAlternativePrice(currency string, r *schema.Resource, c *schema.CostComponent, res gjson.Result) {
+	if c.CustomPrice() != nil {
+		c.SetPrice(*c.CustomPrice())
+		return
+	}
+
+	for _, product := range res.Get("data.products").Array() {
+		prices := product.Get("prices").Array()
+		if len(prices) == 0 {
+			continue
+		}
+
+		p, err := decimal.NewFromString(prices[0].Get(currency).String())
+		if err != nil {
+			break
+		}
+
+		c.SetPrice(p)
+		c.SetPriceHash(prices[0].Get("priceHash").String())
+		return
+	}
+
+	if c.IgnoreIfMissingPrice {
+		r.RemoveCostComponent(c)
+		return
+	}
+
+	c.SetPrice(decimal.Zero)
+}
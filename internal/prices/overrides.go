@@ -0,0 +1,68 @@
+package prices
+
+import (
+	"github.com/rs/zerolog/log"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// applyPricingOverrides applies the pricing overrides from the config file to
+// the prices of the cost components. The list price is kept on the cost
+// component so that the output can show both the list and effective price.
+func applyPricingOverrides(overrides config.PricingOverrides, resources []*schema.Resource) {
+	if len(overrides) == 0 {
+		return
+	}
+
+	for _, r := range resources {
+		// Sub resources don't have a resource type, so match them using the
+		// resource type of their parent.
+		for _, c := range r.CostComponents {
+			applyPricingOverride(overrides, r, r.ResourceType, c)
+		}
+
+		for _, s := range r.FlattenedSubResources() {
+			for _, c := range s.CostComponents {
+				applyPricingOverride(overrides, s, r.ResourceType, c)
+			}
+		}
+	}
+}
+
+func applyPricingOverride(overrides config.PricingOverrides, r *schema.Resource, resourceType string, c *schema.CostComponent) {
+	// User-defined custom prices are not list prices, so they're never overridden.
+	if c.CustomPrice() != nil || c.ProductFilter == nil {
+		return
+	}
+
+	f := c.ProductFilter
+	o := overrides.Find(
+		strVal(f.VendorName),
+		strVal(f.Service),
+		strVal(f.ProductFamily),
+		strVal(f.Region),
+		resourceType,
+	)
+	if o == nil {
+		return
+	}
+
+	listPrice := c.Price()
+	price := o.Apply(listPrice, c.UnitMultiplier)
+	if price.Equal(listPrice) {
+		return
+	}
+
+	log.Debug().Msgf("Applying pricing override %s to %s %s", o, r.Name, c.Name)
+	c.SetListPrice(&listPrice)
+	c.SetPrice(price)
+}
+
+func strVal(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
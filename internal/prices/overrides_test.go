@@ -0,0 +1,54 @@
+package prices
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestApplyPricingOverrides(t *testing.T) {
+	discount := 30.0
+	unitPrice := "0.05"
+	overrides := config.PricingOverrides{
+		{Vendor: "aws", Service: "AmazonEC2", DiscountPercent: &discount},
+		{ResourceType: "aws_instance", Service: "AmazonEBS", UnitPrice: &unitPrice},
+	}
+
+	r := testResource("AmazonEC2", "AmazonRDS")
+	r.CostComponents[0].UnitMultiplier = decimal.NewFromInt(1)
+	r.CostComponents[0].SetPrice(decimal.NewFromInt(1))
+	r.CostComponents[1].SetPrice(decimal.NewFromInt(2))
+
+	sub := testResource("AmazonEBS")
+	sub.ResourceType = ""
+	sub.CostComponents[0].UnitMultiplier = decimal.NewFromInt(1)
+	sub.CostComponents[0].SetPrice(decimal.RequireFromString("0.1"))
+	r.SubResources = []*schema.Resource{sub}
+
+	applyPricingOverrides(overrides, []*schema.Resource{r})
+
+	ec2 := r.CostComponents[0]
+	assert.Equal(t, "0.7", ec2.Price().String())
+	assert.Equal(t, "1", ec2.ListPrice().String())
+
+	rds := r.CostComponents[1]
+	assert.Equal(t, "2", rds.Price().String())
+	assert.Nil(t, rds.ListPrice())
+
+	ebs := sub.CostComponents[0]
+	assert.Equal(t, "0.05", ebs.Price().String())
+	assert.Equal(t, "0.1", ebs.ListPrice().String())
+
+	ec2.MonthlyQuantity = decimalPtr(decimal.NewFromInt(10))
+	ec2.CalculateCosts()
+	assert.Equal(t, "7", ec2.MonthlyCost.String())
+	assert.Equal(t, "10", ec2.ListMonthlyCost.String())
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
@@ -21,19 +21,22 @@ var (
 func PopulatePrices(ctx *config.RunContext, project *schema.Project) error {
 	resources := project.AllResources()
 
-	c := apiclient.GetPricingAPIClient(ctx)
+	source := NewPriceSource(ctx)
 
-	err := GetPricesConcurrent(ctx, c, resources)
+	err := GetPricesConcurrent(ctx, source, resources)
 	if err != nil {
 		return err
 	}
+
+	applyPricingOverrides(ctx.Config.PricingOverrides, resources)
+
 	return nil
 }
 
-// GetPricesConcurrent gets the prices of all resources concurrently.
-// Concurrency level is calculated using the following formula:
+// GetPricesConcurrent gets the prices of all resources concurrently from the
+// price source. Concurrency level is calculated using the following formula:
 // max(min(4, numCPU * 4), 16)
-func GetPricesConcurrent(ctx *config.RunContext, c *apiclient.PricingAPIClient, resources []*schema.Resource) error {
+func GetPricesConcurrent(ctx *config.RunContext, source PriceSource, resources []*schema.Resource) error {
 	// Set the number of workers
 	numWorkers := 4
 	numCPU := runtime.NumCPU()
@@ -44,17 +47,27 @@ func GetPricesConcurrent(ctx *config.RunContext, c *apiclient.PricingAPIClient,
 		numWorkers = 16
 	}
 
-	reqs := c.BatchRequests(resources, batchSize)
+	keys := apiclient.PriceQueryKeys(resources)
+
+	reqs := make([][]apiclient.PriceQueryKey, 0, len(keys)/batchSize+1)
+	for i := 0; i < len(keys); i += batchSize {
+		end := i + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		reqs = append(reqs, keys[i:end])
+	}
 
 	numJobs := len(reqs)
-	jobs := make(chan apiclient.BatchRequest, numJobs)
+	jobs := make(chan []apiclient.PriceQueryKey, numJobs)
 	resultErrors := make(chan error, numJobs)
 
 	// Fire up the workers
 	for i := 0; i < numWorkers; i++ {
-		go func(jobs <-chan apiclient.BatchRequest, resultErrors chan<- error) {
+		go func(jobs <-chan []apiclient.PriceQueryKey, resultErrors chan<- error) {
 			for req := range jobs {
-				err := GetPrices(ctx, c, req)
+				err := GetPrices(ctx, source, req)
 				resultErrors <- err
 			}
 		}(jobs, resultErrors)
@@ -75,14 +88,17 @@ func GetPricesConcurrent(ctx *config.RunContext, c *apiclient.PricingAPIClient,
 	return nil
 }
 
-func GetPrices(ctx *config.RunContext, c *apiclient.PricingAPIClient, req apiclient.BatchRequest) error {
-	results, err := c.PerformRequest(req)
+// GetPrices gets the prices for a batch of cost components from the price
+// source and sets them on the cost components.
+func GetPrices(ctx *config.RunContext, source PriceSource, keys []apiclient.PriceQueryKey) error {
+	results, err := source.GetPrices(keys)
 	if err != nil {
 		return err
 	}
 
+	c := currency(ctx)
 	for _, r := range results {
-		setCostComponentPrice(ctx, c.Currency, r.Resource, r.CostComponent, r.Result)
+		setCostComponentPrice(ctx, c, r.Resource, r.CostComponent, r.Result)
 	}
 
 	return nil
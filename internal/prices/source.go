@@ -0,0 +1,178 @@
+package prices
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+)
+
+var (
+	snapshotSources   = map[string]*SnapshotSource{}
+	snapshotSourcesMu = &sync.Mutex{}
+)
+
+// PriceSource resolves the prices of cost components. Results are in the
+// same shape as Cloud Pricing API GraphQL results, so that prices from
+// different sources produce the same price hashes and warnings.
+type PriceSource interface {
+	// GetPrices returns a result for each of the keys, in the same order as
+	// the keys. A key that has no matching products should return a result
+	// with an empty list of products rather than an error.
+	GetPrices(keys []apiclient.PriceQueryKey) ([]apiclient.PriceQueryResult, error)
+}
+
+// NewPriceSource returns the PriceSource configured for the run. By default
+// this is the Cloud Pricing API. If a price snapshot file is configured prices
+// are resolved from the snapshot, optionally falling back to the Cloud Pricing
+// API for any prices that are missing from the snapshot.
+func NewPriceSource(ctx *config.RunContext) PriceSource {
+	if ctx.Config.PricingSnapshotFile == "" {
+		return apiclient.GetPricingAPIClient(ctx)
+	}
+
+	snapshot := GetSnapshotSource(ctx.Config.PricingSnapshotFile, currency(ctx))
+	if ctx.Config.PricingSnapshotFallback {
+		return NewChainedSource(snapshot, apiclient.GetPricingAPIClient(ctx))
+	}
+
+	return snapshot
+}
+
+// SnapshotSource is a PriceSource that resolves prices from a local price
+// snapshot file. The snapshot is loaded lazily on the first request.
+type SnapshotSource struct {
+	path     string
+	currency string
+
+	once     sync.Once
+	snapshot *apiclient.PriceSnapshot
+	err      error
+}
+
+// GetSnapshotSource returns the SnapshotSource for the snapshot file at path.
+// Sources are shared across the application so that each snapshot file is only
+// loaded once.
+func GetSnapshotSource(path string, currency string) *SnapshotSource {
+	snapshotSourcesMu.Lock()
+	defer snapshotSourcesMu.Unlock()
+
+	if s, ok := snapshotSources[path]; ok && s.currency == currency {
+		return s
+	}
+
+	s := NewSnapshotSource(path, currency)
+	snapshotSources[path] = s
+
+	return s
+}
+
+// NewSnapshotSource returns a SnapshotSource for the snapshot file at path
+// that resolves prices in the given currency.
+func NewSnapshotSource(path string, currency string) *SnapshotSource {
+	return &SnapshotSource{
+		path:     path,
+		currency: currency,
+	}
+}
+
+// GetPrices implements PriceSource.
+func (s *SnapshotSource) GetPrices(keys []apiclient.PriceQueryKey) ([]apiclient.PriceQueryResult, error) {
+	s.once.Do(func() {
+		log.Debug().Msgf("Loading price snapshot from %s", s.path)
+		s.snapshot, s.err = apiclient.LoadPriceSnapshot(s.path)
+		if s.err == nil && s.snapshot.Currency != s.currency {
+			s.err = fmt.Errorf("price snapshot %s contains %s prices, currency %s is not available offline", s.path, s.snapshot.Currency, s.currency)
+		}
+	})
+	if s.err != nil {
+		return []apiclient.PriceQueryResult{}, s.err
+	}
+
+	log.Debug().Msgf("Getting pricing details for %d cost components from price snapshot", len(keys))
+	res := make([]apiclient.PriceQueryResult, len(keys))
+	for i, key := range keys {
+		res[i] = apiclient.PriceQueryResult{
+			PriceQueryKey: key,
+			Result:        s.snapshot.Lookup(key.CostComponent.ProductFilter, key.CostComponent.PriceFilter),
+		}
+	}
+
+	return res, nil
+}
+
+// ChainedSource is a PriceSource that falls back between a list of sources.
+// Each key is resolved by the first source that returns products for it. If a
+// source returns an error the next source is tried. The error is only returned
+// if the last source fails.
+type ChainedSource struct {
+	sources []PriceSource
+}
+
+// NewChainedSource returns a ChainedSource that tries the sources in order.
+func NewChainedSource(sources ...PriceSource) *ChainedSource {
+	return &ChainedSource{sources: sources}
+}
+
+// GetPrices implements PriceSource.
+func (c *ChainedSource) GetPrices(keys []apiclient.PriceQueryKey) ([]apiclient.PriceQueryResult, error) {
+	res := make([]apiclient.PriceQueryResult, len(keys))
+	for i, key := range keys {
+		res[i] = apiclient.PriceQueryResult{PriceQueryKey: key}
+	}
+
+	// pending holds the indexes of the keys that haven't been resolved yet.
+	pending := make([]int, len(keys))
+	for i := range keys {
+		pending[i] = i
+	}
+
+	for i, source := range c.sources {
+		if len(pending) == 0 {
+			break
+		}
+
+		pendingKeys := make([]apiclient.PriceQueryKey, len(pending))
+		for j, k := range pending {
+			pendingKeys[j] = keys[k]
+		}
+
+		results, err := source.GetPrices(pendingKeys)
+		if err != nil {
+			if i == len(c.sources)-1 {
+				return []apiclient.PriceQueryResult{}, err
+			}
+
+			log.Warn().Msgf("Error getting prices from price source %d, trying the next price source: %s", i+1, err)
+			continue
+		}
+
+		var unresolved []int
+		for j, k := range pending {
+			if j >= len(results) {
+				unresolved = append(unresolved, k)
+				continue
+			}
+
+			res[k].Result = results[j].Result
+			if len(results[j].Result.Get("data.products").Array()) == 0 {
+				unresolved = append(unresolved, k)
+			}
+		}
+
+		pending = unresolved
+	}
+
+	return res, nil
+}
+
+func currency(ctx *config.RunContext) string {
+	if ctx.Config.Currency == "" {
+		return "USD"
+	}
+
+	return ctx.Config.Currency
+}
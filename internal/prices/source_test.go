@@ -0,0 +1,145 @@
+package prices
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+var testPriceExport = `productHash,sku,vendorName,region,service,productFamily,attributes,prices
+p1,SKU1,aws,us-east-1,AmazonEC2,Compute Instance,"{""instanceType"":""t3.micro""}","{""h1"":[{""priceHash"":""h1"",""USD"":""0.0104000000"",""purchaseOption"":""on_demand"",""unit"":""Hrs""}]}"
+`
+
+// staticSource is a PriceSource that returns fixed results keyed by the
+// service of the product filter.
+type staticSource struct {
+	results map[string]string
+	err     error
+	calls   int
+}
+
+func (s *staticSource) GetPrices(keys []apiclient.PriceQueryKey) ([]apiclient.PriceQueryResult, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	res := make([]apiclient.PriceQueryResult, len(keys))
+	for i, key := range keys {
+		raw, ok := s.results[*key.CostComponent.ProductFilter.Service]
+		if !ok {
+			raw = `{"data":{"products":[]}}`
+		}
+
+		res[i] = apiclient.PriceQueryResult{PriceQueryKey: key, Result: gjson.Parse(raw)}
+	}
+
+	return res, nil
+}
+
+func testResource(services ...string) *schema.Resource {
+	r := &schema.Resource{Name: "test", ResourceType: "aws_instance"}
+	for _, service := range services {
+		r.CostComponents = append(r.CostComponents, &schema.CostComponent{
+			Name: service,
+			ProductFilter: &schema.ProductFilter{
+				VendorName: strPtr("aws"),
+				Service:    strPtr(service),
+			},
+		})
+	}
+
+	return r
+}
+
+func TestGetPricesConcurrent(t *testing.T) {
+	ctx := config.EmptyRunContext()
+	source := &staticSource{results: map[string]string{
+		"AmazonEC2": `{"data":{"products":[{"prices":[{"priceHash":"h1","USD":"0.0104000000"}]}]}}`,
+	}}
+
+	r := testResource("AmazonEC2", "AmazonRDS")
+	err := GetPricesConcurrent(ctx, source, []*schema.Resource{r})
+	require.NoError(t, err)
+
+	assert.Equal(t, "h1", r.CostComponents[0].PriceHash())
+	assert.True(t, decimal.RequireFromString("0.0104").Equal(r.CostComponents[0].Price()))
+	assert.True(t, decimal.Zero.Equal(r.CostComponents[1].Price()))
+	assert.Equal(t, map[string]map[string]int{"aws_instance": {"No products found": 1}}, ctx.GetResourceWarnings())
+}
+
+func TestSnapshotSource(t *testing.T) {
+	s, err := apiclient.NewPriceSnapshotFromExport(strings.NewReader(testPriceExport))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "prices.json.gz")
+	require.NoError(t, s.Write(path))
+
+	r := &schema.Resource{
+		Name: "test",
+		CostComponents: []*schema.CostComponent{
+			{
+				ProductFilter: &schema.ProductFilter{
+					VendorName: strPtr("aws"),
+					Service:    strPtr("AmazonEC2"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "instanceType", Value: strPtr("t3.micro")},
+					},
+				},
+				PriceFilter: &schema.PriceFilter{PurchaseOption: strPtr("on_demand")},
+			},
+		},
+	}
+	keys := apiclient.PriceQueryKeys([]*schema.Resource{r})
+
+	res, err := NewSnapshotSource(path, "USD").GetPrices(keys)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, "h1", res[0].Result.Get("data.products.0.prices.0.priceHash").String())
+
+	_, err = NewSnapshotSource(path, "EUR").GetPrices(keys)
+	assert.ErrorContains(t, err, "currency EUR is not available offline")
+}
+
+func TestChainedSource(t *testing.T) {
+	first := &staticSource{results: map[string]string{
+		"AmazonEC2": `{"data":{"products":[{"prices":[{"priceHash":"first","USD":"1"}]}]}}`,
+	}}
+	second := &staticSource{results: map[string]string{
+		"AmazonEC2": `{"data":{"products":[{"prices":[{"priceHash":"second","USD":"2"}]}]}}`,
+		"AmazonRDS": `{"data":{"products":[{"prices":[{"priceHash":"second","USD":"3"}]}]}}`,
+	}}
+
+	keys := apiclient.PriceQueryKeys([]*schema.Resource{testResource("AmazonEC2", "AmazonRDS", "AmazonS3")})
+
+	res, err := NewChainedSource(first, second).GetPrices(keys)
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+
+	assert.Equal(t, "1", res[0].Result.Get("data.products.0.prices.0.USD").String())
+	assert.Equal(t, "3", res[1].Result.Get("data.products.0.prices.0.USD").String())
+	assert.Equal(t, `{"data":{"products":[]}}`, res[2].Result.Raw)
+	assert.Equal(t, "AmazonS3", res[2].CostComponent.Name)
+
+	failing := &staticSource{err: errors.New("offline")}
+	res, err = NewChainedSource(failing, second).GetPrices(keys)
+	require.NoError(t, err)
+	assert.Equal(t, "2", res[0].Result.Get("data.products.0.prices.0.USD").String())
+
+	_, err = NewChainedSource(second, failing).GetPrices(keys)
+	assert.ErrorContains(t, err, "offline")
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,48 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetDBInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::RDS::DBInstance",
+		RFunc: NewDBInstance,
+	}
+}
+
+func NewDBInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	piEnabled := d.Get("EnablePerformanceInsights").Bool()
+	piLongTerm := piEnabled && d.Get("PerformanceInsightsRetentionPeriod").Int() > 7
+
+	iops := d.Get("Iops").Float()
+	defaultStorageType := "gp2"
+	if iops > 0 {
+		defaultStorageType = "io1"
+	}
+
+	a := &aws.DBInstance{
+		Address:                              d.Address,
+		Region:                               d.Get("region").String(),
+		InstanceClass:                        d.Get("DBInstanceClass").String(),
+		Engine:                               d.Get("Engine").String(),
+		MultiAZ:                              d.Get("MultiAZ").Bool(),
+		LicenseModel:                         d.Get("LicenseModel").String(),
+		BackupRetentionPeriod:                d.Get("BackupRetentionPeriod").Int(),
+		IOPS:                                 iops,
+		StorageType:                          d.GetStringOrDefault("StorageType", defaultStorageType),
+		PerformanceInsightsEnabled:           piEnabled,
+		PerformanceInsightsLongTermRetention: piLongTerm,
+	}
+
+	// AllocatedStorage is a string in CloudFormation, gjson parses it as a
+	// number for us.
+	if !d.IsEmpty("AllocatedStorage") {
+		a.AllocatedStorageGB = floatPtr(d.Get("AllocatedStorage").Float())
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
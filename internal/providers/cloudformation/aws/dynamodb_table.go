@@ -25,7 +25,7 @@ func NewDynamoDBTable(d *schema.ResourceData, u *schema.UsageData) *schema.Resou
 		return nil
 	}
 
-	region := "us-east-1" // TODO figure out how to set region
+	region := d.Get("region").String()
 	billingMode := cfr.BillingMode
 	var readCapacity int64
 	if cfr.ProvisionedThroughput != nil {
@@ -0,0 +1,90 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetECSServiceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::ECS::Service",
+		RFunc: NewECSService,
+		ReferenceAttributes: []string{
+			"Cluster",
+			"TaskDefinition",
+		},
+	}
+}
+
+func NewECSService(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	memoryGB := float64(0)
+	vcpu := float64(0)
+	inferenceAcceleratorDeviceType := ""
+
+	for _, ref := range d.References("TaskDefinition") {
+		if ref.Type == "AWS::ECS::TaskDefinition" {
+			memoryGB = parseVCPUMemoryString(ref.Get("Memory").String())
+			vcpu = parseVCPUMemoryString(ref.Get("Cpu").String())
+			inferenceAcceleratorDeviceType = ref.Get("InferenceAccelerators.0.DeviceType").String()
+			break
+		}
+	}
+
+	a := &aws.ECSService{
+		Address:                        d.Address,
+		Region:                         d.Get("region").String(),
+		LaunchType:                     ecsLaunchType(d),
+		DesiredCount:                   d.GetInt64OrDefault("DesiredCount", 1),
+		MemoryGB:                       memoryGB,
+		VCPU:                           vcpu,
+		InferenceAcceleratorDeviceType: inferenceAcceleratorDeviceType,
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
+
+// ecsLaunchType determines the launch type of the service using the following
+// precedence:
+//  1. LaunchType
+//  2. CapacityProviderStrategy
+//  3. DefaultCapacityProviderStrategy of the cluster
+func ecsLaunchType(d *schema.ResourceData) string {
+	launchType := d.Get("LaunchType").String()
+	if launchType != "" {
+		return launchType
+	}
+
+	launchType = capacityProviderLaunchType(d.Get("CapacityProviderStrategy").Array())
+	if launchType != "" {
+		return launchType
+	}
+
+	for _, ref := range d.References("Cluster") {
+		if ref.Type == "AWS::ECS::Cluster" {
+			return capacityProviderLaunchType(ref.Get("DefaultCapacityProviderStrategy").Array())
+		}
+	}
+
+	return ""
+}
+
+func capacityProviderLaunchType(strategies []gjson.Result) string {
+	launchType := ""
+	for _, data := range strategies {
+		provider := strings.ToUpper(data.Get("CapacityProvider").String())
+		if data.Get("Base").Int() > 0 || data.Get("Weight").Int() > 0 {
+			if strings.HasPrefix(provider, "FARGATE") {
+				// We have at least one fargate provider, use that as the launch type
+				return "FARGATE"
+			}
+			launchType = "EC2"
+		}
+	}
+	return launchType
+}
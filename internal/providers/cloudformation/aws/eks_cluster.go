@@ -0,0 +1,24 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetNewEKSClusterItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::EKS::Cluster",
+		RFunc: NewEKSCluster,
+	}
+}
+
+func NewEKSCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	a := &aws.EKSCluster{
+		Address: d.Address,
+		Region:  d.Get("region").String(),
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
@@ -0,0 +1,24 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetNewEKSFargateProfileItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::EKS::FargateProfile",
+		RFunc: NewEKSFargateProfile,
+	}
+}
+
+func NewEKSFargateProfile(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	a := &aws.EKSFargateProfile{
+		Address: d.Address,
+		Region:  d.Get("region").String(),
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
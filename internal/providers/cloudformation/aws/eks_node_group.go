@@ -0,0 +1,42 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const defaultEKSInstanceType = "t3.medium"
+
+func GetNewEKSNodeGroupItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name: "AWS::EKS::Nodegroup",
+		Notes: []string{
+			"Launch templates are not yet supported.",
+		},
+		RFunc: NewEKSNodeGroup,
+	}
+}
+
+func NewEKSNodeGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	instanceType := strings.ToLower(d.Get("InstanceTypes.0").String())
+	if instanceType == "" {
+		instanceType = defaultEKSInstanceType
+	}
+
+	a := &aws.EKSNodeGroup{
+		Address:        d.Address,
+		Region:         d.Get("region").String(),
+		Name:           d.Get("NodegroupName").String(),
+		ClusterName:    d.Get("ClusterName").String(),
+		InstanceType:   instanceType,
+		PurchaseOption: strings.ToLower(d.Get("CapacityType").String()),
+		InstanceCount:  intPtr(d.Get("ScalingConfig.DesiredSize").Int()),
+		DiskSize:       d.GetFloat64OrDefault("DiskSize", 20),
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
@@ -0,0 +1,28 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetElastiCacheClusterItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::ElastiCache::CacheCluster",
+		RFunc: NewElastiCacheCluster,
+	}
+}
+
+func NewElastiCacheCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	a := &aws.ElastiCacheCluster{
+		Address:                d.Address,
+		Region:                 d.Get("region").String(),
+		NodeType:               d.Get("CacheNodeType").String(),
+		Engine:                 d.Get("Engine").String(),
+		CacheNodes:             d.Get("NumCacheNodes").Int(),
+		SnapshotRetentionLimit: d.Get("SnapshotRetentionLimit").Int(),
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
@@ -0,0 +1,35 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetElastiCacheReplicationGroupItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::ElastiCache::ReplicationGroup",
+		RFunc: NewElastiCacheReplicationGroup,
+	}
+}
+
+func NewElastiCacheReplicationGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	// The node groups can either be set with NumNodeGroups and
+	// ReplicasPerNodeGroup or configured individually with NodeGroupConfiguration.
+	clusterNodeGroups := d.GetInt64OrDefault("NumNodeGroups", int64(len(d.Get("NodeGroupConfiguration").Array())))
+	clusterReplicasPerNodeGroup := d.GetInt64OrDefault("ReplicasPerNodeGroup", d.Get("NodeGroupConfiguration.0.ReplicaCount").Int())
+
+	a := &aws.ElastiCacheReplicationGroup{
+		Address:                     d.Address,
+		Region:                      d.Get("region").String(),
+		NodeType:                    d.Get("CacheNodeType").String(),
+		Engine:                      d.Get("Engine").String(),
+		CacheClusters:               d.GetInt64OrDefault("NumCacheClusters", 1),
+		ClusterNodeGroups:           clusterNodeGroups,
+		ClusterReplicasPerNodeGroup: clusterReplicasPerNodeGroup,
+		SnapshotRetentionLimit:      d.Get("SnapshotRetentionLimit").Int(),
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
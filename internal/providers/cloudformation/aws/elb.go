@@ -0,0 +1,24 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetELBRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::ElasticLoadBalancing::LoadBalancer",
+		RFunc: NewELB,
+	}
+}
+
+func NewELB(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	a := &aws.ELB{
+		Address: d.Address,
+		Region:  d.Get("region").String(),
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
@@ -0,0 +1,83 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// rootDeviceNames are the device names that AMIs commonly use for the root
+// volume. CloudFormation doesn't distinguish the root volume from other block
+// device mappings so we use these to find it.
+var rootDeviceNames = map[string]bool{
+	"/dev/xvda": true,
+	"/dev/sda1": true,
+}
+
+func GetInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name: "AWS::EC2::Instance",
+		Notes: []string{
+			"Costs associated with marketplace AMIs are not supported.",
+			"For non-standard Linux AMIs such as Windows and RHEL, the operating system should be specified in usage file.",
+			"EC2 detailed monitoring assumes the standard 7 metrics and the lowest tier of prices for CloudWatch.",
+			"If a root volume is not specified then an 8Gi gp2 volume is assumed.",
+			"Launch templates are not yet supported.",
+		},
+		RFunc: NewInstance,
+	}
+}
+
+func NewInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	a := &aws.Instance{
+		Address:          d.Address,
+		Region:           region,
+		Tenancy:          d.Get("Tenancy").String(),
+		PurchaseOption:   "on_demand",
+		AMI:              d.Get("ImageId").String(),
+		InstanceType:     d.Get("InstanceType").String(),
+		EBSOptimized:     d.Get("EbsOptimized").Bool(),
+		EnableMonitoring: d.Get("Monitoring").Bool(),
+		CPUCredits:       d.Get("CreditSpecification.CPUCredits").String(),
+		HasHost:          !d.IsEmpty("HostId"),
+		RootBlockDevice: &aws.EBSVolume{
+			Address: "root_block_device",
+			Region:  region,
+		},
+	}
+
+	for _, data := range d.Get("BlockDeviceMappings").Array() {
+		ebs := data.Get("Ebs")
+		if !ebs.Exists() {
+			// Instance store volumes and NoDevice mappings don't have a cost.
+			continue
+		}
+
+		volume := &aws.EBSVolume{
+			Region: region,
+			Type:   ebs.Get("VolumeType").String(),
+			IOPS:   ebs.Get("Iops").Int(),
+		}
+
+		if v := ebs.Get("VolumeSize"); v.Exists() {
+			volume.Size = intPtr(v.Int())
+		}
+
+		if rootDeviceNames[data.Get("DeviceName").String()] {
+			volume.Address = "root_block_device"
+			a.RootBlockDevice = volume
+
+			continue
+		}
+
+		volume.Address = fmt.Sprintf("ebs_block_device[%d]", len(a.EBSBlockDevices))
+		a.EBSBlockDevices = append(a.EBSBlockDevices, volume)
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
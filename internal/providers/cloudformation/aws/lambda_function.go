@@ -0,0 +1,33 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetLambdaFunctionRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::Lambda::Function",
+		RFunc: NewLambdaFunction,
+	}
+}
+
+func NewLambdaFunction(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	architecture := "x86_64"
+	if v := d.Get("Architectures.0"); v.Exists() {
+		architecture = v.String()
+	}
+
+	a := &aws.LambdaFunction{
+		Address:      d.Address,
+		Region:       d.Get("region").String(),
+		Name:         d.Get("FunctionName").String(),
+		MemorySize:   d.GetInt64OrDefault("MemorySize", 128),
+		Architecture: architecture,
+		StorageSize:  d.GetInt64OrDefault("EphemeralStorage.Size", 512),
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
@@ -0,0 +1,27 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetLBRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::ElasticLoadBalancingV2::LoadBalancer",
+		RFunc: NewLB,
+	}
+}
+
+func NewLB(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	a := &aws.LB{
+		Address: d.Address,
+		Region:  d.Get("region").String(),
+		// CloudFormation defaults to an application load balancer if the
+		// Type isn't set.
+		LoadBalancerType: d.GetStringOrDefault("Type", "application"),
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
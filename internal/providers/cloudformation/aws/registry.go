@@ -20,7 +20,7 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	// GetConfigOrganizationCustomRuleItem(),
 	// GetConfigOrganizationManagedRuleItem(),
 	// getDataTransferRegistryItem(),
-	// GetDBInstanceRegistryItem(),
+	GetDBInstanceRegistryItem(),
 	// GetDMSRegistryItem(),
 	// GetDocDBClusterInstanceRegistryItem(),
 	// GetDocDBClusterRegistryItem(),
@@ -37,17 +37,17 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	// GetEC2TransitGatewayPeeringAttachmentRegistryItem(),
 	// GetEC2TransitGatewayVpcAttachmentRegistryItem(),
 	// GetECRRegistryItem(),
-	// GetECSServiceRegistryItem(),
+	GetECSServiceRegistryItem(),
 	// GetEFSFileSystemRegistryItem(),
 	// GetEIPRegistryItem(),
-	// GetElastiCacheClusterItem(),
-	// GetElastiCacheReplicationGroupItem(),
+	GetElastiCacheClusterItem(),
+	GetElastiCacheReplicationGroupItem(),
 	// GetElasticsearchDomainRegistryItem(),
-	// GetELBRegistryItem(),
+	GetELBRegistryItem(),
 	// GetFSXWindowsFSRegistryItem(),
-	// GetInstanceRegistryItem(),
-	// GetLambdaFunctionRegistryItem(),
-	// GetLBRegistryItem(),
+	GetInstanceRegistryItem(),
+	GetLambdaFunctionRegistryItem(),
+	GetLBRegistryItem(),
 	// GetLightsailInstanceRegistryItem(),
 	// GetMSKClusterRegistryItem(),
 	// GetALBRegistryItem(),
@@ -60,7 +60,7 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	// GetRoute53ResolverEndpointRegistryItem(),
 	// GetRoute53RecordRegistryItem(),
 	// GetRoute53ZoneRegistryItem(),
-	// GetS3BucketRegistryItem(),
+	GetS3BucketRegistryItem(),
 	// GetS3BucketAnalyticsConfigurationRegistryItem(),
 	// GetS3BucketInventoryRegistryItem(),
 	// GetSecretsManagerSecret(),
@@ -69,9 +69,9 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	// GetSNSTopicRegistryItem(),
 	// GetSNSTopicSubscriptionRegistryItem(),
 	// GetSQSQueueRegistryItem(),
-	// GetNewEKSNodeGroupItem(),
-	// GetNewEKSFargateProfileItem(),
-	// GetNewEKSClusterItem(),
+	GetNewEKSNodeGroupItem(),
+	GetNewEKSFargateProfileItem(),
+	GetNewEKSClusterItem(),
 	// GetNewKMSKeyRegistryItem(),
 	// GetNewKMSExternalKeyRegistryItem(),
 	// GetVPNConnectionRegistryItem(),
@@ -363,6 +363,50 @@ var FreeResources = []string{
 	"aws_vpn_gateway_attachment",
 	"aws_vpn_gateway_route_propagation",
 
+	// CloudFormation resource types
+	"AWS::CDK::Metadata",
+	"AWS::EC2::EIPAssociation",
+	"AWS::EC2::InternetGateway",
+	"AWS::EC2::LaunchTemplate",
+	"AWS::EC2::Route",
+	"AWS::EC2::RouteTable",
+	"AWS::EC2::SecurityGroup",
+	"AWS::EC2::SecurityGroupEgress",
+	"AWS::EC2::SecurityGroupIngress",
+	"AWS::EC2::Subnet",
+	"AWS::EC2::SubnetRouteTableAssociation",
+	"AWS::EC2::VPC",
+	"AWS::EC2::VPCGatewayAttachment",
+	"AWS::ECS::CapacityProvider",
+	"AWS::ECS::Cluster",
+	"AWS::ECS::ClusterCapacityProviderAssociations",
+	"AWS::ECS::TaskDefinition",
+	"AWS::EKS::Addon",
+	"AWS::EKS::IdentityProviderConfig",
+	"AWS::ElastiCache::ParameterGroup",
+	"AWS::ElastiCache::SecurityGroup",
+	"AWS::ElastiCache::SubnetGroup",
+	"AWS::ElasticLoadBalancingV2::Listener",
+	"AWS::ElasticLoadBalancingV2::ListenerCertificate",
+	"AWS::ElasticLoadBalancingV2::ListenerRule",
+	"AWS::ElasticLoadBalancingV2::TargetGroup",
+	"AWS::IAM::Group",
+	"AWS::IAM::InstanceProfile",
+	"AWS::IAM::ManagedPolicy",
+	"AWS::IAM::Policy",
+	"AWS::IAM::Role",
+	"AWS::IAM::User",
+	"AWS::Lambda::Alias",
+	"AWS::Lambda::EventInvokeConfig",
+	"AWS::Lambda::EventSourceMapping",
+	"AWS::Lambda::LayerVersion",
+	"AWS::Lambda::Permission",
+	"AWS::Lambda::Version",
+	"AWS::RDS::DBParameterGroup",
+	"AWS::RDS::DBSubnetGroup",
+	"AWS::RDS::OptionGroup",
+	"AWS::S3::BucketPolicy",
+
 	// Hashicorp
 	"null_resource",
 	"local_file",
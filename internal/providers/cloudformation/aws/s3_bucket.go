@@ -0,0 +1,77 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+var s3StorageClassNames = map[string]string{
+	"STANDARD":            "standard",
+	"INTELLIGENT_TIERING": "intelligent_tiering",
+	"STANDARD_IA":         "standard_infrequent_access",
+	"ONEZONE_IA":          "one_zone_infrequent_access",
+	"GLACIER":             "glacier_flexible_retrieval",
+	"DEEP_ARCHIVE":        "glacier_deep_archive",
+}
+
+func GetS3BucketRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name: "AWS::S3::Bucket",
+		Notes: []string{
+			"S3 replication time control data transfer, and batch operations are not supported.",
+			"S3 Analytics and S3 Inventory are not yet supported.",
+		},
+		RFunc: NewS3Bucket,
+	}
+}
+
+func NewS3Bucket(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	objTagsEnabled := false
+
+	// Always add the standard storage class
+	lifecycleStorageClassMap := map[string]bool{
+		"standard": true,
+	}
+
+	for _, rule := range d.Get("LifecycleConfiguration.Rules").Array() {
+		if rule.Get("Status").String() != "Enabled" {
+			continue
+		}
+
+		if len(rule.Get("TagFilters").Array()) > 0 {
+			objTagsEnabled = true
+		}
+
+		transitions := rule.Get("Transitions").Array()
+		transitions = append(transitions, rule.Get("NoncurrentVersionTransitions").Array()...)
+		for _, key := range []string{"Transition", "NoncurrentVersionTransition"} {
+			if t := rule.Get(key); t.Exists() {
+				transitions = append(transitions, t)
+			}
+		}
+
+		for _, t := range transitions {
+			storageClass := s3StorageClassNames[t.Get("StorageClass").String()]
+			if storageClass != "" {
+				lifecycleStorageClassMap[storageClass] = true
+			}
+		}
+	}
+
+	lifecycleStorageClasses := make([]string, 0, len(lifecycleStorageClassMap))
+	for storageClass := range lifecycleStorageClassMap {
+		lifecycleStorageClasses = append(lifecycleStorageClasses, storageClass)
+	}
+
+	a := &aws.S3Bucket{
+		Address:                 d.Address,
+		Region:                  d.Get("region").String(),
+		Name:                    d.Get("BucketName").String(),
+		ObjectTagsEnabled:       objTagsEnabled,
+		LifecycleStorageClasses: lifecycleStorageClasses,
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
@@ -1,9 +1,15 @@
 package aws
 
 import (
+	"regexp"
+	"strconv"
+	"strings"
+
 	"github.com/awslabs/goformation/v4/cloudformation/tags"
 )
 
+var vcpuMemoryUnitRegex = regexp.MustCompile(`(?i)vcpu|gb`)
+
 func mapTags(cfTags []tags.Tag) *map[string]string {
 	mapped := make(map[string]string)
 	for _, tag := range cfTags {
@@ -11,3 +17,29 @@ func mapTags(cfTags []tags.Tag) *map[string]string {
 	}
 	return &mapped
 }
+
+func intPtr(i int64) *int64 {
+	return &i
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+// parseVCPUMemoryString parses the Cpu and Memory of an ECS task definition.
+// These can either be set in units (1024 CPU units is 1 vCPU and memory is in
+// MiB) or with a unit suffix such as "1 vCPU" or "2 GB".
+func parseVCPUMemoryString(rawValue string) float64 {
+	var quantity float64
+
+	noSpaceString := strings.ReplaceAll(rawValue, " ", "")
+
+	if vcpuMemoryUnitRegex.MatchString(noSpaceString) {
+		quantity, _ = strconv.ParseFloat(vcpuMemoryUnitRegex.ReplaceAllString(noSpaceString, ""), 64)
+	} else {
+		quantity, _ = strconv.ParseFloat(noSpaceString, 64)
+		quantity /= 1024.0
+	}
+
+	return quantity
+}
@@ -0,0 +1,314 @@
+package cloudformation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const (
+	cdkManifestFile = "manifest.json"
+
+	// cdkOutputDir is the default directory that `cdk synth` writes the cloud
+	// assembly to in the CDK app directory.
+	cdkOutputDir = "cdk.out"
+
+	cdkStackArtifactType    = "aws:cloudformation:stack"
+	cdkAssemblyArtifactType = "cdk:cloud-assembly"
+
+	// cdkAssetPathMetadata is the metadata key that CDK adds to nested stack
+	// resources with the path of the nested stack template in the cloud
+	// assembly.
+	cdkAssetPathMetadata = "aws:asset:path"
+
+	nestedStackResourceType = "AWS::CloudFormation::Stack"
+
+	// maxNestedStackDepth stops us from recursing forever if a nested stack
+	// template refers back to its parent.
+	maxNestedStackDepth = 10
+)
+
+// cdkManifest is the manifest.json of an AWS CDK cloud assembly, e.g. the
+// cdk.out directory created by `cdk synth`.
+type cdkManifest struct {
+	Artifacts map[string]cdkArtifact `json:"artifacts"`
+}
+
+type cdkArtifact struct {
+	Type        string `json:"type"`
+	Environment string `json:"environment"`
+	DisplayName string `json:"displayName"`
+	Properties  struct {
+		TemplateFile  string                 `json:"templateFile"`
+		StackName     string                 `json:"stackName"`
+		DirectoryName string                 `json:"directoryName"`
+		Parameters    map[string]interface{} `json:"parameters"`
+	} `json:"properties"`
+}
+
+// cdkStack is a synthesized stack in a cloud assembly.
+type cdkStack struct {
+	Name         string
+	Dir          string
+	TemplateFile string
+	Region       string
+	Parameters   map[string]interface{}
+}
+
+// IsCDKOutput returns true if the path is an AWS CDK cloud assembly directory,
+// or a CDK app directory with a cdk.out cloud assembly, that contains at least
+// one CloudFormation stack.
+func IsCDKOutput(path string) bool {
+	stacks, err := loadCDKStacks(path)
+	return err == nil && len(stacks) > 0
+}
+
+// CDKProvider loads the stacks of an AWS CDK cloud assembly. Each stack is
+// returned as a separate project using the stack name as the project name.
+type CDKProvider struct {
+	ctx                  *config.ProjectContext
+	Path                 string
+	includePastResources bool
+}
+
+func NewCDKProvider(ctx *config.ProjectContext, includePastResources bool) schema.Provider {
+	return &CDKProvider{
+		ctx:                  ctx,
+		Path:                 ctx.ProjectConfig.Path,
+		includePastResources: includePastResources,
+	}
+}
+
+func (p *CDKProvider) Context() *config.ProjectContext { return p.ctx }
+
+func (p *CDKProvider) Type() string {
+	return "cdk"
+}
+
+func (p *CDKProvider) DisplayType() string {
+	return "AWS CDK"
+}
+
+func (p *CDKProvider) AddMetadata(metadata *schema.ProjectMetadata) {
+	metadata.ConfigSha = p.ctx.ProjectConfig.ConfigSha
+}
+
+func (p *CDKProvider) LoadResources(usage schema.UsageMap) ([]*schema.Project, error) {
+	stacks, err := loadCDKStacks(p.Path)
+	if err != nil {
+		return []*schema.Project{}, errors.Wrap(err, "Error reading AWS CDK cloud assembly")
+	}
+
+	parser := NewParser(p.ctx)
+	projects := make([]*schema.Project, 0, len(stacks))
+
+	for _, stack := range stacks {
+		templatePath := filepath.Join(stack.Dir, stack.TemplateFile)
+
+		metadata := schema.DetectProjectMetadata(templatePath)
+		metadata.Type = p.Type()
+		p.AddMetadata(metadata)
+
+		project := schema.NewProject(stack.Name, metadata)
+
+		region := stack.Region
+		if region == "" {
+			region = projectRegion(p.ctx)
+		}
+
+		resources, err := p.loadStack(parser, stack.Dir, stack.TemplateFile, region, stack.Parameters, usage, 0)
+		if err != nil {
+			return projects, errors.Wrapf(err, "Error loading AWS CDK stack %s", stack.Name)
+		}
+
+		resources = append(parser.loadUsageFileResources(usage), resources...)
+		project.Resources = resources
+
+		if p.includePastResources {
+			project.PastResources = resources
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects, nil
+}
+
+// loadStack returns the resources of the stack template. Nested stacks are
+// loaded from the nested stack templates in the cloud assembly and their
+// resources are added as sub resources of the AWS::CloudFormation::Stack
+// resource so the breakdown shows the costs of each nested stack.
+func (p *CDKProvider) loadStack(parser *Parser, dir string, templateFile string, region string, params map[string]interface{}, usage schema.UsageMap, depth int) ([]*schema.Resource, error) {
+	t, err := LoadTemplate(filepath.Join(dir, templateFile), region, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := parser.parseResources(t, usage)
+
+	for _, r := range t.Resources {
+		if r.Type != nestedStackResourceType {
+			continue
+		}
+
+		assetPath := r.Metadata.Get(cdkAssetPathMetadata).String()
+		if assetPath == "" {
+			log.Debug().Msgf("Skipping nested stack %s as it does not have an %s metadata", r.LogicalID, cdkAssetPathMetadata)
+			continue
+		}
+
+		if depth >= maxNestedStackDepth {
+			log.Warn().Msgf("Skipping nested stack %s as stacks are nested more than %d levels deep", r.LogicalID, maxNestedStackDepth)
+			continue
+		}
+
+		var nestedParams map[string]interface{}
+		if v, ok := r.Properties.Get("Parameters").Value().(map[string]interface{}); ok {
+			nestedParams = v
+		}
+
+		nested, err := p.loadStack(parser, dir, assetPath, region, nestedParams, usage, depth+1)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error loading nested stack %s", r.LogicalID)
+		}
+
+		for i, res := range resources {
+			if res.Name == r.LogicalID {
+				var skipped []*schema.Resource
+				resources[i], skipped = nestedStackResource(res, nested)
+				resources = append(resources, skipped...)
+				break
+			}
+		}
+	}
+
+	return resources, nil
+}
+
+// nestedStackResource returns the resource for a nested stack with the
+// resources of the nested stack as its sub resources. Skipped resources can't
+// be shown as sub resources, so these are returned separately with the nested
+// stack name as a prefix.
+func nestedStackResource(stack *schema.Resource, nested []*schema.Resource) (*schema.Resource, []*schema.Resource) {
+	r := &schema.Resource{
+		Name:         stack.Name,
+		ResourceType: nestedStackResourceType,
+		Tags:         stack.Tags,
+	}
+
+	var skipped []*schema.Resource
+
+	for _, n := range nested {
+		if n.IsSkipped {
+			n.Name = fmt.Sprintf("%s/%s", stack.Name, n.Name)
+			skipped = append(skipped, n)
+
+			continue
+		}
+
+		r.SubResources = append(r.SubResources, n)
+	}
+
+	if len(r.SubResources) == 0 {
+		r.IsSkipped = true
+		r.NoPrice = true
+		r.SkipMessage = "Free resource."
+	}
+
+	return r, skipped
+}
+
+// loadCDKStacks returns the stacks in the cloud assembly at path, including the
+// stacks of any nested cloud assemblies used by CDK stages.
+func loadCDKStacks(path string) ([]cdkStack, error) {
+	return loadCDKAssemblyStacks(cdkAssemblyDir(path), 0)
+}
+
+// cdkAssemblyDir returns the cdk.out directory of the path if the path is a
+// CDK app directory rather than a cloud assembly directory.
+func cdkAssemblyDir(path string) string {
+	if _, err := os.Stat(filepath.Join(path, cdkManifestFile)); err == nil {
+		return path
+	}
+
+	dir := filepath.Join(path, cdkOutputDir)
+	if _, err := os.Stat(filepath.Join(dir, cdkManifestFile)); err == nil {
+		return dir
+	}
+
+	return path
+}
+
+func loadCDKAssemblyStacks(dir string, depth int) ([]cdkStack, error) {
+	data, err := os.ReadFile(filepath.Join(dir, cdkManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest cdkManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", cdkManifestFile, err)
+	}
+
+	ids := make([]string, 0, len(manifest.Artifacts))
+	for id := range manifest.Artifacts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var stacks []cdkStack
+
+	for _, id := range ids {
+		artifact := manifest.Artifacts[id]
+
+		switch artifact.Type {
+		case cdkStackArtifactType:
+			name := artifact.Properties.StackName
+			if name == "" {
+				name = id
+			}
+
+			stacks = append(stacks, cdkStack{
+				Name:         name,
+				Dir:          dir,
+				TemplateFile: artifact.Properties.TemplateFile,
+				Region:       cdkEnvironmentRegion(artifact.Environment),
+				Parameters:   artifact.Properties.Parameters,
+			})
+		case cdkAssemblyArtifactType:
+			if depth >= maxNestedStackDepth || artifact.Properties.DirectoryName == "" {
+				continue
+			}
+
+			nested, err := loadCDKAssemblyStacks(filepath.Join(dir, artifact.Properties.DirectoryName), depth+1)
+			if err != nil {
+				return nil, err
+			}
+
+			stacks = append(stacks, nested...)
+		}
+	}
+
+	return stacks, nil
+}
+
+// cdkEnvironmentRegion returns the region of a CDK environment such as
+// aws://123456789012/us-east-1. Environment agnostic stacks use
+// unknown-region, in which case an empty string is returned.
+func cdkEnvironmentRegion(env string) string {
+	parts := strings.Split(strings.TrimPrefix(env, "aws://"), "/")
+	if len(parts) != 2 || parts[1] == "unknown-region" {
+		return ""
+	}
+
+	return parts[1]
+}
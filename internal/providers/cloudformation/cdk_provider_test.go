@@ -0,0 +1,63 @@
+package cloudformation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestIsCDKOutput(t *testing.T) {
+	assert.True(t, IsCDKOutput("testdata/cdk.out"))
+	// testdata is a CDK app directory as it contains the cdk.out directory.
+	assert.True(t, IsCDKOutput("testdata"))
+	assert.False(t, IsCDKOutput(t.TempDir()))
+	assert.False(t, IsCDKOutput("testdata/template.yml"))
+}
+
+func TestCDKProviderAppDir(t *testing.T) {
+	ctx := config.NewProjectContext(config.EmptyRunContext(), &config.Project{Path: "testdata"}, map[string]interface{}{})
+	p := NewCDKProvider(ctx, false)
+
+	projects, err := p.LoadResources(schema.NewUsageMapFromInterface(map[string]interface{}{}))
+	require.NoError(t, err)
+	require.Len(t, projects, 2)
+
+	assert.Equal(t, "api", projects[0].Name)
+	assert.Equal(t, "testdata/cdk.out/ApiStack.template.json", projects[0].Metadata.Path)
+	assert.Equal(t, "Prod-DataStack", projects[1].Name)
+	assert.NotNil(t, resourcesByName(projects[1].Resources)["Table"])
+}
+
+func TestCDKProvider(t *testing.T) {
+	ctx := config.NewProjectContext(config.EmptyRunContext(), &config.Project{Path: "testdata/cdk.out"}, map[string]interface{}{})
+	p := NewCDKProvider(ctx, false)
+
+	projects, err := p.LoadResources(schema.NewUsageMapFromInterface(map[string]interface{}{}))
+	require.NoError(t, err)
+	require.Len(t, projects, 2)
+
+	api := projects[0]
+	assert.Equal(t, "api", api.Name)
+	assert.Equal(t, "testdata/cdk.out/ApiStack.template.json", api.Metadata.Path)
+
+	resources := resourcesByName(api.Resources)
+	assert.ElementsMatch(t, []string{"Handler", "HandlerRole", "CacheNestedStack", "CDKMetadata", "CacheNestedStack/CacheSubnetGroup"}, keys(resources))
+	assert.True(t, resources["CDKMetadata"].NoPrice)
+	assert.True(t, resources["CacheNestedStack/CacheSubnetGroup"].NoPrice)
+
+	nested := resources["CacheNestedStack"]
+	assert.False(t, nested.IsSkipped)
+	require.Len(t, nested.SubResources, 1)
+	assert.Equal(t, "Cache", nested.SubResources[0].Name)
+	assert.Equal(t, "cache.m5.large", attributeFilterValue(nested.SubResources[0].CostComponents[0], "instanceType"))
+
+	data := projects[1]
+	assert.Equal(t, "Prod-DataStack", data.Name)
+	table := resourcesByName(data.Resources)["Table"]
+	require.NotNil(t, table)
+	assert.Equal(t, "eu-central-1", *table.CostComponents[0].ProductFilter.Region)
+}
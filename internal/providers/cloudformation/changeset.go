@@ -0,0 +1,240 @@
+package cloudformation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/awslabs/goformation/v4/intrinsics"
+	"github.com/rs/zerolog/log"
+	"github.com/tidwall/gjson"
+)
+
+// ChangeSet is a CloudFormation change set as returned by
+// `aws cloudformation describe-change-set`.
+type ChangeSet struct {
+	ChangeSetID string         `json:"ChangeSetId"`
+	StackName   string         `json:"StackName"`
+	Parameters  []cliParameter `json:"Parameters"`
+	Changes     []Change       `json:"Changes"`
+}
+
+// Change is a single change in a CloudFormation change set.
+type Change struct {
+	Type           string         `json:"Type"`
+	ResourceChange ResourceChange `json:"ResourceChange"`
+}
+
+// ResourceChange is the change to a resource in a CloudFormation change set.
+// BeforeContext and AfterContext contain the resource properties as a JSON
+// string and are only included when the change set is described with
+// --include-property-values.
+type ResourceChange struct {
+	Action            string `json:"Action"`
+	LogicalResourceID string `json:"LogicalResourceId"`
+	ResourceType      string `json:"ResourceType"`
+	BeforeContext     string `json:"BeforeContext"`
+	AfterContext      string `json:"AfterContext"`
+}
+
+// LoadChangeSet reads the CloudFormation change set at path.
+func LoadChangeSet(path string) (*ChangeSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c ChangeSet
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid change set: %w", err)
+	}
+
+	return &c, nil
+}
+
+// IsChangeSet returns true if the JSON is a CloudFormation change set.
+func IsChangeSet(data []byte) bool {
+	if !gjson.ValidBytes(data) {
+		return false
+	}
+
+	parsed := gjson.ParseBytes(data)
+	if strings.Contains(parsed.Get("ChangeSetId").String(), ":changeSet/") {
+		return true
+	}
+
+	return parsed.Get("StackName").Exists() && parsed.Get("Changes").IsArray()
+}
+
+// Region returns the region from the change set ARN, or an empty string if the
+// change set doesn't have an ARN.
+func (c *ChangeSet) Region() string {
+	parts := strings.Split(c.ChangeSetID, ":")
+	if len(parts) < 4 || parts[0] != "arn" {
+		return ""
+	}
+
+	return parts[3]
+}
+
+// Templates returns the resources of the stack before and after the change set
+// is executed. A change set only contains the resources that change, so the
+// templates don't include any resources that are unchanged. Any Ref or Fn::Sub
+// to a stack parameter left in the resource properties is resolved with the
+// parameters of the change set.
+func (c *ChangeSet) Templates(region string) (past *Template, current *Template, err error) {
+	past = &Template{Region: region}
+	current = &Template{Region: region}
+
+	r := c.resolver(region)
+
+	var missingContext []string
+
+	for _, change := range c.Changes {
+		if change.Type != "Resource" {
+			continue
+		}
+
+		rc := change.ResourceChange
+
+		var before, after bool
+		switch rc.Action {
+		case "Add":
+			after = true
+		case "Remove":
+			before = true
+		case "Modify", "Import":
+			// Imported resources already exist so the cost doesn't change,
+			// but they are shown in both so they are part of the totals.
+			before = true
+			after = true
+		default:
+			log.Debug().Msgf("Skipping CloudFormation change for %s with action %s", rc.LogicalResourceID, rc.Action)
+			continue
+		}
+
+		if (before && rc.BeforeContext == "") || (after && rc.AfterContext == "") {
+			missingContext = append(missingContext, rc.LogicalResourceID)
+			continue
+		}
+
+		if before {
+			res, err := r.changeSetResource(rc, rc.BeforeContext)
+			if err != nil {
+				return nil, nil, err
+			}
+			past.Resources = append(past.Resources, res)
+		}
+
+		if after {
+			res, err := r.changeSetResource(rc, rc.AfterContext)
+			if err != nil {
+				return nil, nil, err
+			}
+			current.Resources = append(current.Resources, res)
+		}
+	}
+
+	if len(missingContext) > 0 {
+		sort.Strings(missingContext)
+		log.Warn().Msgf("Skipping CloudFormation resources %s as the change set does not include their properties. Use `aws cloudformation describe-change-set --include-property-values` to include them.", strings.Join(missingContext, ", "))
+	}
+
+	return past, current, nil
+}
+
+// resolver returns a resolver for the intrinsic functions in the resource
+// properties of the change set. Refs to the resources of the change set resolve
+// to their logical IDs, the same as for templates.
+func (c *ChangeSet) resolver(region string) *changeSetResolver {
+	if region == "" {
+		region = defaultRegion
+	}
+
+	params := cliParametersToMap(c.Parameters)
+
+	declarations := make(map[string]interface{}, len(params))
+	for name := range params {
+		declarations[name] = map[string]interface{}{"Type": "String"}
+	}
+
+	resources := make(map[string]interface{})
+	for _, change := range c.Changes {
+		if change.Type == "Resource" {
+			resources[change.ResourceChange.LogicalResourceID] = map[string]interface{}{"Type": change.ResourceChange.ResourceType}
+		}
+	}
+
+	return &changeSetResolver{
+		resolver: &resolver{
+			region:     region,
+			parameters: params,
+			resources:  resources,
+		},
+		declarations: declarations,
+	}
+}
+
+// changeSetResolver resolves the intrinsic functions in the resource properties
+// of a change set. The change set doesn't include the template, so the
+// parameters are declared from the parameters of the change set.
+type changeSetResolver struct {
+	*resolver
+	declarations map[string]interface{}
+}
+
+func (r *changeSetResolver) changeSetResource(rc ResourceChange, resourceContext string) (*TemplateResource, error) {
+	if !gjson.Valid(resourceContext) {
+		return nil, fmt.Errorf("invalid resource context for %s in change set", rc.LogicalResourceID)
+	}
+
+	// The context contains the resource properties under a Properties key.
+	properties := gjson.Parse(resourceContext)
+	if p := properties.Get("Properties"); p.Exists() {
+		properties = p
+	}
+
+	resolved, err := r.resolveProperties(properties)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource context for %s in change set: %w", rc.LogicalResourceID, err)
+	}
+
+	raw := fmt.Sprintf(`{"Type":%q,"Properties":%s}`, rc.ResourceType, resolved.Raw)
+
+	return &TemplateResource{
+		LogicalID:  rc.LogicalResourceID,
+		Type:       rc.ResourceType,
+		Properties: resolved,
+		CFResource: decodeCFResource(rc.LogicalResourceID, raw),
+	}, nil
+}
+
+func (r *changeSetResolver) resolveProperties(properties gjson.Result) (gjson.Result, error) {
+	var value interface{}
+	if err := json.Unmarshal([]byte(properties.Raw), &value); err != nil {
+		return gjson.Result{}, err
+	}
+
+	input, err := json.Marshal(map[string]interface{}{
+		"Parameters": r.declarations,
+		"Properties": value,
+	})
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	processed, err := intrinsics.ProcessJSON(input, &intrinsics.ProcessorOptions{
+		IntrinsicHandlerOverrides: map[string]intrinsics.IntrinsicHandler{
+			"Ref":        r.ref,
+			"Fn::GetAtt": r.getAtt,
+			"Fn::Sub":    r.sub,
+		},
+	})
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	return gjson.GetBytes(processed, "Properties"), nil
+}
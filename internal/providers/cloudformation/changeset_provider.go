@@ -0,0 +1,88 @@
+package cloudformation
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+type ChangeSetProvider struct {
+	ctx                  *config.ProjectContext
+	Path                 string
+	includePastResources bool
+}
+
+func NewChangeSetProvider(ctx *config.ProjectContext, includePastResources bool) schema.Provider {
+	return &ChangeSetProvider{
+		ctx:                  ctx,
+		Path:                 ctx.ProjectConfig.Path,
+		includePastResources: includePastResources,
+	}
+}
+
+func (p *ChangeSetProvider) Context() *config.ProjectContext { return p.ctx }
+
+func (p *ChangeSetProvider) Type() string {
+	return "cloudformation_change_set"
+}
+
+func (p *ChangeSetProvider) DisplayType() string {
+	return "CloudFormation change set"
+}
+
+func (p *ChangeSetProvider) AddMetadata(metadata *schema.ProjectMetadata) {
+	metadata.ConfigSha = p.ctx.ProjectConfig.ConfigSha
+}
+
+// LoadResources returns the resources that are added, modified or removed by
+// the change set. The past resources are the resources before the change set
+// is executed so the diff shows the cost change of the stack update.
+func (p *ChangeSetProvider) LoadResources(usage schema.UsageMap) ([]*schema.Project, error) {
+	changeSet, err := LoadChangeSet(p.Path)
+	if err != nil {
+		return []*schema.Project{}, errors.Wrap(err, "Error reading CloudFormation change set file")
+	}
+
+	region := changeSet.Region()
+	if region == "" {
+		region = projectRegion(p.ctx)
+	}
+
+	metadata := schema.DetectProjectMetadata(p.ctx.ProjectConfig.Path)
+	metadata.Type = p.Type()
+	p.AddMetadata(metadata)
+	name := p.ctx.ProjectConfig.Name
+	if name == "" {
+		name = changeSet.StackName
+	}
+	if name == "" {
+		name = metadata.GenerateProjectName(p.ctx.RunContext.VCSMetadata.Remote, p.ctx.RunContext.IsCloudEnabled())
+	}
+
+	project := schema.NewProject(name, metadata)
+
+	pastTemplate, template, err := changeSet.Templates(region)
+	if err != nil {
+		return []*schema.Project{project}, errors.Wrap(err, "Error reading CloudFormation change set file")
+	}
+
+	parser := NewParser(p.ctx)
+	_, resources, err := parser.parseTemplate(template, usage)
+	if err != nil {
+		return []*schema.Project{project}, errors.Wrap(err, "Error parsing CloudFormation change set file")
+	}
+
+	project.Resources = resources
+
+	if p.includePastResources {
+		_, pastResources, err := parser.parseTemplate(pastTemplate, usage)
+		if err != nil {
+			return []*schema.Project{project}, errors.Wrap(err, "Error parsing CloudFormation change set file")
+		}
+
+		project.PastResources = pastResources
+	}
+
+	return []*schema.Project{project}, nil
+}
@@ -0,0 +1,73 @@
+package cloudformation
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestIsChangeSet(t *testing.T) {
+	b, err := os.ReadFile("testdata/change_set.json")
+	require.NoError(t, err)
+	assert.True(t, IsChangeSet(b))
+
+	assert.False(t, IsChangeSet([]byte(`{"AWSTemplateFormatVersion":"2010-09-09","Resources":{}}`)))
+	assert.False(t, IsChangeSet([]byte(`not json`)))
+}
+
+func TestChangeSetProvider(t *testing.T) {
+	ctx := config.NewProjectContext(config.EmptyRunContext(), &config.Project{Path: "testdata/change_set.json"}, map[string]interface{}{})
+	p := NewChangeSetProvider(ctx, true)
+
+	projects, err := p.LoadResources(schema.NewUsageMapFromInterface(map[string]interface{}{}))
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+
+	project := projects[0]
+	assert.Equal(t, "web", project.Name)
+
+	current := resourcesByName(project.Resources)
+	past := resourcesByName(project.PastResources)
+
+	assert.ElementsMatch(t, []string{"WebInstance", "Function"}, keys(current))
+	assert.ElementsMatch(t, []string{"WebInstance", "OldCache"}, keys(past))
+
+	// The instance type of the change set is a Ref to the InstanceType
+	// parameter of the change set.
+	assert.Equal(t, "m5.xlarge", attributeFilterValue(current["WebInstance"].CostComponents[0], "instanceType"))
+	assert.Equal(t, "m5.large", attributeFilterValue(past["WebInstance"].CostComponents[0], "instanceType"))
+	assert.Equal(t, "eu-west-2", *current["Function"].CostComponents[0].ProductFilter.Region)
+}
+
+func resourcesByName(resources []*schema.Resource) map[string]*schema.Resource {
+	m := make(map[string]*schema.Resource, len(resources))
+	for _, r := range resources {
+		m[r.Name] = r
+	}
+
+	return m
+}
+
+func keys(m map[string]*schema.Resource) []string {
+	k := make([]string, 0, len(m))
+	for name := range m {
+		k = append(k, name)
+	}
+
+	return k
+}
+
+func attributeFilterValue(c *schema.CostComponent, key string) string {
+	for _, f := range c.ProductFilter.AttributeFilters {
+		if f.Key == key && f.Value != nil {
+			return *f.Value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,67 @@
+package cloudformation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// cliParameter is a parameter in the format used by the AWS CLI for
+// `aws cloudformation create-stack --parameters file://params.json` and in the
+// Parameters of a change set.
+type cliParameter struct {
+	ParameterKey   string      `json:"ParameterKey"`
+	ParameterValue interface{} `json:"ParameterValue"`
+}
+
+// LoadParameters reads the CloudFormation parameter values from a JSON file.
+// The following formats are supported:
+//
+//   - The AWS CLI format, a list of ParameterKey/ParameterValue objects.
+//   - The CodePipeline template configuration format, an object with a
+//     Parameters map.
+//   - A plain map of parameter names to values.
+func LoadParameters(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := parseParameters(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CloudFormation parameters file %s: %w", path, err)
+	}
+
+	return params, nil
+}
+
+func parseParameters(data []byte) (map[string]interface{}, error) {
+	var list []cliParameter
+	if err := json.Unmarshal(data, &list); err == nil {
+		return cliParametersToMap(list), nil
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	if nested, ok := m["Parameters"].(map[string]interface{}); ok {
+		return nested, nil
+	}
+
+	return m, nil
+}
+
+func cliParametersToMap(list []cliParameter) map[string]interface{} {
+	params := make(map[string]interface{}, len(list))
+	for _, p := range list {
+		if p.ParameterKey == "" || p.ParameterValue == nil {
+			continue
+		}
+
+		params[p.ParameterKey] = p.ParameterValue
+	}
+
+	return params
+}
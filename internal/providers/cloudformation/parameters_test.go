@@ -0,0 +1,52 @@
+package cloudformation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseParameters(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]interface{}
+	}{
+		{
+			name:  "AWS CLI format",
+			input: `[{"ParameterKey":"Env","ParameterValue":"prod"},{"ParameterKey":"Previous","UsePreviousValue":true}]`,
+			expected: map[string]interface{}{
+				"Env": "prod",
+			},
+		},
+		{
+			name:  "CodePipeline template configuration",
+			input: `{"Parameters":{"Env":"prod"},"Tags":{"Team":"web"}}`,
+			expected: map[string]interface{}{
+				"Env": "prod",
+			},
+		},
+		{
+			name:  "plain map",
+			input: `{"Env":"prod","Size":"2"}`,
+			expected: map[string]interface{}{
+				"Env":  "prod",
+				"Size": "2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := parseParameters([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, params)
+		})
+	}
+}
+
+func TestParseParametersInvalid(t *testing.T) {
+	_, err := parseParameters([]byte(`"not parameters"`))
+	assert.Error(t, err)
+}
@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/awslabs/goformation/v4/cloudformation"
-
 	"github.com/tidwall/gjson"
 
 	"github.com/infracost/infracost/internal/config"
@@ -42,8 +40,9 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 		res := registryItem.RFunc(d, u)
 		if res != nil {
 			res.ResourceType = d.Type
-			// TODO: Figure out how to set tags.  For now, have the RFunc set them.
-			// res.Tags = d.Tags
+			if res.Tags == nil {
+				res.Tags = d.Tags
+			}
 			if u != nil {
 				res.EstimationSummary = u.CalcEstimationSummary()
 			}
@@ -60,24 +59,90 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 	}
 }
 
-func (p *Parser) parseTemplate(t *cloudformation.Template, usage schema.UsageMap) ([]*schema.Resource, []*schema.Resource, error) {
+func (p *Parser) parseTemplate(t *Template, usage schema.UsageMap) ([]*schema.Resource, []*schema.Resource, error) {
 	baseResources := p.loadUsageFileResources(usage)
 
 	var resources []*schema.Resource
 	resources = append(resources, baseResources...)
+	resources = append(resources, p.parseResources(t, usage)...)
+
+	return resources, resources, nil
+}
+
+// parseResources returns the resources of the template, without any of the
+// usage file only resources.
+func (p *Parser) parseResources(t *Template, usage schema.UsageMap) []*schema.Resource {
+	var resources []*schema.Resource
+
+	resourceDatas := make(map[string]*schema.ResourceData, len(t.Resources))
+	for _, r := range t.Resources {
+		rawValues := schema.AddRawValue(r.Properties, "region", t.Region)
 
-	for name, d := range t.Resources {
-		tags := map[string]string{} // TODO: Where do I get tags?
-		usageData := usage.Get(name)
+		d := schema.NewCFResourceData(r.Type, "aws", r.LogicalID, parseTags(r.Properties.Get("Tags")), rawValues, r.CFResource)
+		d.UsageData = usage.Get(r.LogicalID)
+		resourceDatas[r.LogicalID] = d
+	}
 
-		resourceData := schema.NewCFResourceData(d.AWSCloudFormationType(), "aws", name, &tags, d)
+	p.parseReferences(t, resourceDatas)
 
-		if r := p.createResource(resourceData, usageData); r != nil {
-			resources = append(resources, r)
+	for _, r := range t.Resources {
+		d := resourceDatas[r.LogicalID]
+		if res := p.createResource(d, d.UsageData); res != nil {
+			resources = append(resources, res)
 		}
 	}
 
-	return resources, resources, nil
+	return resources
+}
+
+// parseReferences adds the references for the ReferenceAttributes of each
+// resource. Ref and Fn::GetAtt to other resources are resolved to the logical
+// ID of the resource when the template is loaded, so the value of the
+// attribute is used to find the referenced resource.
+func (p *Parser) parseReferences(t *Template, resourceDatas map[string]*schema.ResourceData) {
+	registryMap := GetResourceRegistryMap()
+
+	for _, r := range t.Resources {
+		d := resourceDatas[r.LogicalID]
+
+		registryItem, ok := (*registryMap)[d.Type]
+		if !ok {
+			continue
+		}
+
+		for _, attr := range registryItem.ReferenceAttributes {
+			values := []gjson.Result{d.Get(attr)}
+			if values[0].IsArray() {
+				values = values[0].Array()
+			}
+
+			for _, v := range values {
+				if ref, ok := resourceDatas[v.String()]; ok {
+					d.AddReference(attr, ref, nil)
+				}
+			}
+		}
+	}
+}
+
+// parseTags returns the tags of a resource. Most resources use a list of
+// Key/Value objects, but some such as AWS::EKS::Nodegroup use a map.
+func parseTags(v gjson.Result) *map[string]string {
+	tags := map[string]string{}
+
+	if v.IsArray() {
+		for _, t := range v.Array() {
+			if t.Get("Key").Exists() {
+				tags[t.Get("Key").String()] = t.Get("Value").String()
+			}
+		}
+	} else if v.IsObject() {
+		for k, val := range v.Map() {
+			tags[k] = val.String()
+		}
+	}
+
+	return &tags
 }
 
 func (p *Parser) loadUsageFileResources(u schema.UsageMap) []*schema.Resource {
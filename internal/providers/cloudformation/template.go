@@ -0,0 +1,312 @@
+package cloudformation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/awslabs/goformation/v4/cloudformation"
+	"github.com/awslabs/goformation/v4/intrinsics"
+	"github.com/rs/zerolog/log"
+	"github.com/tidwall/gjson"
+)
+
+const defaultRegion = "us-east-1"
+
+var subVariableRegex = regexp.MustCompile(`\$\{([^!}][^}]*)\}`)
+
+// Template is a CloudFormation template where the intrinsic functions, parameter
+// defaults and conditions have been resolved.
+type Template struct {
+	Region    string
+	Resources []*TemplateResource
+}
+
+// TemplateResource is a resource in a resolved CloudFormation template.
+type TemplateResource struct {
+	LogicalID  string
+	Type       string
+	Properties gjson.Result
+	Metadata   gjson.Result
+	// CFResource is the typed goformation resource. This is nil if the
+	// resource properties could not be decoded into the goformation type, e.g.
+	// because the template uses properties that goformation doesn't know about.
+	CFResource cloudformation.Resource
+}
+
+// LoadTemplate reads the CloudFormation template at path and resolves it. The
+// following are resolved:
+//
+//   - Ref to parameters resolves to the parameter default value, or the value in
+//     parameterOverrides if one is set.
+//   - Ref to pseudo parameters such as AWS::Region resolves using the region.
+//   - Ref and Fn::GetAtt to other resources resolve to the logical ID of the
+//     resource, so that they can be used to find the referenced resource.
+//   - Fn::Sub using the above.
+//   - Conditions are evaluated. Resources whose condition is false are removed
+//     and Fn::If uses the evaluated condition.
+func LoadTemplate(path string, region string, parameterOverrides map[string]interface{}) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if region == "" {
+		region = defaultRegion
+	}
+
+	// Convert the template to JSON without resolving any intrinsic functions so
+	// that we can remove the parts that goformation doesn't handle correctly.
+	noProcess := &intrinsics.ProcessorOptions{NoProcess: true}
+	var raw []byte
+	if strings.HasSuffix(path, ".json") {
+		raw, err = intrinsics.ProcessJSON(data, noProcess)
+	} else {
+		raw, err = intrinsics.ProcessYAML(data, noProcess)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var tmpl map[string]interface{}
+	if err := json.Unmarshal(raw, &tmpl); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	resources, _ := tmpl["Resources"].(map[string]interface{})
+
+	// goformation replaces any object with a Condition key with the value of
+	// the condition when evaluating conditions, which would replace the whole
+	// resource, so we handle resource conditions ourselves.
+	resourceConditions := map[string]string{}
+	for name, r := range resources {
+		if m, ok := r.(map[string]interface{}); ok {
+			if c, ok := m["Condition"].(string); ok {
+				resourceConditions[name] = c
+				delete(m, "Condition")
+			}
+		}
+	}
+
+	// Outputs aren't used and can have conditions that hit the same issue.
+	delete(tmpl, "Outputs")
+
+	r := &resolver{
+		region:     region,
+		parameters: parameterOverrides,
+		resources:  resources,
+	}
+
+	input, err := json.Marshal(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	processed, err := intrinsics.ProcessJSON(input, &intrinsics.ProcessorOptions{
+		EvaluateConditions: true,
+		IntrinsicHandlerOverrides: map[string]intrinsics.IntrinsicHandler{
+			"Ref":        r.ref,
+			"Fn::GetAtt": r.getAtt,
+			"Fn::Sub":    r.sub,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := gjson.ParseBytes(processed)
+	conditions := parsed.Get("Conditions")
+
+	t := &Template{Region: region}
+
+	names := make([]string, 0, len(resources))
+	for name := range parsed.Get("Resources").Map() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if c, ok := resourceConditions[name]; ok {
+			v := conditions.Get(gjson.Escape(c))
+			if v.Type != gjson.True {
+				log.Debug().Msgf("Skipping CloudFormation resource %s as condition %s is not true", name, c)
+				continue
+			}
+		}
+
+		res := parsed.Get("Resources." + gjson.Escape(name))
+		t.Resources = append(t.Resources, &TemplateResource{
+			LogicalID:  name,
+			Type:       res.Get("Type").String(),
+			Properties: res.Get("Properties"),
+			Metadata:   res.Get("Metadata"),
+			CFResource: decodeCFResource(name, res.Raw),
+		})
+	}
+
+	return t, nil
+}
+
+// Resource returns the resource with the logical ID, or nil if the template
+// doesn't contain the resource.
+func (t *Template) Resource(logicalID string) *TemplateResource {
+	for _, r := range t.Resources {
+		if r.LogicalID == logicalID {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func decodeCFResource(name string, raw string) cloudformation.Resource {
+	var resources cloudformation.Resources
+
+	err := json.Unmarshal([]byte(fmt.Sprintf(`{%q:%s}`, name, raw)), &resources)
+	if err != nil {
+		log.Debug().Msgf("Could not decode CloudFormation resource %s: %s", name, err)
+		return nil
+	}
+
+	return resources[name]
+}
+
+// resolver implements the intrinsic functions that goformation doesn't resolve
+// or that need to know the region.
+type resolver struct {
+	region     string
+	parameters map[string]interface{}
+	resources  map[string]interface{}
+}
+
+func (r *resolver) ref(name string, input interface{}, template interface{}) interface{} {
+	v, ok := input.(string)
+	if !ok {
+		return nil
+	}
+
+	switch v {
+	case "AWS::Region":
+		return r.region
+	case "AWS::Partition":
+		if strings.HasPrefix(r.region, "cn-") {
+			return "aws-cn"
+		}
+		if strings.HasPrefix(r.region, "us-gov-") {
+			return "aws-us-gov"
+		}
+		return "aws"
+	case "AWS::URLSuffix":
+		if strings.HasPrefix(r.region, "cn-") {
+			return "amazonaws.com.cn"
+		}
+		return "amazonaws.com"
+	}
+
+	if p, ok := r.parameter(v, template); ok {
+		return p
+	}
+
+	if _, ok := r.resources[v]; ok {
+		return v
+	}
+
+	return intrinsics.Ref(name, input, template)
+}
+
+// parameter returns the value of a template parameter. Number parameters are
+// returned as numbers so they can be used for numeric properties.
+func (r *resolver) parameter(name string, template interface{}) (interface{}, bool) {
+	t, _ := template.(map[string]interface{})
+	params, _ := t["Parameters"].(map[string]interface{})
+	param, ok := params[name].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	value, ok := r.parameters[name]
+	if !ok {
+		value, ok = param["Default"]
+		if !ok {
+			return nil, true
+		}
+	}
+
+	if param["Type"] == "Number" {
+		if s, ok := value.(string); ok {
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				return f, true
+			}
+		}
+	}
+
+	return value, true
+}
+
+// getAtt resolves to the logical ID of the resource. The attribute values
+// aren't known until the stack is deployed, but this allows the attribute to be
+// used to find the referenced resource.
+func (r *resolver) getAtt(name string, input interface{}, template interface{}) interface{} {
+	var logicalID string
+
+	switch v := input.(type) {
+	case []interface{}:
+		if len(v) > 0 {
+			logicalID, _ = v[0].(string)
+		}
+	case string:
+		logicalID, _, _ = strings.Cut(v, ".")
+	}
+
+	if _, ok := r.resources[logicalID]; ok {
+		return logicalID
+	}
+
+	return nil
+}
+
+func (r *resolver) sub(name string, input interface{}, template interface{}) interface{} {
+	var src string
+	var vars map[string]interface{}
+
+	switch v := input.(type) {
+	case string:
+		src = v
+	case []interface{}:
+		if len(v) != 2 {
+			return nil
+		}
+		src, _ = v[0].(string)
+		vars, _ = v[1].(map[string]interface{})
+	default:
+		return nil
+	}
+
+	return subVariableRegex.ReplaceAllStringFunc(src, func(m string) string {
+		variable := m[2 : len(m)-1]
+
+		var resolved interface{}
+		if v, ok := vars[variable]; ok {
+			resolved = v
+		} else if strings.Contains(variable, ".") && !strings.HasPrefix(variable, "AWS::") {
+			resolved = r.getAtt("Fn::GetAtt", variable, template)
+		} else {
+			resolved = r.ref("Ref", variable, template)
+		}
+
+		switch v := resolved.(type) {
+		case string:
+			return v
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			return strconv.FormatBool(v)
+		}
+
+		return ""
+	})
+}
@@ -1,7 +1,8 @@
 package cloudformation
 
 import (
-	"github.com/awslabs/goformation/v4"
+	"os"
+
 	"github.com/pkg/errors"
 
 	"github.com/infracost/infracost/internal/config"
@@ -37,7 +38,18 @@ func (p *TemplateProvider) AddMetadata(metadata *schema.ProjectMetadata) {
 }
 
 func (p *TemplateProvider) LoadResources(usage schema.UsageMap) ([]*schema.Project, error) {
-	template, err := goformation.Open(p.Path)
+	var params map[string]interface{}
+	if p.ctx.ProjectConfig.CloudFormationParametersFile != "" {
+		var err error
+		params, err = LoadParameters(p.ctx.ProjectConfig.CloudFormationParametersFile)
+		if err != nil {
+			return []*schema.Project{}, errors.Wrap(err, "Error reading CloudFormation parameters file")
+		}
+	}
+
+	region := projectRegion(p.ctx)
+
+	template, err := LoadTemplate(p.Path, region, params)
 	if err != nil {
 		return []*schema.Project{}, errors.Wrap(err, "Error reading CloudFormation template file")
 	}
@@ -57,6 +69,20 @@ func (p *TemplateProvider) LoadResources(usage schema.UsageMap) ([]*schema.Proje
 		return []*schema.Project{project}, errors.Wrap(err, "Error parsing CloudFormation template file")
 	}
 
+	// If the currently deployed template is given then use it for the past
+	// resources so the diff shows the cost change of the stack update.
+	if pastPath := p.ctx.ProjectConfig.CloudFormationPastTemplate; pastPath != "" {
+		pastTemplate, err := LoadTemplate(pastPath, region, params)
+		if err != nil {
+			return []*schema.Project{project}, errors.Wrap(err, "Error reading past CloudFormation template file")
+		}
+
+		pastResources, _, err = parser.parseTemplate(pastTemplate, usage)
+		if err != nil {
+			return []*schema.Project{project}, errors.Wrap(err, "Error parsing past CloudFormation template file")
+		}
+	}
+
 	project.PastResources = pastResources
 	project.Resources = resources
 
@@ -66,3 +92,23 @@ func (p *TemplateProvider) LoadResources(usage schema.UsageMap) ([]*schema.Proje
 
 	return []*schema.Project{project}, nil
 }
+
+// projectRegion returns the region the stack will be deployed to. CloudFormation
+// templates don't include the region, so this is taken from the AWS_REGION or
+// AWS_DEFAULT_REGION environment variables in the project config, then the
+// environment, and defaults to us-east-1.
+func projectRegion(ctx *config.ProjectContext) string {
+	for _, k := range []string{"AWS_REGION", "AWS_DEFAULT_REGION"} {
+		if v := ctx.ProjectConfig.Env[k]; v != "" {
+			return v
+		}
+	}
+
+	for _, k := range []string{"AWS_REGION", "AWS_DEFAULT_REGION"} {
+		if v := os.Getenv(k); v != "" {
+			return v
+		}
+	}
+
+	return defaultRegion
+}
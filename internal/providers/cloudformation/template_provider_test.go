@@ -0,0 +1,33 @@
+package cloudformation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestTemplateProviderPastTemplate(t *testing.T) {
+	ctx := config.NewProjectContext(config.EmptyRunContext(), &config.Project{
+		Path:                         "testdata/template.yml",
+		CloudFormationParametersFile: "testdata/parameters.json",
+		CloudFormationPastTemplate:   "testdata/past_template.yml",
+	}, map[string]interface{}{})
+	p := NewTemplateProvider(ctx, true)
+
+	projects, err := p.LoadResources(schema.NewUsageMapFromInterface(map[string]interface{}{}))
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+
+	current := resourcesByName(projects[0].Resources)
+	past := resourcesByName(projects[0].PastResources)
+
+	assert.Equal(t, "t3.large", attributeFilterValue(current["WebInstance"].CostComponents[0], "instanceType"))
+	assert.NotNil(t, current["DevCache"], "the parameters file should set Env to dev")
+
+	assert.ElementsMatch(t, []string{"WebInstance"}, keys(past))
+	assert.Equal(t, "t3.micro", attributeFilterValue(past["WebInstance"].CostComponents[0], "instanceType"))
+}
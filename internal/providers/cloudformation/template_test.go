@@ -0,0 +1,91 @@
+package cloudformation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestLoadTemplate(t *testing.T) {
+	tmpl, err := LoadTemplate("testdata/template.yml", "eu-west-1", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "eu-west-1", tmpl.Region)
+	assert.Nil(t, tmpl.Resource("DevCache"), "resources with a false condition should be removed")
+
+	instance := tmpl.Resource("WebInstance")
+	require.NotNil(t, instance)
+	assert.Equal(t, "AWS::EC2::Instance", instance.Type)
+	assert.Equal(t, "m5.large", instance.Properties.Get("InstanceType").String())
+	assert.Equal(t, "prod-web", instance.Properties.Get("Tags.0.Value").String())
+	assert.NotNil(t, instance.CFResource)
+
+	db := tmpl.Resource("Database")
+	require.NotNil(t, db)
+	assert.Equal(t, "db.m5.large", db.Properties.Get("DBInstanceClass").String())
+	assert.True(t, db.Properties.Get("MultiAZ").Bool())
+
+	fn := tmpl.Resource("Function")
+	require.NotNil(t, fn)
+	assert.Equal(t, float64(1024), fn.Properties.Get("MemorySize").Value())
+	assert.Equal(t, "FunctionRole", fn.Properties.Get("Role").String())
+
+	svc := tmpl.Resource("Service")
+	require.NotNil(t, svc)
+	assert.Equal(t, "TaskDefinition", svc.Properties.Get("TaskDefinition").String())
+
+	lb := tmpl.Resource("LoadBalancer")
+	require.NotNil(t, lb)
+	assert.Equal(t, "prod-eu-west-1", lb.Properties.Get("Name").String())
+}
+
+func TestLoadTemplateParameterOverrides(t *testing.T) {
+	tmpl, err := LoadTemplate("testdata/template.yml", "", map[string]interface{}{"Env": "dev"})
+	require.NoError(t, err)
+
+	assert.Equal(t, defaultRegion, tmpl.Region)
+	assert.NotNil(t, tmpl.Resource("DevCache"))
+	assert.Equal(t, "db.t3.micro", tmpl.Resource("Database").Properties.Get("DBInstanceClass").String())
+}
+
+func TestParseTemplate(t *testing.T) {
+	tmpl, err := LoadTemplate("testdata/template.yml", "eu-west-1", nil)
+	require.NoError(t, err)
+
+	p := NewParser(config.NewProjectContext(config.EmptyRunContext(), &config.Project{}, map[string]interface{}{}))
+	_, resources, err := p.parseTemplate(tmpl, schema.NewUsageMapFromInterface(map[string]interface{}{}))
+	require.NoError(t, err)
+
+	byName := map[string]*schema.Resource{}
+	for _, r := range resources {
+		byName[r.Name] = r
+	}
+
+	instance := byName["WebInstance"]
+	require.NotNil(t, instance)
+	assert.Equal(t, "AWS::EC2::Instance", instance.ResourceType)
+	assert.Equal(t, map[string]string{"Name": "prod-web"}, *instance.Tags)
+	assert.Equal(t, "eu-west-1", *instance.CostComponents[0].ProductFilter.Region)
+	require.Len(t, instance.SubResources, 2)
+	assert.Equal(t, "root_block_device", instance.SubResources[0].Name)
+
+	fn := byName["Function"]
+	require.NotNil(t, fn)
+	assert.False(t, fn.IsSkipped)
+
+	svc := byName["Service"]
+	require.NotNil(t, svc)
+	require.NotEmpty(t, svc.CostComponents)
+	assert.Equal(t, "Per GB per hour", svc.CostComponents[0].Name)
+	assert.Equal(t, "4", svc.CostComponents[0].HourlyQuantity.String())
+
+	assert.True(t, byName["FunctionRole"].NoPrice)
+	assert.True(t, byName["TaskDefinition"].NoPrice)
+	assert.NotNil(t, byName["Database"])
+	assert.NotNil(t, byName["LoadBalancer"])
+	assert.Nil(t, byName["DevCache"])
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/infracost/infracost/internal/hcl"
 	"github.com/infracost/infracost/internal/logging"
 	"github.com/infracost/infracost/internal/providers/cloudformation"
+	"github.com/infracost/infracost/internal/providers/pulumi"
 	"github.com/infracost/infracost/internal/providers/terraform"
 	"github.com/infracost/infracost/internal/schema"
 )
@@ -48,6 +49,14 @@ func Detect(ctx *config.RunContext, project *config.Project, includePastResource
 		return []schema.Provider{terraform.NewStateJSONProvider(projectContext, includePastResources)}, nil
 	case ProjectTypeCloudFormation:
 		return []schema.Provider{cloudformation.NewTemplateProvider(projectContext, includePastResources)}, nil
+	case ProjectTypeCloudFormationChangeSet:
+		return []schema.Provider{cloudformation.NewChangeSetProvider(projectContext, includePastResources)}, nil
+	case ProjectTypeCDK:
+		return []schema.Provider{cloudformation.NewCDKProvider(projectContext, includePastResources)}, nil
+	case ProjectTypePulumiPreviewJSON:
+		return []schema.Provider{pulumi.NewPreviewJSONProvider(projectContext, includePastResources)}, nil
+	case ProjectTypePulumiStateJSON:
+		return []schema.Provider{pulumi.NewStateJSONProvider(projectContext, includePastResources)}, nil
 	}
 
 	pathOverrides := make([]hcl.PathOverrideConfig, len(ctx.Config.Autodetect.PathOverrides))
@@ -178,20 +187,40 @@ func autodetectedRootToProviders(pl *hcl.ProjectLocator, projectContext *config.
 type ProjectType string
 
 var (
-	ProjectTypeTerraformPlanJSON   ProjectType = "terraform_plan_json"
-	ProjectTypeTerraformPlanBinary ProjectType = "terraform_plan_binary"
-	ProjectTypeTerraformCLI        ProjectType = "terraform_cli"
-	ProjectTypeTerragruntCLI       ProjectType = "terragrunt_cli"
-	ProjectTypeTerraformStateJSON  ProjectType = "terraform_state_json"
-	ProjectTypeCloudFormation      ProjectType = "cloudformation"
-	ProjectTypeAutodetect          ProjectType = "autodetect"
+	ProjectTypeTerraformPlanJSON       ProjectType = "terraform_plan_json"
+	ProjectTypeTerraformPlanBinary     ProjectType = "terraform_plan_binary"
+	ProjectTypeTerraformCLI            ProjectType = "terraform_cli"
+	ProjectTypeTerragruntCLI           ProjectType = "terragrunt_cli"
+	ProjectTypeTerraformStateJSON      ProjectType = "terraform_state_json"
+	ProjectTypeCloudFormation          ProjectType = "cloudformation"
+	ProjectTypeCloudFormationChangeSet ProjectType = "cloudformation_change_set"
+	ProjectTypeCDK                     ProjectType = "cdk"
+	ProjectTypePulumiPreviewJSON       ProjectType = "pulumi_preview_json"
+	ProjectTypePulumiStateJSON         ProjectType = "pulumi_state_json"
+	ProjectTypeAutodetect              ProjectType = "autodetect"
 )
 
 func DetectProjectType(path string, forceCLI bool) ProjectType {
+	if cloudformation.IsCDKOutput(path) {
+		return ProjectTypeCDK
+	}
+
+	if isCloudFormationChangeSet(path) {
+		return ProjectTypeCloudFormationChangeSet
+	}
+
 	if isCloudFormationTemplate(path) {
 		return ProjectTypeCloudFormation
 	}
 
+	if isPulumiPreviewJSON(path) {
+		return ProjectTypePulumiPreviewJSON
+	}
+
+	if isPulumiStateJSON(path) {
+		return ProjectTypePulumiStateJSON
+	}
+
 	if isTerraformPlanJSON(path) {
 		return ProjectTypeTerraformPlanJSON
 	}
@@ -313,10 +342,49 @@ func isTerragruntNestedDir(path string, maxDepth int) bool {
 	return false
 }
 
+func isPulumiPreviewJSON(path string) bool {
+	if filepath.Ext(path) != ".json" {
+		return false
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	return pulumi.IsPreviewJSON(b)
+}
+
+func isPulumiStateJSON(path string) bool {
+	if filepath.Ext(path) != ".json" {
+		return false
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	return pulumi.IsStackExport(b)
+}
+
 // goformation lib is not threadsafe, so we run this check synchronously
 // See: https://github.com/awslabs/goformation/issues/363
 var cfMux = &sync.Mutex{}
 
+func isCloudFormationChangeSet(path string) bool {
+	if filepath.Ext(path) != ".json" {
+		return false
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	return cloudformation.IsChangeSet(b)
+}
+
 func isCloudFormationTemplate(path string) bool {
 	cfMux.Lock()
 	defer cfMux.Unlock()
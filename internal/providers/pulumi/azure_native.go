@@ -0,0 +1,127 @@
+package pulumi
+
+import (
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// azureNativeTranslator converts the inputs of an azure-native resource to the
+// Terraform resource type and attributes of the equivalent azurerm resource.
+type azureNativeTranslator func(inputs gjson.Result) (string, map[string]interface{})
+
+// azureNativeTranslators are keyed by the module and resource of the
+// azure-native token. Unlike the AWS, Azure Classic and GCP providers, the
+// azure-native provider is generated from the Azure Resource Manager API so its
+// properties don't match the Terraform attributes and have to be mapped for
+// each resource.
+var azureNativeTranslators = map[string]azureNativeTranslator{
+	"compute:VirtualMachine":   translateAzureNativeVirtualMachine,
+	"compute:Disk":             translateAzureNativeDisk,
+	"resources:ResourceGroup":  translateAzureNativeResourceGroup,
+	"storage:StorageAccount":   translateAzureNativeStorageAccount,
+	"network:VirtualNetwork":   translateAzureNativeFree("azurerm_virtual_network"),
+	"network:Subnet":           translateAzureNativeFree("azurerm_subnet"),
+	"network:NetworkInterface": translateAzureNativeFree("azurerm_network_interface"),
+}
+
+func azureNativeResource(token string, inputs gjson.Result) (string, map[string]interface{}) {
+	_, module, resource, ok := parseToken(token)
+	if !ok {
+		return "", nil
+	}
+
+	translator, ok := azureNativeTranslators[module+":"+resource]
+	if !ok {
+		return "", nil
+	}
+
+	return translator(inputs)
+}
+
+func translateAzureNativeVirtualMachine(inputs gjson.Result) (string, map[string]interface{}) {
+	osDisk := inputs.Get("storageProfile.osDisk")
+
+	resourceType := "azurerm_linux_virtual_machine"
+	if inputs.Get("osProfile.windowsConfiguration").Exists() || strings.EqualFold(osDisk.Get("osType").String(), "Windows") {
+		resourceType = "azurerm_windows_virtual_machine"
+	}
+
+	values := map[string]interface{}{
+		"location": inputs.Get("location").String(),
+		"size":     inputs.Get("hardwareProfile.vmSize").String(),
+		"additional_capabilities": []interface{}{
+			map[string]interface{}{
+				"ultra_ssd_enabled": inputs.Get("additionalCapabilities.ultraSSDEnabled").Bool(),
+			},
+		},
+	}
+
+	if osDisk.Exists() {
+		disk := map[string]interface{}{
+			"storage_account_type": osDisk.Get("managedDisk.storageAccountType").String(),
+		}
+		if v := osDisk.Get("diskSizeGB"); v.Exists() {
+			disk["disk_size_gb"] = v.Int()
+		}
+
+		values["os_disk"] = []interface{}{disk}
+	}
+
+	return resourceType, values
+}
+
+func translateAzureNativeDisk(inputs gjson.Result) (string, map[string]interface{}) {
+	values := map[string]interface{}{
+		"location":             inputs.Get("location").String(),
+		"storage_account_type": inputs.Get("sku.name").String(),
+		"disk_size_gb":         inputs.Get("diskSizeGB").Int(),
+	}
+
+	if v := inputs.Get("diskIOPSReadWrite"); v.Exists() {
+		values["disk_iops_read_write"] = v.Int()
+	}
+
+	if v := inputs.Get("diskMBpsReadWrite"); v.Exists() {
+		values["disk_mbps_read_write"] = v.Int()
+	}
+
+	return "azurerm_managed_disk", values
+}
+
+func translateAzureNativeResourceGroup(inputs gjson.Result) (string, map[string]interface{}) {
+	return "azurerm_resource_group", map[string]interface{}{
+		"location": inputs.Get("location").String(),
+	}
+}
+
+func translateAzureNativeStorageAccount(inputs gjson.Result) (string, map[string]interface{}) {
+	// The SKU name combines the tier and replication type, e.g. Standard_LRS.
+	tier, replication, _ := strings.Cut(inputs.Get("sku.name").String(), "_")
+
+	values := map[string]interface{}{
+		"location":                 inputs.Get("location").String(),
+		"account_kind":             inputs.Get("kind").String(),
+		"account_tier":             tier,
+		"account_replication_type": replication,
+		"nfsv3_enabled":            inputs.Get("isNfsV3Enabled").Bool(),
+	}
+
+	if v := inputs.Get("accessTier"); v.Exists() {
+		values["access_tier"] = v.String()
+	}
+
+	if v := inputs.Get("accountName"); v.Exists() {
+		values["name"] = v.String()
+	}
+
+	return "azurerm_storage_account", values
+}
+
+func translateAzureNativeFree(resourceType string) azureNativeTranslator {
+	return func(inputs gjson.Result) (string, map[string]interface{}) {
+		return resourceType, map[string]interface{}{
+			"location": inputs.Get("location").String(),
+		}
+	}
+}
@@ -0,0 +1,534 @@
+package pulumi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/logging"
+)
+
+const (
+	// unknownValue is the value Pulumi uses in previews for outputs that
+	// aren't known until the resource is created or updated.
+	unknownValue = "04da6b54-80e4-46f7-96ec-b56ff0331ba9"
+
+	// specialSigKey is the key Pulumi uses to mark secrets, assets, archives
+	// and resource references in serialized property values.
+	specialSigKey = "4dabf18193072939515e22adb298388d"
+
+	providerTypePrefix = "pulumi:providers:"
+)
+
+// mapAttributes are attributes whose values are maps in Terraform rather than
+// nested blocks, so their keys are kept as they are.
+var mapAttributes = map[string]bool{
+	"app_settings":    true,
+	"default_tags":    true,
+	"labels":          true,
+	"metadata":        true,
+	"parameters":      true,
+	"resource_labels": true,
+	"tags":            true,
+	"tags_all":        true,
+	"triggers":        true,
+	"user_labels":     true,
+	"variables":       true,
+}
+
+var invalidAddressChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// resourceState is the state of a Pulumi resource as it appears in the steps
+// of `pulumi preview --json` and the resources of `pulumi stack export`.
+type resourceState struct {
+	URN      string                 `json:"urn"`
+	Type     string                 `json:"type"`
+	Custom   bool                   `json:"custom"`
+	Inputs   map[string]interface{} `json:"inputs"`
+	Outputs  map[string]interface{} `json:"outputs"`
+	Provider string                 `json:"provider"`
+}
+
+type previewStep struct {
+	Op       string         `json:"op"`
+	URN      string         `json:"urn"`
+	OldState *resourceState `json:"oldState"`
+	NewState *resourceState `json:"newState"`
+}
+
+// preview is the output of `pulumi preview --json`.
+type preview struct {
+	Config map[string]interface{} `json:"config"`
+	Steps  []previewStep          `json:"steps"`
+}
+
+// stackExport is the output of `pulumi stack export`.
+type stackExport struct {
+	Deployment struct {
+		Resources []*resourceState `json:"resources"`
+	} `json:"deployment"`
+}
+
+// IsPreviewJSON returns true if the data is the output of
+// `pulumi preview --json`.
+func IsPreviewJSON(data []byte) bool {
+	var p preview
+	if err := json.Unmarshal(data, &p); err != nil {
+		return false
+	}
+
+	for _, s := range p.Steps {
+		if strings.HasPrefix(s.URN, "urn:pulumi:") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsStackExport returns true if the data is the output of
+// `pulumi stack export`.
+func IsStackExport(data []byte) bool {
+	var e stackExport
+	if err := json.Unmarshal(data, &e); err != nil {
+		return false
+	}
+
+	for _, r := range e.Deployment.Resources {
+		if strings.HasPrefix(r.URN, "urn:pulumi:") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// convertPreview converts the output of `pulumi preview --json` to a
+// Terraform plan JSON so it can be parsed by the Terraform plan JSON parser.
+func convertPreview(data []byte) ([]byte, error) {
+	var p preview
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid Pulumi preview JSON: %w", err)
+	}
+
+	var planned, prior []*resourceState
+	plannedURNs := map[string]bool{}
+	priorURNs := map[string]bool{}
+	actions := map[string][]string{}
+
+	for _, s := range p.Steps {
+		if s.NewState != nil && isPlannedOp(s.Op) && !plannedURNs[s.URN] {
+			plannedURNs[s.URN] = true
+			planned = append(planned, s.NewState)
+		}
+
+		if s.OldState != nil && !priorURNs[s.URN] {
+			priorURNs[s.URN] = true
+			prior = append(prior, s.OldState)
+		}
+
+		if a := stepActions(s.Op); a != nil {
+			actions[s.URN] = mergeActions(actions[s.URN], a)
+		}
+	}
+
+	c := newConverter(p.Config, append(append([]*resourceState{}, planned...), prior...))
+
+	plannedValues := c.rootModule(planned)
+	priorValues := c.rootModule(prior)
+
+	urns := make([]string, 0, len(actions))
+	for urn := range actions {
+		urns = append(urns, urn)
+	}
+	sort.Strings(urns)
+
+	resourceChanges := make([]interface{}, 0, len(urns))
+	for _, urn := range urns {
+		addr, ok := c.addresses[urn]
+		if !ok {
+			continue
+		}
+
+		resourceChanges = append(resourceChanges, map[string]interface{}{
+			"address": addr,
+			"change": map[string]interface{}{
+				"actions": actions[urn],
+			},
+		})
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"format_version":   "1.0",
+		"planned_values":   map[string]interface{}{"root_module": plannedValues},
+		"prior_state":      map[string]interface{}{"values": map[string]interface{}{"root_module": priorValues}},
+		"resource_changes": resourceChanges,
+		"configuration":    c.configuration(),
+	})
+}
+
+// convertStackExport converts the output of `pulumi stack export` to a
+// Terraform state JSON so it can be parsed by the Terraform plan JSON parser.
+func convertStackExport(data []byte) ([]byte, error) {
+	var e stackExport
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("invalid Pulumi stack export JSON: %w", err)
+	}
+
+	c := newConverter(nil, e.Deployment.Resources)
+	values := c.rootModule(e.Deployment.Resources)
+
+	return json.Marshal(map[string]interface{}{
+		"format_version": "1.0",
+		"values":         map[string]interface{}{"root_module": values},
+		"configuration":  c.configuration(),
+	})
+}
+
+// isPlannedOp returns true if the resource still exists after a step with the
+// given operation is applied.
+func isPlannedOp(op string) bool {
+	switch op {
+	case "delete", "delete-replaced", "discard", "discard-replaced", "read", "read-replacement", "remove-pending-replace":
+		return false
+	}
+
+	return true
+}
+
+// stepActions returns the Terraform resource change actions for the Pulumi
+// step operation.
+func stepActions(op string) []string {
+	switch op {
+	case "same":
+		return []string{"no-op"}
+	case "create", "import":
+		return []string{"create"}
+	case "update":
+		return []string{"update"}
+	case "delete", "delete-replaced":
+		return []string{"delete"}
+	case "replace", "create-replacement", "import-replacement":
+		return []string{"delete", "create"}
+	}
+
+	return nil
+}
+
+func mergeActions(existing, actions []string) []string {
+	if len(existing) >= len(actions) {
+		return existing
+	}
+
+	return actions
+}
+
+// converter converts Pulumi resources to the resources of a Terraform plan
+// JSON. Resources are given the same address in the planned and prior values
+// so the Terraform parser can match them up.
+type converter struct {
+	providerConf  map[string]interface{}
+	providerKeys  map[string]string
+	addresses     map[string]string
+	usedAddresses map[string]bool
+	configured    map[string]bool
+	resourceConf  []interface{}
+}
+
+func newConverter(config map[string]interface{}, resources []*resourceState) *converter {
+	c := &converter{
+		providerConf:  map[string]interface{}{},
+		providerKeys:  map[string]string{},
+		addresses:     map[string]string{},
+		usedAddresses: map[string]bool{},
+		configured:    map[string]bool{},
+	}
+
+	// Use the region from the stack config for the default providers.
+	for key, v := range config {
+		pkg, setting, ok := strings.Cut(key, ":")
+		region, isString := v.(string)
+		if !ok || setting != "region" || !isString {
+			continue
+		}
+
+		if prefix, ok := providerPrefixes[pkg]; ok {
+			c.addProviderConf(prefix, prefix, region)
+		}
+	}
+
+	for _, r := range resources {
+		if !strings.HasPrefix(r.Type, providerTypePrefix) {
+			continue
+		}
+
+		prefix, ok := providerPrefixes[strings.TrimPrefix(r.Type, providerTypePrefix)]
+		if !ok {
+			continue
+		}
+
+		key := fmt.Sprintf("%s.%s", prefix, urnName(r.URN))
+		region, _ := r.Inputs["region"].(string)
+		if region == "" || region == unknownValue {
+			continue
+		}
+
+		c.addProviderConf(key, prefix, region)
+		c.providerKeys[r.URN] = key
+	}
+
+	return c
+}
+
+func (c *converter) addProviderConf(key, name, region string) {
+	c.providerConf[key] = map[string]interface{}{
+		"name": name,
+		"expressions": map[string]interface{}{
+			"region": map[string]interface{}{
+				"constant_value": region,
+			},
+		},
+	}
+}
+
+func (c *converter) configuration() map[string]interface{} {
+	return map[string]interface{}{
+		"provider_config": c.providerConf,
+		"root_module": map[string]interface{}{
+			"resources": c.resourceConf,
+		},
+	}
+}
+
+func (c *converter) rootModule(resources []*resourceState) map[string]interface{} {
+	converted := make([]interface{}, 0, len(resources))
+
+	for _, r := range resources {
+		res, ok := c.convertResource(r)
+		if !ok {
+			continue
+		}
+
+		converted = append(converted, res)
+	}
+
+	return map[string]interface{}{
+		"resources": converted,
+	}
+}
+
+func (c *converter) convertResource(r *resourceState) (map[string]interface{}, bool) {
+	if !r.Custom || strings.HasPrefix(r.Type, "pulumi:") {
+		return nil, false
+	}
+
+	var resourceType string
+	var values map[string]interface{}
+
+	if strings.HasPrefix(r.Type, "azure-native:") {
+		inputs, err := json.Marshal(stateValues(r))
+		if err != nil {
+			return nil, false
+		}
+
+		resourceType, values = azureNativeResource(r.Type, gjson.ParseBytes(inputs))
+		if tags, ok := r.Inputs["tags"]; ok && values != nil {
+			values["tags"] = convertValue("tags", tags)
+		}
+	} else {
+		resourceType = terraformResourceType(r.Type)
+		if resourceType != "" {
+			values = convertObject(stateValues(r))
+		}
+	}
+
+	if resourceType == "" {
+		logging.Logger.Debug().Msgf("Skipping Pulumi resource %s as %s is not supported", r.URN, r.Type)
+		return nil, false
+	}
+
+	addr := c.address(r.URN, resourceType)
+
+	if key, ok := c.providerKeys[providerURN(r.Provider)]; ok && !c.configured[addr] {
+		c.configured[addr] = true
+		c.resourceConf = append(c.resourceConf, map[string]interface{}{
+			"address":             addr,
+			"provider_config_key": key,
+		})
+	}
+
+	name := strings.TrimPrefix(addr, resourceType+".")
+
+	return map[string]interface{}{
+		"address":       addr,
+		"mode":          "managed",
+		"type":          resourceType,
+		"name":          name,
+		"provider_name": fmt.Sprintf("registry.terraform.io/hashicorp/%s", strings.Split(resourceType, "_")[0]),
+		"values":        values,
+	}, true
+}
+
+// address returns the Terraform address for the resource. The same URN always
+// returns the same address.
+func (c *converter) address(urn, resourceType string) string {
+	if addr, ok := c.addresses[urn]; ok {
+		return addr
+	}
+
+	name := invalidAddressChars.ReplaceAllString(urnName(urn), "_")
+	addr := fmt.Sprintf("%s.%s", resourceType, name)
+
+	for i := 2; c.usedAddresses[addr]; i++ {
+		addr = fmt.Sprintf("%s.%s_%d", resourceType, name, i)
+	}
+
+	c.addresses[urn] = addr
+	c.usedAddresses[addr] = true
+
+	return addr
+}
+
+// stateValues merges the inputs and outputs of the resource. Outputs contain
+// computed values such as IDs, but the inputs are used where both exist since
+// outputs in a preview are from before the change.
+func stateValues(r *resourceState) map[string]interface{} {
+	values := make(map[string]interface{}, len(r.Inputs)+len(r.Outputs))
+	for k, v := range r.Outputs {
+		values[k] = v
+	}
+	for k, v := range r.Inputs {
+		values[k] = v
+	}
+
+	return values
+}
+
+// convertObject converts the camelCase keys of a Pulumi object to snake_case
+// Terraform attribute names and the values to the format Terraform uses.
+func convertObject(obj map[string]interface{}) map[string]interface{} {
+	values := make(map[string]interface{}, len(obj))
+
+	for k, v := range obj {
+		if strings.HasPrefix(k, "__") {
+			continue
+		}
+
+		key := toSnakeCase(k)
+
+		converted := convertValue(key, v)
+		if converted == nil {
+			continue
+		}
+
+		values[key] = converted
+
+		// Pulumi pluralizes the names of nested blocks that can be repeated,
+		// e.g. ebsBlockDevices for the ebs_block_device block.
+		if singular := singularize(key); singular != key && isObjectList(converted) {
+			if _, ok := values[singular]; !ok {
+				values[singular] = converted
+			}
+		}
+	}
+
+	return values
+}
+
+func convertValue(key string, v interface{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		if val == unknownValue {
+			return nil
+		}
+
+		return val
+	case map[string]interface{}:
+		if _, ok := val[specialSigKey]; ok {
+			return nil
+		}
+
+		if mapAttributes[key] {
+			m := make(map[string]interface{}, len(val))
+			for k, v := range val {
+				if converted := convertValue("", v); converted != nil {
+					m[k] = converted
+				}
+			}
+
+			return m
+		}
+
+		// Nested blocks that can only appear once are objects in Pulumi but
+		// lists with a single item in Terraform.
+		return []interface{}{convertObject(val)}
+	case []interface{}:
+		list := make([]interface{}, 0, len(val))
+		for _, item := range val {
+			if m, ok := item.(map[string]interface{}); ok {
+				if _, ok := m[specialSigKey]; !ok {
+					list = append(list, convertObject(m))
+				}
+
+				continue
+			}
+
+			if converted := convertValue("", item); converted != nil {
+				list = append(list, converted)
+			}
+		}
+
+		return list
+	}
+
+	return v
+}
+
+func isObjectList(v interface{}) bool {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 {
+		return false
+	}
+
+	_, ok = list[0].(map[string]interface{})
+	return ok
+}
+
+func singularize(s string) string {
+	switch {
+	case strings.HasSuffix(s, "ies"):
+		return strings.TrimSuffix(s, "ies") + "y"
+	case strings.HasSuffix(s, "sses"):
+		return strings.TrimSuffix(s, "es")
+	case strings.HasSuffix(s, "s") && !strings.HasSuffix(s, "ss"):
+		return strings.TrimSuffix(s, "s")
+	}
+
+	return s
+}
+
+// urnName returns the name of the resource from its URN, e.g.
+// urn:pulumi:dev::app::aws:ec2/instance:Instance::web returns web.
+func urnName(urn string) string {
+	i := strings.LastIndex(urn, "::")
+	if i == -1 {
+		return urn
+	}
+
+	return urn[i+2:]
+}
+
+// providerURN returns the URN of the provider from a provider reference,
+// which is the provider URN and ID separated by ::.
+func providerURN(ref string) string {
+	i := strings.LastIndex(ref, "::")
+	if i == -1 {
+		return ref
+	}
+
+	return ref[:i]
+}
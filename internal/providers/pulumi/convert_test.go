@@ -0,0 +1,101 @@
+package pulumi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestIsPreviewJSON(t *testing.T) {
+	preview, err := os.ReadFile("testdata/preview.json")
+	require.NoError(t, err)
+	export, err := os.ReadFile("testdata/export.json")
+	require.NoError(t, err)
+
+	assert.True(t, IsPreviewJSON(preview))
+	assert.False(t, IsPreviewJSON(export))
+	assert.False(t, IsPreviewJSON([]byte(`{"format_version":"1.1","planned_values":{}}`)))
+
+	assert.True(t, IsStackExport(export))
+	assert.False(t, IsStackExport(preview))
+	assert.False(t, IsStackExport([]byte(`not json`)))
+}
+
+func TestConvertPreview(t *testing.T) {
+	data, err := os.ReadFile("testdata/preview.json")
+	require.NoError(t, err)
+
+	j, err := convertPreview(data)
+	require.NoError(t, err)
+
+	plan := gjson.ParseBytes(j)
+
+	planned := addresses(plan.Get("planned_values.root_module.resources"))
+	assert.ElementsMatch(t, []string{"aws_instance.web", "aws_db_instance.db", "google_compute_instance.vm", "azurerm_linux_virtual_machine.vm"}, planned)
+
+	prior := addresses(plan.Get("prior_state.values.root_module.resources"))
+	assert.ElementsMatch(t, []string{"aws_db_instance.db", "aws_ebs_volume.old-data", "google_compute_instance.vm"}, prior)
+
+	web := plan.Get(`planned_values.root_module.resources.#(address="aws_instance.web").values`)
+	assert.Equal(t, "t3.medium", web.Get("instance_type").String())
+	assert.Equal(t, "gp3", web.Get("root_block_device.0.volume_type").String())
+	assert.Equal(t, int64(100), web.Get("ebs_block_device.0.volume_size").Int())
+	assert.Equal(t, "platform", web.Get("tags.team").String())
+	assert.False(t, web.Get("arn").Exists())
+	assert.False(t, web.Get("__defaults").Exists())
+
+	db := plan.Get(`planned_values.root_module.resources.#(address="aws_db_instance.db").values`)
+	assert.Equal(t, "db.t3.large", db.Get("instance_class").String())
+	assert.False(t, db.Get("password").Exists())
+
+	assert.Equal(t, "us-west-2", plan.Get(`configuration.provider_config.aws\.west.expressions.region.constant_value`).String())
+	assert.Equal(t, "us-east-2", plan.Get(`configuration.provider_config.aws.expressions.region.constant_value`).String())
+	assert.Equal(t, "aws.west", plan.Get(`configuration.root_module.resources.#(address="aws_instance.web").provider_config_key`).String())
+
+	assert.Equal(t, `["update"]`, plan.Get(`resource_changes.#(address="aws_db_instance.db").change.actions`).Raw)
+	assert.Equal(t, `["delete"]`, plan.Get(`resource_changes.#(address="aws_ebs_volume.old-data").change.actions`).Raw)
+	assert.Equal(t, `["no-op"]`, plan.Get(`resource_changes.#(address="google_compute_instance.vm").change.actions`).Raw)
+}
+
+func TestConvertStackExport(t *testing.T) {
+	data, err := os.ReadFile("testdata/export.json")
+	require.NoError(t, err)
+
+	j, err := convertStackExport(data)
+	require.NoError(t, err)
+
+	state := gjson.ParseBytes(j)
+	assert.False(t, state.Get("planned_values").Exists())
+	assert.ElementsMatch(t, []string{"aws_instance.web", "aws_instance.web-2"}, addresses(state.Get("values.root_module.resources")))
+
+	web := state.Get(`values.root_module.resources.#(address="aws_instance.web").values`)
+	assert.Equal(t, "i-0123456789abcdef0", web.Get("id").String())
+	assert.Equal(t, int64(8), web.Get("root_block_device.0.volume_size").Int())
+}
+
+func TestConverterAddress(t *testing.T) {
+	c := newConverter(nil, nil)
+
+	assert.Equal(t, "aws_instance.my_app_web", c.address("urn:pulumi:dev::app::aws:ec2/instance:Instance::my.app/web", "aws_instance"))
+	assert.Equal(t, "aws_instance.my_app_web_2", c.address("urn:pulumi:dev::app::my:component:Web$aws:ec2/instance:Instance::my.app/web", "aws_instance"))
+	assert.Equal(t, "aws_instance.my_app_web", c.address("urn:pulumi:dev::app::aws:ec2/instance:Instance::my.app/web", "aws_instance"))
+}
+
+func TestSingularize(t *testing.T) {
+	assert.Equal(t, "ebs_block_device", singularize("ebs_block_devices"))
+	assert.Equal(t, "policy", singularize("policies"))
+	assert.Equal(t, "address", singularize("addresses"))
+	assert.Equal(t, "access", singularize("access"))
+}
+
+func addresses(resources gjson.Result) []string {
+	var addrs []string
+	for _, r := range resources.Array() {
+		addrs = append(addrs, r.Get("address").String())
+	}
+
+	return addrs
+}
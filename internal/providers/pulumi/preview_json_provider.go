@@ -0,0 +1,79 @@
+package pulumi
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// PreviewJSONProvider loads the resources from the output of
+// `pulumi preview --json`. The resources are converted to a Terraform plan
+// JSON so the Terraform resource cost functions can be reused.
+type PreviewJSONProvider struct {
+	ctx                  *config.ProjectContext
+	Path                 string
+	includePastResources bool
+}
+
+func NewPreviewJSONProvider(ctx *config.ProjectContext, includePastResources bool) schema.Provider {
+	return &PreviewJSONProvider{
+		ctx:                  ctx,
+		Path:                 ctx.ProjectConfig.Path,
+		includePastResources: includePastResources,
+	}
+}
+
+func (p *PreviewJSONProvider) Context() *config.ProjectContext { return p.ctx }
+
+func (p *PreviewJSONProvider) Type() string {
+	return "pulumi_preview_json"
+}
+
+func (p *PreviewJSONProvider) DisplayType() string {
+	return "Pulumi preview JSON file"
+}
+
+func (p *PreviewJSONProvider) AddMetadata(metadata *schema.ProjectMetadata) {
+	metadata.ConfigSha = p.ctx.ProjectConfig.ConfigSha
+}
+
+func (p *PreviewJSONProvider) LoadResources(usage schema.UsageMap) ([]*schema.Project, error) {
+	spinner := ui.NewSpinner("Extracting only cost-related params from Pulumi preview", ui.SpinnerOptions{
+		EnableLogging: p.ctx.RunContext.Config.IsLogging(),
+		NoColor:       p.ctx.RunContext.Config.NoColor,
+		Indent:        "  ",
+	})
+	defer spinner.Fail()
+
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		return []*schema.Project{}, errors.Wrap(err, "Error reading Pulumi preview JSON file")
+	}
+
+	j, err := convertPreview(data)
+	if err != nil {
+		return []*schema.Project{}, errors.Wrap(err, "Error parsing Pulumi preview JSON file")
+	}
+
+	project, err := loadPlanJSON(p.ctx, p.includePastResources, usage, j)
+	if err != nil {
+		return []*schema.Project{project}, errors.Wrap(err, "Error parsing Pulumi preview JSON file")
+	}
+
+	project.Metadata.Type = p.Type()
+	p.AddMetadata(project.Metadata)
+
+	spinner.Success()
+	return []*schema.Project{project}, nil
+}
+
+// loadPlanJSON loads the project from a Terraform plan JSON converted from the
+// Pulumi output.
+func loadPlanJSON(ctx *config.ProjectContext, includePastResources bool, usage schema.UsageMap, j []byte) (*schema.Project, error) {
+	return terraform.NewPlanJSONProvider(ctx, includePastResources).LoadResourcesFromSrc(usage, j, nil)
+}
@@ -0,0 +1,73 @@
+package pulumi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestPreviewJSONProvider(t *testing.T) {
+	ctx := config.NewProjectContext(config.EmptyRunContext(), &config.Project{Path: "testdata/preview.json"}, map[string]interface{}{})
+	p := NewPreviewJSONProvider(ctx, true)
+
+	usage := schema.NewUsageMapFromInterface(map[string]interface{}{})
+	projects, err := p.LoadResources(usage)
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+
+	project := projects[0]
+	assert.Equal(t, "pulumi_preview_json", project.Metadata.Type)
+
+	project.BuildResources(usage)
+
+	resources := resourcesByName(project.Resources)
+	assert.ElementsMatch(t, []string{"aws_instance.web", "aws_db_instance.db", "google_compute_instance.vm", "azurerm_linux_virtual_machine.vm"}, keys(resources))
+	assert.Equal(t, "us-west-2", *resources["aws_instance.web"].CostComponents[0].ProductFilter.Region)
+	assert.Equal(t, "us-east-2", *resources["aws_db_instance.db"].CostComponents[0].ProductFilter.Region)
+	assert.Equal(t, "europe-west1", *resources["google_compute_instance.vm"].CostComponents[0].ProductFilter.Region)
+	assert.Equal(t, "westeurope", *resources["azurerm_linux_virtual_machine.vm"].CostComponents[0].ProductFilter.Region)
+
+	past := resourcesByName(project.PastResources)
+	assert.ElementsMatch(t, []string{"aws_db_instance.db", "aws_ebs_volume.old-data", "google_compute_instance.vm"}, keys(past))
+}
+
+func TestStateJSONProvider(t *testing.T) {
+	ctx := config.NewProjectContext(config.EmptyRunContext(), &config.Project{Path: "testdata/export.json"}, map[string]interface{}{})
+	p := NewStateJSONProvider(ctx, false)
+
+	usage := schema.NewUsageMapFromInterface(map[string]interface{}{})
+	projects, err := p.LoadResources(usage)
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+
+	project := projects[0]
+	assert.Equal(t, "pulumi_state_json", project.Metadata.Type)
+
+	project.BuildResources(usage)
+
+	resources := resourcesByName(project.Resources)
+	assert.ElementsMatch(t, []string{"aws_instance.web", "aws_instance.web-2"}, keys(resources))
+	assert.Equal(t, "eu-west-1", *resources["aws_instance.web"].CostComponents[0].ProductFilter.Region)
+}
+
+func resourcesByName(resources []*schema.Resource) map[string]*schema.Resource {
+	m := make(map[string]*schema.Resource, len(resources))
+	for _, r := range resources {
+		m[r.Name] = r
+	}
+
+	return m
+}
+
+func keys(m map[string]*schema.Resource) []string {
+	k := make([]string, 0, len(m))
+	for name := range m {
+		k = append(k, name)
+	}
+
+	return k
+}
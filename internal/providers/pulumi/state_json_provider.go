@@ -0,0 +1,71 @@
+package pulumi
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// StateJSONProvider loads the resources from the output of
+// `pulumi stack export`, i.e. the resources that are currently deployed.
+type StateJSONProvider struct {
+	ctx                  *config.ProjectContext
+	Path                 string
+	includePastResources bool
+}
+
+func NewStateJSONProvider(ctx *config.ProjectContext, includePastResources bool) schema.Provider {
+	return &StateJSONProvider{
+		ctx:                  ctx,
+		Path:                 ctx.ProjectConfig.Path,
+		includePastResources: includePastResources,
+	}
+}
+
+func (p *StateJSONProvider) Context() *config.ProjectContext { return p.ctx }
+
+func (p *StateJSONProvider) Type() string {
+	return "pulumi_state_json"
+}
+
+func (p *StateJSONProvider) DisplayType() string {
+	return "Pulumi stack export JSON file"
+}
+
+func (p *StateJSONProvider) AddMetadata(metadata *schema.ProjectMetadata) {
+	metadata.ConfigSha = p.ctx.ProjectConfig.ConfigSha
+}
+
+func (p *StateJSONProvider) LoadResources(usage schema.UsageMap) ([]*schema.Project, error) {
+	spinner := ui.NewSpinner("Extracting only cost-related params from Pulumi stack", ui.SpinnerOptions{
+		EnableLogging: p.ctx.RunContext.Config.IsLogging(),
+		NoColor:       p.ctx.RunContext.Config.NoColor,
+		Indent:        "  ",
+	})
+	defer spinner.Fail()
+
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		return []*schema.Project{}, errors.Wrap(err, "Error reading Pulumi stack export JSON file")
+	}
+
+	j, err := convertStackExport(data)
+	if err != nil {
+		return []*schema.Project{}, errors.Wrap(err, "Error parsing Pulumi stack export JSON file")
+	}
+
+	project, err := loadPlanJSON(p.ctx, p.includePastResources, usage, j)
+	if err != nil {
+		return []*schema.Project{project}, errors.Wrap(err, "Error parsing Pulumi stack export JSON file")
+	}
+
+	project.Metadata.Type = p.Type()
+	p.AddMetadata(project.Metadata)
+
+	spinner.Success()
+	return []*schema.Project{project}, nil
+}
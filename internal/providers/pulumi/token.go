@@ -0,0 +1,121 @@
+package pulumi
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/infracost/infracost/internal/providers/terraform"
+)
+
+// explicitResourceTypes maps Pulumi resource tokens to Terraform resource types
+// where the Terraform type can't be derived from the token.
+var explicitResourceTypes = map[string]string{
+	"aws:alb/listener:Listener":                                 "aws_alb_listener",
+	"aws:alb/loadBalancer:LoadBalancer":                         "aws_alb",
+	"aws:alb/targetGroup:TargetGroup":                           "aws_alb_target_group",
+	"aws:apigateway/restApi:RestApi":                            "aws_api_gateway_rest_api",
+	"aws:apigateway/stage:Stage":                                "aws_api_gateway_stage",
+	"aws:ec2transitgateway/peeringAttachment:PeeringAttachment": "aws_ec2_transit_gateway_peering_attachment",
+	"aws:ec2transitgateway/transitGateway:TransitGateway":       "aws_ec2_transit_gateway",
+	"aws:ec2transitgateway/vpcAttachment:VpcAttachment":         "aws_ec2_transit_gateway_vpc_attachment",
+	"aws:elasticsearch/domain:Domain":                           "aws_elasticsearch_domain",
+	"aws:elb/loadBalancer:LoadBalancer":                         "aws_elb",
+	"aws:lb/listener:Listener":                                  "aws_lb_listener",
+	"aws:lb/loadBalancer:LoadBalancer":                          "aws_lb",
+	"aws:lb/targetGroup:TargetGroup":                            "aws_lb_target_group",
+	"aws:rds/instance:Instance":                                 "aws_db_instance",
+	"aws:rds/parameterGroup:ParameterGroup":                     "aws_db_parameter_group",
+	"aws:rds/subnetGroup:SubnetGroup":                           "aws_db_subnet_group",
+	"aws:s3/bucketV2:BucketV2":                                  "aws_s3_bucket",
+	"azure:core/resourceGroup:ResourceGroup":                    "azurerm_resource_group",
+}
+
+// providerPrefixes maps the Pulumi package name to the prefix of the Terraform
+// resource types.
+var providerPrefixes = map[string]string{
+	"aws":   "aws",
+	"azure": "azurerm",
+	"gcp":   "google",
+}
+
+// terraformResourceType returns the Terraform resource type for a Pulumi
+// resource token such as aws:ec2/instance:Instance. The Pulumi AWS, Azure
+// Classic and GCP providers are bridged from the Terraform providers so their
+// resources map to a Terraform resource type. This returns an empty string if
+// there is no Terraform resource type that Infracost supports.
+func terraformResourceType(token string) string {
+	if t, ok := explicitResourceTypes[token]; ok {
+		return t
+	}
+
+	pkg, module, resource, ok := parseToken(token)
+	if !ok {
+		return ""
+	}
+
+	prefix, ok := providerPrefixes[pkg]
+	if !ok {
+		return ""
+	}
+
+	candidates := []string{
+		prefix + "_" + toSnakeCase(module) + "_" + toSnakeCase(resource),
+	}
+
+	// Most AWS EC2 and VPC resources and the Azure resources that already
+	// include the service in their name don't have the module in the
+	// Terraform type, e.g. aws:ec2/natGateway:NatGateway is aws_nat_gateway.
+	// We only do this for these modules, otherwise resources such as
+	// aws:opsworks/instance:Instance would be mapped to aws_instance.
+	if (pkg == "aws" && module == "ec2") || pkg == "azure" || pkg == "gcp" {
+		candidates = append(candidates, prefix+"_"+toSnakeCase(resource))
+	}
+
+	registryMap := terraform.GetResourceRegistryMap()
+	for _, c := range candidates {
+		if _, ok := (*registryMap)[c]; ok {
+			return c
+		}
+	}
+
+	return ""
+}
+
+// parseToken splits a Pulumi resource token of the form pkg:module/name:Resource
+// into its parts. The module for azure-native tokens can contain an API
+// version, e.g. azure-native:compute/v20230301:VirtualMachine, which is
+// removed.
+func parseToken(token string) (pkg, module, resource string, ok bool) {
+	parts := strings.Split(token, ":")
+	if len(parts) != 3 {
+		return "", "", "", false
+	}
+
+	module, _, _ = strings.Cut(parts[1], "/")
+
+	return parts[0], module, parts[2], true
+}
+
+// toSnakeCase converts a camelCase or PascalCase name to snake_case.
+func toSnakeCase(s string) string {
+	runes := []rune(s)
+
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				prev := runes[i-1]
+				nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+					b.WriteRune('_')
+				}
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
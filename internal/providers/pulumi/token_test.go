@@ -0,0 +1,49 @@
+package pulumi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"instanceType":             "instance_type",
+		"ebsBlockDevices":          "ebs_block_devices",
+		"diskSizeGB":               "disk_size_gb",
+		"diskIOPSReadWrite":        "disk_iops_read_write",
+		"ipv6AddressCount":         "ipv6_address_count",
+		"VirtualMachine":           "virtual_machine",
+		"already_snake":            "already_snake",
+		"cpuCoreCount":             "cpu_core_count",
+		"enableHttpEndpoint":       "enable_http_endpoint",
+		"associatePublicIpAddress": "associate_public_ip_address",
+	}
+
+	for input, expected := range tests {
+		assert.Equal(t, expected, toSnakeCase(input), input)
+	}
+}
+
+func TestTerraformResourceType(t *testing.T) {
+	tests := map[string]string{
+		"aws:ec2/instance:Instance":                             "aws_instance",
+		"aws:ec2/natGateway:NatGateway":                         "aws_nat_gateway",
+		"aws:rds/instance:Instance":                             "aws_db_instance",
+		"aws:ebs/volume:Volume":                                 "aws_ebs_volume",
+		"aws:dynamodb/table:Table":                              "aws_dynamodb_table",
+		"aws:lambda/function:Function":                          "aws_lambda_function",
+		"aws:lb/loadBalancer:LoadBalancer":                      "aws_lb",
+		"gcp:compute/instance:Instance":                         "google_compute_instance",
+		"gcp:storage/bucket:Bucket":                             "google_storage_bucket",
+		"azure:compute/linuxVirtualMachine:LinuxVirtualMachine": "azurerm_linux_virtual_machine",
+		"azure:core/resourceGroup:ResourceGroup":                "azurerm_resource_group",
+		"random:index/randomPet:RandomPet":                      "",
+		"aws:opsworks/instance:Instance":                        "",
+		"invalid":                                               "",
+	}
+
+	for token, expected := range tests {
+		assert.Equal(t, expected, terraformResourceType(token), token)
+	}
+}
@@ -15,6 +15,7 @@ func NewAPIGatewayRestAPI(d *schema.ResourceData, u *schema.UsageData) *schema.R
 	r := &aws.APIGatewayRestAPI{
 		Address: d.Address,
 		Region:  d.Get("region").String(),
+		Name:    d.Get("name").String(),
 	}
 
 	r.PopulateUsage(u)
@@ -14,6 +14,7 @@ func getAPIGatewayV2APIRegistryItem() *schema.RegistryItem {
 func NewAPIGatewayV2API(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	r := &aws.APIGatewayV2API{
 		Address:      d.Address,
+		ID:           d.Get("id").String(),
 		ProtocolType: d.Get("protocol_type").String(),
 		Region:       d.Get("region").String(),
 	}
@@ -27,6 +27,7 @@ func newCloudfrontDistribution(d *schema.ResourceData, u *schema.UsageData) *sch
 	r := &aws.CloudfrontDistribution{
 		Address:                   d.Address,
 		Region:                    region,
+		ID:                        d.Get("id").String(),
 		IsOriginShieldEnabled:     isOriginShieldEnabled,
 		IsSSLSupportMethodVIP:     isSSLSupportMethodVIP,
 		HasLoggingConfigBucket:    hasLoggingConfigBucket,
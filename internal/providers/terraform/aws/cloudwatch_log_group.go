@@ -15,6 +15,7 @@ func NewCloudwatchLogGroup(d *schema.ResourceData, u *schema.UsageData) *schema.
 	r := &aws.CloudwatchLogGroup{
 		Address: d.Address,
 		Region:  d.Get("region").String(),
+		Name:    d.Get("name").String(),
 	}
 
 	r.PopulateUsage(u)
@@ -16,5 +16,6 @@ func NewECRRepository(d *schema.ResourceData) schema.CoreResource {
 	return &aws.ECRRepository{
 		Address: d.Address,
 		Region:  d.Get("region").String(),
+		Name:    d.Get("name").String(),
 	}
 }
@@ -20,6 +20,7 @@ func newKinesisStream(d *schema.ResourceData) schema.CoreResource {
 	return &aws.KinesisStream{
 		Address:    d.Address,
 		Region:     region,
+		Name:       d.Get("name").String(),
 		StreamMode: StreamMode,
 		ShardCount: ShardCount,
 	}
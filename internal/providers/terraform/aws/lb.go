@@ -34,6 +34,7 @@ func NewLB(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 		Address:          d.Address,
 		Region:           d.Get("region").String(),
 		LoadBalancerType: loadBalancerType,
+		ARNSuffix:        d.Get("arn_suffix").String(),
 	}
 
 	r.PopulateUsage(u)
@@ -21,6 +21,7 @@ func NewNATGateway(d *schema.ResourceData, u *schema.UsageData) *schema.Resource
 	a := &aws.NATGateway{
 		Address: d.Address,
 		Region:  region,
+		ID:      d.Get("id").String(),
 	}
 	a.PopulateUsage(u)
 
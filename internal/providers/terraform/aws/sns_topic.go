@@ -18,6 +18,7 @@ func NewSNSTopic(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 		r := &aws.SNSFIFOTopic{
 			Address:       d.Address,
 			Region:        d.Get("region").String(),
+			Name:          d.Get("name").String(),
 			Subscriptions: int64(len(d.References("aws_sns_topic_subscription.topic_arn"))),
 		}
 
@@ -28,6 +29,7 @@ func NewSNSTopic(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	r := &aws.SNSTopic{
 		Address: d.Address,
 		Region:  d.Get("region").String(),
+		Name:    d.Get("name").String(),
 	}
 	r.PopulateUsage(u)
 	return r.BuildResource()
@@ -16,6 +16,7 @@ func NewSQSQueue(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	r := &aws.SQSQueue{
 		Address:   d.Address,
 		Region:    d.Get("region").String(),
+		Name:      d.Get("name").String(),
 		FifoQueue: d.Get("fifo_queue").Bool(),
 	}
 
@@ -1,6 +1,7 @@
 package azure
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/tidwall/gjson"
 
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/azure"
 )
 
 func GetAzureRMCosmosdbCassandraKeyspaceRegistryItem() *schema.RegistryItem {
@@ -33,15 +35,90 @@ const (
 func NewAzureRMCosmosdb(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	if len(d.References("account_name")) > 0 {
 		account := d.References("account_name")[0]
-		return &schema.Resource{
-			Name:           d.Address,
-			CostComponents: cosmosDBCostComponents(d, u, account),
-		}
+		return newCosmosDBResource(d, u, account)
 	}
 	log.Warn().Msgf("Skipping resource %s as its 'account_name' property could not be found.", d.Address)
 	return nil
 }
 
+func newCosmosDBResource(d *schema.ResourceData, u *schema.UsageData, account *schema.ResourceData) *schema.Resource {
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: cosmosDBCostComponents(d, u, account),
+		UsageSchema: []*schema.UsageItem{
+			{Key: "monthly_serverless_request_units", ValueType: schema.Int64, DefaultValue: 0},
+			{Key: "max_request_units_utilization_percentage", ValueType: schema.Float64, DefaultValue: 0},
+		},
+		EstimateUsage: cosmosDBEstimateFunc(d, account),
+	}
+}
+
+// cosmosDBEstimateFunc returns the func that estimates the request units of
+// serverless databases and collections, and the utilization of the maximum
+// throughput of autoscale ones, from the Azure Monitor metrics of the account.
+func cosmosDBEstimateFunc(d *schema.ResourceData, account *schema.ResourceData) schema.EstimateFunc {
+	return func(ctx context.Context, values map[string]interface{}) error {
+		accountID := account.Get("id").String()
+		if accountID == "" {
+			// The account hasn't been created yet so it has no metrics.
+			return nil
+		}
+
+		database, collection := cosmosDBMetricNames(d)
+
+		switch cosmosDBModel(d) {
+		case Serverless:
+			requestUnits, err := azure.CosmosDBGetRequestUnits(ctx, accountID, database, collection)
+			if err != nil {
+				return err
+			}
+			values["monthly_serverless_request_units"] = int64(requestUnits)
+		case Autoscale:
+			utilization, err := azure.CosmosDBGetMaxRUUtilization(ctx, accountID, database, collection)
+			if err != nil {
+				return err
+			}
+			// The maximum throughput is billed for at least 10% of the time.
+			if utilization < 10 {
+				utilization = 10
+			}
+			values["max_request_units_utilization_percentage"] = utilization
+		}
+
+		return nil
+	}
+}
+
+// cosmosDBMetricNames returns the names of the database and collection the
+// Azure Monitor metrics of the resource are split by. The collection is empty
+// for databases.
+func cosmosDBMetricNames(d *schema.ResourceData) (string, string) {
+	switch d.Type {
+	case "azurerm_cosmosdb_sql_container", "azurerm_cosmosdb_mongo_collection", "azurerm_cosmosdb_gremlin_graph":
+		return d.Get("database_name").String(), d.Get("name").String()
+	case "azurerm_cosmosdb_cassandra_table":
+		keyspace := ""
+		if len(d.References("cassandra_keyspace_id")) > 0 {
+			keyspace = d.References("cassandra_keyspace_id")[0].Get("name").String()
+		}
+		return keyspace, d.Get("name").String()
+	case "azurerm_cosmosdb_table":
+		return "", d.Get("name").String()
+	}
+
+	return d.Get("name").String(), ""
+}
+
+func cosmosDBModel(d *schema.ResourceData) modelType {
+	if d.Get("throughput").Type != gjson.Null {
+		return Provisioned
+	}
+	if d.Get("autoscale_settings.0.max_throughput").Type != gjson.Null {
+		return Autoscale
+	}
+	return Serverless
+}
+
 func cosmosDBCostComponents(d *schema.ResourceData, u *schema.UsageData, account *schema.ResourceData) []*schema.CostComponent {
 	// Find the region in from the passed-in account
 	region := lookupRegion(account, []string{"account_name", "resource_group_name"})
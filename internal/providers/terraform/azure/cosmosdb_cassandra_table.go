@@ -22,10 +22,7 @@ func NewAzureRMCosmosdbCassandraTable(d *schema.ResourceData, u *schema.UsageDat
 		keyspace := d.References("cassandra_keyspace_id")[0]
 		if len(keyspace.References("account_name")) > 0 {
 			account := keyspace.References("account_name")[0]
-			return &schema.Resource{
-				Name:           d.Address,
-				CostComponents: cosmosDBCostComponents(d, u, account),
-			}
+			return newCosmosDBResource(d, u, account)
 		}
 		log.Warn().Msgf("Skipping resource %s as its 'cassandra_keyspace_id.account_name' property could not be found.", d.Address)
 		return nil
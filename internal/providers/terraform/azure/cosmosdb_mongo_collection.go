@@ -23,10 +23,7 @@ func NewAzureRMCosmosdbMongoCollection(d *schema.ResourceData, u *schema.UsageDa
 		mongoDB := d.References("database_name")[0]
 		if len(mongoDB.References("account_name")) > 0 {
 			account := mongoDB.References("account_name")[0]
-			return &schema.Resource{
-				Name:           d.Address,
-				CostComponents: cosmosDBCostComponents(d, u, account),
-			}
+			return newCosmosDBResource(d, u, account)
 		}
 		log.Warn().Msgf("Skipping resource %s as its 'database_name.account_name' property could not be found.", d.Address)
 		return nil
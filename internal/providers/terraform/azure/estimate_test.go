@@ -0,0 +1,102 @@
+package azure_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/providers/terraform/azure"
+	"github.com/infracost/infracost/internal/schema"
+	azureusage "github.com/infracost/infracost/internal/usage/azure"
+)
+
+// stubMonitor returns a context that sends the Azure Monitor requests to a
+// server that responds with a single data point for the metric. The request
+// for the metric must have the filter.
+func stubMonitor(t *testing.T, resourceID, metric, aggregation, filter string, value float64) context.Context {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != resourceID+"/providers/Microsoft.Insights/metrics" || q.Get("metricnames") != metric || q.Get("$filter") != filter {
+			t.Errorf("received unexpected stubbed Azure call: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"value": [{"name": {"value": %q}, "errorCode": "Success", "timeseries": [{"data": [{"timeStamp": "1970-01-01T00:00:00Z", %q: %f}]}]}]}`, metric, aggregation, value)
+	}))
+	t.Cleanup(server.Close)
+
+	return azureusage.WithTestEndpoint(context.TODO(), server.URL)
+}
+
+func estimateUsage(t *testing.T, ctx context.Context, resource *schema.Resource) map[string]interface{} {
+	require.NotNil(t, resource.EstimateUsage)
+
+	u := make(map[string]interface{})
+	require.NoError(t, resource.EstimateUsage(ctx, u))
+	return u
+}
+
+func TestAzureRMEventHubsEstimate(t *testing.T) {
+	id := "/subscriptions/0000/resourceGroups/rg/providers/Microsoft.EventHub/namespaces/my-namespace"
+	ctx := stubMonitor(t, id, "IncomingMessages", "total", "", 2500000)
+
+	d := schema.NewResourceData("azurerm_eventhub_namespace", "azurerm", "azurerm_eventhub_namespace.main", nil, gjson.Parse(fmt.Sprintf(`{"id": %q, "location": "eastus", "sku": "Standard"}`, id)))
+	u := estimateUsage(t, ctx, azure.NewAzureRMEventHubs(d, nil))
+	assert.Equal(t, int64(2500000), u["monthly_ingress_events"])
+}
+
+func TestAzureRMCosmosdbEstimate(t *testing.T) {
+	id := "/subscriptions/0000/resourceGroups/rg/providers/Microsoft.DocumentDB/databaseAccounts/my-account"
+	account := schema.NewResourceData("azurerm_cosmosdb_account", "azurerm", "azurerm_cosmosdb_account.main", nil, gjson.Parse(fmt.Sprintf(`{"id": %q, "location": "eastus", "geo_location": [{"location": "eastus"}]}`, id)))
+
+	t.Run("serverless container", func(t *testing.T) {
+		ctx := stubMonitor(t, id, "TotalRequestUnits", "total", "DatabaseName eq 'my-db' and CollectionName eq 'my-container'", 12000000)
+
+		d := schema.NewResourceData("azurerm_cosmosdb_sql_container", "azurerm", "azurerm_cosmosdb_sql_container.main", nil, gjson.Parse(`{"name": "my-container", "database_name": "my-db"}`))
+		d.AddReference("account_name", account, nil)
+
+		u := estimateUsage(t, ctx, azure.NewAzureRMCosmosdb(d, nil))
+		assert.Equal(t, int64(12000000), u["monthly_serverless_request_units"])
+	})
+
+	t.Run("autoscale database", func(t *testing.T) {
+		ctx := stubMonitor(t, id, "NormalizedRUConsumption", "maximum", "DatabaseName eq 'my-db'", 35.5)
+
+		d := schema.NewResourceData("azurerm_cosmosdb_sql_database", "azurerm", "azurerm_cosmosdb_sql_database.main", nil, gjson.Parse(`{"name": "my-db", "autoscale_settings": [{"max_throughput": 4000}]}`))
+		d.AddReference("account_name", account, nil)
+
+		u := estimateUsage(t, ctx, azure.NewAzureRMCosmosdb(d, nil))
+		assert.Equal(t, 35.5, u["max_request_units_utilization_percentage"])
+		assert.NotContains(t, u, "monthly_serverless_request_units")
+	})
+
+	t.Run("autoscale below minimum", func(t *testing.T) {
+		ctx := stubMonitor(t, id, "NormalizedRUConsumption", "maximum", "DatabaseName eq 'my-db'", 2)
+
+		d := schema.NewResourceData("azurerm_cosmosdb_sql_database", "azurerm", "azurerm_cosmosdb_sql_database.main", nil, gjson.Parse(`{"name": "my-db", "autoscale_settings": [{"max_throughput": 4000}]}`))
+		d.AddReference("account_name", account, nil)
+
+		u := estimateUsage(t, ctx, azure.NewAzureRMCosmosdb(d, nil))
+		assert.Equal(t, 10.0, u["max_request_units_utilization_percentage"])
+	})
+
+	t.Run("serverless cassandra table", func(t *testing.T) {
+		ctx := stubMonitor(t, id, "TotalRequestUnits", "total", "DatabaseName eq 'my-keyspace' and CollectionName eq 'my-table'", 500)
+
+		keyspace := schema.NewResourceData("azurerm_cosmosdb_cassandra_keyspace", "azurerm", "azurerm_cosmosdb_cassandra_keyspace.main", nil, gjson.Parse(`{"name": "my-keyspace"}`))
+		keyspace.AddReference("account_name", account, nil)
+		d := schema.NewResourceData("azurerm_cosmosdb_cassandra_table", "azurerm", "azurerm_cosmosdb_cassandra_table.main", nil, gjson.Parse(`{"name": "my-table"}`))
+		d.AddReference("cassandra_keyspace_id", keyspace, nil)
+
+		u := estimateUsage(t, ctx, azure.NewAzureRMCosmosdbCassandraTable(d, nil))
+		assert.Equal(t, int64(500), u["monthly_serverless_request_units"])
+	})
+}
@@ -1,10 +1,12 @@
 package azure
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/azure"
 	"github.com/shopspring/decimal"
 	"github.com/tidwall/gjson"
 )
@@ -79,9 +81,29 @@ func NewAzureRMEventHubs(d *schema.ResourceData, u *schema.UsageData) *schema.Re
 		}
 	}
 
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		id := d.Get("id").String()
+		if id == "" {
+			// The namespace hasn't been created yet so it has no metrics.
+			return nil
+		}
+
+		events, err := azure.EventHubsGetIngressEvents(ctx, id)
+		if err != nil {
+			return err
+		}
+		values["monthly_ingress_events"] = int64(events)
+
+		return nil
+	}
+
 	return &schema.Resource{
 		Name:           d.Address,
 		CostComponents: costComponents,
+		UsageSchema: []*schema.UsageItem{
+			{Key: "monthly_ingress_events", ValueType: schema.Int64, DefaultValue: 0},
+		},
+		EstimateUsage: estimate,
 	}
 }
 
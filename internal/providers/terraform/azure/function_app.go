@@ -27,6 +27,7 @@ func newFunctionApp(d *schema.ResourceData) *azure.FunctionApp {
 	if len(appServicePlan) == 0 && len(servicePlan) == 0 {
 		return &azure.FunctionApp{
 			Address: d.Address,
+			ID:      d.Get("id").String(),
 			Region:  region,
 			Tier:    "standard",
 		}
@@ -57,6 +58,7 @@ func newFunctionAppFromAppServicePlanRef(d *schema.ResourceData, data *schema.Re
 
 		return &azure.FunctionApp{
 			Address: d.Address,
+			ID:      d.Get("id").String(),
 			Region:  region,
 			SKUName: skuSize,
 			Tier:    tier,
@@ -71,6 +73,7 @@ func newFunctionAppFromAppServicePlanRef(d *schema.ResourceData, data *schema.Re
 
 	return &azure.FunctionApp{
 		Address: d.Address,
+		ID:      d.Get("id").String(),
 		Region:  region,
 		SKUName: strings.ToLower(skuName),
 		Tier:    tier,
@@ -50,6 +50,7 @@ func newAzureRMStorageAccount(d *schema.ResourceData) schema.CoreResource {
 
 	return &azure.StorageAccount{
 		Address:                d.Address,
+		ID:                     d.Get("id").String(),
 		Region:                 region,
 		AccessTier:             accessTier,
 		AccountKind:            accountKind,
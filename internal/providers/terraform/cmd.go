@@ -8,15 +8,48 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
+	"github.com/infracost/infracost/internal/hcl/modules"
 	"github.com/infracost/infracost/internal/logging"
 )
 
-var defaultTerraformBinary = "terraform"
+var (
+	defaultTerraformBinary = "terraform"
+	defaultOpenTofuBinary  = "tofu"
+
+	// lookPath is used to check which binaries are installed, it's a variable
+	// so it can be replaced in tests.
+	lookPath = exec.LookPath
+)
+
+// defaultBinary returns the binary to use for the project at path when a
+// custom binary hasn't been set. OpenTofu is used for projects that contain
+// .tofu files, or if the terraform binary isn't installed but tofu is.
+func defaultBinary(path string) string {
+	if _, err := lookPath(defaultOpenTofuBinary); err != nil {
+		return defaultTerraformBinary
+	}
+
+	if modules.IsOpenTofuDir(path) {
+		return defaultOpenTofuBinary
+	}
+
+	if _, err := lookPath(defaultTerraformBinary); err != nil {
+		return defaultOpenTofuBinary
+	}
+
+	return defaultTerraformBinary
+}
+
+// isOpenTofuBinary returns true if the binary is the OpenTofu CLI.
+func isOpenTofuBinary(binary string) bool {
+	return strings.TrimSuffix(filepath.Base(binary), ".exe") == defaultOpenTofuBinary
+}
 
 type CmdOptions struct {
 	TerraformBinary     string
@@ -61,7 +94,7 @@ func Cmd(opts *CmdOptions, args ...string) ([]byte, error) {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("TF_CLI_CONFIG_FILE=%s", opts.TerraformConfigFile))
 	}
 
-	subLogger := logging.Logger.With().Str("binary", "terraform").Logger().Level(zerolog.DebugLevel)
+	subLogger := logging.Logger.With().Str("binary", filepath.Base(exe)).Logger().Level(zerolog.DebugLevel)
 	logWriter := &cmdLogWriter{
 		logger: subLogger,
 		level:  zerolog.DebugLevel,
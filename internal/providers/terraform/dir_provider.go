@@ -26,6 +26,10 @@ import (
 
 var minTerraformVer = "v0.12"
 
+// minOpenTofuVer is the first OpenTofu release, older versions are Terraform
+// releases that report themselves as Terraform.
+var minOpenTofuVer = "v1.6.0"
+
 type DirProvider struct {
 	ctx                  *config.ProjectContext
 	Path                 string
@@ -51,7 +55,7 @@ type RunShowOptions struct {
 func NewDirProvider(ctx *config.ProjectContext, includePastResources bool) schema.Provider {
 	terraformBinary := ctx.ProjectConfig.TerraformBinary
 	if terraformBinary == "" {
-		terraformBinary = defaultTerraformBinary
+		terraformBinary = defaultBinary(ctx.ProjectConfig.Path)
 	}
 
 	return &DirProvider{
@@ -81,26 +85,50 @@ func (p *DirProvider) Type() string {
 }
 
 func (p *DirProvider) DisplayType() string {
+	if p.isOpenTofu() {
+		return "OpenTofu CLI"
+	}
+
 	return "Terraform CLI"
 }
 
+// isOpenTofu returns true if the provider runs the OpenTofu CLI rather than
+// the Terraform CLI.
+func (p *DirProvider) isOpenTofu() bool {
+	return !p.IsTerragrunt && isOpenTofuBinary(p.TerraformBinary)
+}
+
+// binaryName returns the name of the tool the provider runs for use in
+// messages.
+func (p *DirProvider) binaryName() string {
+	switch {
+	case p.IsTerragrunt:
+		return "Terragrunt"
+	case p.isOpenTofu():
+		return "OpenTofu"
+	default:
+		return "Terraform"
+	}
+}
+
 func (p *DirProvider) checks() error {
 	binary := p.TerraformBinary
+	binName := p.binaryName()
 
 	p.ctx.ContextValues.SetValue("terraformBinary", binary)
 
 	_, err := exec.LookPath(binary)
 	if err != nil {
-		msg := fmt.Sprintf("Terraform binary '%s' could not be found. You have two options:\n", binary)
-		msg += "1. Set a custom Terraform binary using the environment variable INFRACOST_TERRAFORM_BINARY.\n\n"
+		msg := fmt.Sprintf("%s binary '%s' could not be found. You have two options:\n", binName, binary)
+		msg += "1. Set a custom Terraform or OpenTofu binary using the environment variable INFRACOST_TERRAFORM_BINARY.\n\n"
 		msg += fmt.Sprintf("2. Set --path to a Terraform plan JSON file. See %s for how to generate this.", ui.LinkString("https://infracost.io/troubleshoot"))
-		return clierror.NewCLIError(errors.Errorf(msg), "Terraform binary could not be found")
+		return clierror.NewCLIError(errors.Errorf(msg), fmt.Sprintf("%s binary could not be found", binName))
 	}
 
 	out, err := exec.Command(binary, "-version").Output()
 	if err != nil {
-		msg := fmt.Sprintf("Could not get version of Terraform binary '%s'", binary)
-		return clierror.NewCLIError(errors.Errorf(msg), "Could not get version of Terraform binary")
+		msg := fmt.Sprintf("Could not get version of %s binary '%s'", binName, binary)
+		return clierror.NewCLIError(errors.Errorf(msg), fmt.Sprintf("Could not get version of %s binary", binName))
 	}
 
 	fullVersion := strings.SplitN(string(out), "\n", 2)[0]
@@ -499,7 +527,7 @@ func (p *DirProvider) runShow(opts *CmdOptions, spinner *ui.Spinner, planFile st
 }
 
 func IsTerraformDir(path string) bool {
-	for _, ext := range []string{"tf", "tf.json"} {
+	for _, ext := range []string{"tf", "tf.json", "tofu", "tofu.json"} {
 		matches, err := filepath.Glob(filepath.Join(path, fmt.Sprintf("*.%s", ext)))
 		if matches != nil && err == nil {
 			return true
@@ -527,21 +555,22 @@ func checkTerraformVersion(v string, fullV string) error {
 		return fmt.Errorf("Terraform %s is not supported. Please use Terraform version >= %s. Update it or set the environment variable INFRACOST_TERRAFORM_BINARY.", v, minTerraformVer) //nolint
 	}
 
+	if strings.HasPrefix(fullV, "OpenTofu ") && semver.Compare(v, minOpenTofuVer) < 0 {
+		return fmt.Errorf("OpenTofu %s is not supported. Please use OpenTofu version >= %s. Update it or set the environment variable INFRACOST_TERRAFORM_BINARY.", v, minOpenTofuVer) //nolint
+	}
+
 	if strings.HasPrefix(fullV, "terragrunt") && semver.Compare(v, minTerragruntVer) < 0 {
 		return fmt.Errorf("Terragrunt %s is not supported. Please use Terragrunt version >= %s. Update it or set the environment variable INFRACOST_TERRAFORM_BINARY.", v, minTerragruntVer) //nolint
 	}
 
-	// Allow any non-terraform and non-terragrunt binaries
+	// Allow any other binaries
 	return nil
 }
 
 func (p *DirProvider) buildTerraformErr(err error, isInit bool) error {
 	stderr := extractStderr(err)
 
-	binName := "Terraform"
-	if p.IsTerragrunt {
-		binName = "Terragrunt"
-	}
+	binName := p.binaryName()
 
 	msg := ""
 
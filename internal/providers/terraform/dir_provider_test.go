@@ -0,0 +1,78 @@
+package terraform
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTerraformVersion(t *testing.T) {
+	tests := []struct {
+		fullVersion string
+		returnsErr  bool
+	}{
+		{"Terraform v1.5.7", false},
+		{"Terraform v0.11.14", true},
+		{"OpenTofu v1.8.0", false},
+		{"OpenTofu v1.6.0-alpha1", true},
+		{"terragrunt version v0.50.0", false},
+		{"terragrunt version v0.10.0", true},
+		{"Something else v0.0.1", false},
+	}
+
+	for _, test := range tests {
+		err := checkTerraformVersion(shortTerraformVersion(test.fullVersion), test.fullVersion)
+		if test.returnsErr {
+			assert.Error(t, err, test.fullVersion)
+		} else {
+			assert.NoError(t, err, test.fullVersion)
+		}
+	}
+}
+
+func TestDefaultBinary(t *testing.T) {
+	origLookPath := lookPath
+	defer func() { lookPath = origLookPath }()
+
+	tfDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tfDir, "main.tf"), []byte{}, 0600))
+
+	tofuDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tofuDir, "main.tofu"), []byte{}, 0600))
+
+	installed := func(binaries ...string) func(string) (string, error) {
+		return func(file string) (string, error) {
+			for _, b := range binaries {
+				if b == file {
+					return "/usr/bin/" + file, nil
+				}
+			}
+
+			return "", errors.New("not found")
+		}
+	}
+
+	lookPath = installed("terraform", "tofu")
+	assert.Equal(t, "terraform", defaultBinary(tfDir))
+	assert.Equal(t, "tofu", defaultBinary(tofuDir))
+
+	lookPath = installed("tofu")
+	assert.Equal(t, "tofu", defaultBinary(tfDir))
+
+	lookPath = installed("terraform")
+	assert.Equal(t, "terraform", defaultBinary(tofuDir))
+
+	lookPath = installed()
+	assert.Equal(t, "terraform", defaultBinary(tfDir))
+}
+
+func TestIsOpenTofuBinary(t *testing.T) {
+	assert.True(t, isOpenTofuBinary("tofu"))
+	assert.True(t, isOpenTofuBinary("/usr/local/bin/tofu"))
+	assert.False(t, isOpenTofuBinary("terraform"))
+	assert.False(t, isOpenTofuBinary("terragrunt"))
+}
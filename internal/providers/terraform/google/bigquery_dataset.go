@@ -16,6 +16,7 @@ func NewBigQueryDataset(d *schema.ResourceData, u *schema.UsageData) *schema.Res
 	r := &google.BigQueryDataset{
 		Address: d.Address,
 		Region:  d.Get("region").String(),
+		Project: d.Get("project").String(),
 	}
 
 	r.PopulateUsage(u)
@@ -16,6 +16,8 @@ func NewCloudFunctionsFunction(d *schema.ResourceData, u *schema.UsageData) *sch
 	r := &google.CloudFunctionsFunction{
 		Address: d.Address,
 		Region:  d.Get("region").String(),
+		Project: d.Get("project").String(),
+		Name:    d.Get("name").String(),
 	}
 
 	if !d.IsEmpty("available_memory_mb") {
@@ -15,6 +15,8 @@ func getPubSubSubscriptionRegistryItem() *schema.RegistryItem {
 func NewPubSubSubscription(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	r := &google.PubSubSubscription{
 		Address: d.Address,
+		Project: d.Get("project").String(),
+		Name:    d.Get("name").String(),
 	}
 
 	r.PopulateUsage(u)
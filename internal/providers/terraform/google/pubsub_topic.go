@@ -15,6 +15,8 @@ func getPubSubTopicRegistryItem() *schema.RegistryItem {
 func NewPubSubTopic(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	r := &google.PubSubTopic{
 		Address: d.Address,
+		Project: d.Get("project").String(),
+		Name:    d.Get("name").String(),
 	}
 
 	r.PopulateUsage(u)
@@ -18,6 +18,8 @@ func NewStorageBucket(d *schema.ResourceData, u *schema.UsageData) *schema.Resou
 		Region:       d.Get("region").String(),
 		Location:     d.Get("location").String(),
 		StorageClass: d.Get("storage_class").String(),
+		Project:      d.Get("project").String(),
+		Name:         d.Get("name").String(),
 	}
 	r.PopulateUsage(u)
 	return r.BuildResource()
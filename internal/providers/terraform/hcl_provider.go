@@ -146,6 +146,11 @@ func NewHCLProvider(ctx *config.ProjectContext, rootPath hcl.RootPath, config *H
 	}
 
 	loader := modules.NewModuleLoader(ctx.RunContext.Config.CachePath(), modules.NewSharedHCLParser(), credsSource, ctx.RunContext.Config.TerraformSourceMap, logger, ctx.RunContext.ModuleMutex)
+	// OpenTofu uses its own registry for module sources without a hostname.
+	if isOpenTofuBinary(ctx.ProjectConfig.TerraformBinary) || modules.IsOpenTofuDir(rootPath.Path) {
+		loader.SetDefaultRegistryHost(modules.OpenTofuRegistryHost)
+	}
+
 	cachePath := ctx.RunContext.Config.CachePath()
 	initialPath := rootPath.Path
 	if filepath.IsAbs(cachePath) {
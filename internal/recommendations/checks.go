@@ -0,0 +1,282 @@
+package recommendations
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// check returns the recommendations for a resource.
+type check func(c *checkContext, p *schema.PartialResource) []candidate
+
+var checks = []check{
+	checkEBSVolumeType,
+	checkInstanceType,
+	checkDBInstanceClass,
+	checkS3BucketLifecycle,
+	checkNATGateways,
+	checkManagedDiskType,
+}
+
+// previousGenerationFamilies maps previous generation EC2 instance families
+// to the current generation family with the same sizes.
+var previousGenerationFamilies = map[string]string{
+	"t2": "t3",
+	"m3": "m5",
+	"m4": "m5",
+	"c3": "c5",
+	"c4": "c5",
+	"r3": "r5",
+	"r4": "r5",
+	"i2": "i3",
+	"d2": "d3",
+}
+
+// gravitonFamilies maps x86 EC2 instance families to the Graviton (arm64)
+// family with the same sizes.
+var gravitonFamilies = map[string]string{
+	"t3":  "t4g",
+	"t3a": "t4g",
+	"m5":  "m6g",
+	"m5a": "m6g",
+	"m6i": "m6g",
+	"c5":  "c6g",
+	"c5a": "c6g",
+	"c6i": "c6g",
+	"r5":  "r6g",
+	"r5a": "r6g",
+	"r6i": "r6g",
+}
+
+// previousGenerationDBFamilies maps previous generation RDS instance class
+// families to the current generation family with the same sizes.
+var previousGenerationDBFamilies = map[string]string{
+	"t2": "t3",
+	"m3": "m5",
+	"m4": "m5",
+	"r3": "r5",
+	"r4": "r5",
+}
+
+// gravitonDBFamilies maps x86 RDS instance class families to the Graviton
+// family with the same sizes.
+var gravitonDBFamilies = map[string]string{
+	"t3":  "t4g",
+	"m5":  "m6g",
+	"m6i": "m6g",
+	"r5":  "r6g",
+	"r6i": "r6g",
+}
+
+// gravitonUnsupportedDBEngines are the RDS engines that can't run on
+// Graviton instance classes.
+var gravitonUnsupportedDBEngines = []string{"sqlserver", "oracle"}
+
+func checkEBSVolumeType(c *checkContext, p *schema.PartialResource) []candidate {
+	switch p.Type {
+	case "aws_ebs_volume":
+		// gp2 is the default volume type.
+		volumeType := p.RawValues.Get("type").String()
+		if volumeType != "" && volumeType != "gp2" {
+			return nil
+		}
+
+		return []candidate{ebsVolumeCandidate(p, map[string]interface{}{"type": "gp3"})}
+	case "aws_instance":
+		changes := map[string]interface{}{}
+		if p.RawValues.Get("root_block_device.0.volume_type").String() == "gp2" {
+			changes["root_block_device.0.volume_type"] = "gp3"
+		}
+
+		for i, d := range p.RawValues.Get("ebs_block_device").Array() {
+			if d.Get("volume_type").String() == "gp2" {
+				changes[fmt.Sprintf("ebs_block_device.%d.volume_type", i)] = "gp3"
+			}
+		}
+
+		if len(changes) == 0 {
+			return nil
+		}
+
+		return []candidate{ebsVolumeCandidate(p, changes)}
+	}
+
+	return nil
+}
+
+func ebsVolumeCandidate(p *schema.PartialResource, changes map[string]interface{}) candidate {
+	return candidate{
+		partial:     p,
+		title:       "Use gp3 instead of gp2 EBS volumes",
+		description: "gp3 volumes cost less per GB than gp2 volumes and include 3,000 IOPS and 125 MB/s throughput regardless of their size.",
+		changes:     changes,
+	}
+}
+
+func checkInstanceType(c *checkContext, p *schema.PartialResource) []candidate {
+	if p.Type != "aws_instance" {
+		return nil
+	}
+
+	// Instances on dedicated hosts are not charged by instance type.
+	if len(p.References["host_id"]) > 0 || p.RawValues.Get("host_id").String() != "" {
+		return nil
+	}
+
+	instanceType := p.RawValues.Get("instance_type").String()
+	family, size, ok := strings.Cut(instanceType, ".")
+	if !ok {
+		return nil
+	}
+
+	if f, ok := previousGenerationFamilies[family]; ok {
+		return []candidate{previousGenerationCandidate(p, "instance_type", instanceType, f+"."+size)}
+	}
+
+	if f, ok := gravitonFamilies[family]; ok {
+		return []candidate{gravitonCandidate(p, "instance_type", instanceType, f+"."+size)}
+	}
+
+	return nil
+}
+
+func checkDBInstanceClass(c *checkContext, p *schema.PartialResource) []candidate {
+	if p.Type != "aws_db_instance" {
+		return nil
+	}
+
+	instanceClass := p.RawValues.Get("instance_class").String()
+	family, size, ok := strings.Cut(strings.TrimPrefix(instanceClass, "db."), ".")
+	if !ok || !strings.HasPrefix(instanceClass, "db.") {
+		return nil
+	}
+
+	if f, ok := previousGenerationDBFamilies[family]; ok {
+		return []candidate{previousGenerationCandidate(p, "instance_class", instanceClass, "db."+f+"."+size)}
+	}
+
+	engine := p.RawValues.Get("engine").String()
+	for _, e := range gravitonUnsupportedDBEngines {
+		if strings.HasPrefix(engine, e) {
+			return nil
+		}
+	}
+
+	if f, ok := gravitonDBFamilies[family]; ok {
+		return []candidate{gravitonCandidate(p, "instance_class", instanceClass, "db."+f+"."+size)}
+	}
+
+	return nil
+}
+
+func previousGenerationCandidate(p *schema.PartialResource, attr, from, to string) candidate {
+	return candidate{
+		partial:     p,
+		title:       "Use current generation instance type",
+		description: fmt.Sprintf("Change %s from the previous generation %s to %s, which has better price performance.", attr, from, to),
+		changes:     map[string]interface{}{attr: to},
+	}
+}
+
+func gravitonCandidate(p *schema.PartialResource, attr, from, to string) candidate {
+	return candidate{
+		partial:     p,
+		title:       "Use Graviton instance type",
+		description: fmt.Sprintf("Change %s from %s to the Graviton equivalent %s if the workload supports arm64.", attr, from, to),
+		changes:     map[string]interface{}{attr: to},
+	}
+}
+
+func checkS3BucketLifecycle(c *checkContext, p *schema.PartialResource) []candidate {
+	if p.Type != "aws_s3_bucket" {
+		return nil
+	}
+
+	if len(p.RawValues.Get("lifecycle_rule").Array()) > 0 {
+		return nil
+	}
+
+	if len(c.referencedBy("aws_s3_bucket_lifecycle_configuration", "bucket", p.Address)) > 0 {
+		return nil
+	}
+
+	return []candidate{{
+		partial:     p,
+		title:       "Add lifecycle rules to S3 bucket",
+		description: "Lifecycle rules can move objects to cheaper storage classes, and expire old objects and incomplete multipart uploads. The savings depend on how the bucket is used.",
+	}}
+}
+
+func checkNATGateways(c *checkContext, p *schema.PartialResource) []candidate {
+	if p.Type != "aws_nat_gateway" {
+		return nil
+	}
+
+	var gateways []*schema.PartialResource
+	for _, g := range c.partials {
+		if g.Type == "aws_nat_gateway" {
+			gateways = append(gateways, g)
+		}
+	}
+
+	// The first gateway is the one that is kept.
+	addresses := sortedAddresses(gateways)
+	if len(addresses) < 2 || addresses[0] == p.Address {
+		return nil
+	}
+
+	var savings *decimal.Decimal
+	if r := c.resources[p.Address]; r != nil {
+		for _, cc := range r.CostComponents {
+			if cc.Name == "NAT gateway" && cc.MonthlyCost != nil {
+				s := cc.MonthlyCost.Round(2)
+				savings = &s
+			}
+		}
+	}
+
+	return []candidate{{
+		partial:     p,
+		title:       "Share NAT gateways across availability zones",
+		description: fmt.Sprintf("The project has %d NAT gateways. Environments that don't need availability zone redundancy, such as development, can route all private subnets through one NAT gateway to save its hourly charge.", len(addresses)),
+		savings:     savings,
+	}}
+}
+
+// ssdDiskTypes maps Azure managed disk storage account types to the Standard
+// SSD type with the same redundancy.
+var ssdDiskTypes = map[string]string{
+	"Premium_LRS":  "StandardSSD_LRS",
+	"Premium_ZRS":  "StandardSSD_ZRS",
+	"Standard_LRS": "StandardSSD_LRS",
+}
+
+func checkManagedDiskType(c *checkContext, p *schema.PartialResource) []candidate {
+	if p.Type != "azurerm_managed_disk" {
+		return nil
+	}
+
+	diskType := p.RawValues.Get("storage_account_type").String()
+	to, ok := ssdDiskTypes[diskType]
+	if !ok {
+		return nil
+	}
+
+	// Standard HDD disks are cheaper to store, but are also charged for disk
+	// operations. The recommendation is only kept if the Standard SSD disk is
+	// cheaper for the expected usage.
+	description := fmt.Sprintf("Change storage_account_type from %s to %s for workloads that don't need Premium SSD performance.", diskType, to)
+	if diskType == "Standard_LRS" {
+		description = fmt.Sprintf("Change storage_account_type from %s to %s, which has lower disk operation costs for the expected usage.", diskType, to)
+	}
+
+	return []candidate{{
+		partial:     p,
+		title:       "Use Standard SSD managed disk",
+		description: description,
+		changes:     map[string]interface{}{"storage_account_type": to},
+	}}
+}
@@ -0,0 +1,318 @@
+// Package recommendations checks resources against well-known cloud cost best
+// practices, e.g. using gp3 instead of gp2 EBS volumes, and estimates the
+// monthly savings of following them by pricing the suggested alternative.
+package recommendations
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/prices"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// candidate is a recommendation for a resource before its savings have been
+// estimated.
+type candidate struct {
+	partial     *schema.PartialResource
+	title       string
+	description string
+
+	// changes are the attributes of the resource that are changed by the
+	// recommendation, keyed by their path, e.g. root_block_device.0.volume_type.
+	// If set the savings are estimated by pricing the resource with the
+	// changes applied and the recommendation is dropped if it doesn't save
+	// anything.
+	changes map[string]interface{}
+	// savings are the monthly savings of recommendations that can't be priced
+	// by changing the attributes of the resource. nil if the savings depend on
+	// usage that can't be estimated.
+	savings *decimal.Decimal
+}
+
+// checkContext holds the resources of the project so checks can look at
+// resources other than the one being checked.
+type checkContext struct {
+	partials  []*schema.PartialResource
+	addresses map[string]*schema.PartialResource
+	resources map[string]*schema.Resource
+}
+
+func newCheckContext(project *schema.Project) *checkContext {
+	c := &checkContext{
+		partials:  project.PartialResources,
+		addresses: make(map[string]*schema.PartialResource, len(project.PartialResources)),
+		resources: make(map[string]*schema.Resource, len(project.Resources)),
+	}
+
+	for _, p := range project.PartialResources {
+		c.addresses[p.Address] = p
+	}
+
+	for _, r := range project.Resources {
+		c.resources[r.Name] = r
+	}
+
+	return c
+}
+
+// referencedBy returns the addresses of the resources of type resourceType
+// that reference the resource at address with the attribute attr.
+func (c *checkContext) referencedBy(resourceType, attr, address string) []string {
+	var addresses []string
+	for _, p := range c.partials {
+		if p.Type != resourceType {
+			continue
+		}
+
+		for _, ref := range p.References[attr] {
+			if ref == address {
+				addresses = append(addresses, p.Address)
+			}
+		}
+	}
+
+	return addresses
+}
+
+// Populate checks the resources of the project and sets the project
+// recommendations. The prices of the project resources must have been
+// populated, since some of the savings are based on them.
+func Populate(ctx *config.RunContext, project *schema.Project) error {
+	return populate(ctx, prices.NewPriceSource(ctx), project)
+}
+
+func populate(ctx *config.RunContext, source prices.PriceSource, project *schema.Project) error {
+	c := newCheckContext(project)
+
+	var candidates []candidate
+	for _, p := range project.PartialResources {
+		if r := c.resources[p.Address]; r == nil || r.IsSkipped {
+			continue
+		}
+
+		for _, check := range checks {
+			candidates = append(candidates, check(c, p)...)
+		}
+	}
+
+	// Resources that are priced are rebuilt with and without the changes, so
+	// anything that is lost by rebuilding them, such as usage estimates from
+	// Infracost Cloud, affects both prices in the same way.
+	type pricedCandidate struct {
+		candidate
+		current     *schema.Resource
+		alternative *schema.Resource
+	}
+
+	var priced []*pricedCandidate
+	var resources []*schema.Resource
+	for _, cand := range candidates {
+		if cand.changes == nil {
+			continue
+		}
+
+		current := c.rebuild(cand.partial, nil)
+		alternative := c.rebuild(cand.partial, cand.changes)
+		if current == nil || alternative == nil {
+			continue
+		}
+
+		priced = append(priced, &pricedCandidate{candidate: cand, current: current, alternative: alternative})
+		resources = append(resources, current, alternative)
+	}
+
+	if len(resources) > 0 {
+		err := prices.PopulateAlternativePrices(ctx, source, resources)
+		if err != nil {
+			return err
+		}
+	}
+
+	savings := make(map[*schema.PartialResource]map[string]*decimal.Decimal)
+	for _, p := range priced {
+		p.current.CalculateCosts()
+		p.alternative.CalculateCosts()
+
+		if !hasPrices(p.current) || !hasPrices(p.alternative) {
+			continue
+		}
+
+		s := monthlyCost(p.current).Sub(monthlyCost(p.alternative)).Round(2)
+		if !s.IsPositive() {
+			continue
+		}
+
+		if savings[p.partial] == nil {
+			savings[p.partial] = make(map[string]*decimal.Decimal)
+		}
+		savings[p.partial][p.title] = &s
+	}
+
+	project.Recommendations = nil
+	for _, cand := range candidates {
+		s := cand.savings
+		if cand.changes != nil {
+			s = savings[cand.partial][cand.title]
+			if s == nil {
+				continue
+			}
+		}
+
+		project.Recommendations = append(project.Recommendations, &schema.Recommendation{
+			ResourceName:   cand.partial.Address,
+			ResourceType:   cand.partial.Type,
+			Title:          cand.title,
+			Description:    cand.description,
+			MonthlySavings: s,
+		})
+	}
+
+	return nil
+}
+
+// rebuild builds the resource again from its planned attributes with the
+// changes applied. The referenced resources are rebuilt from the planned
+// attributes of the project resources, so references of references are not
+// available.
+func (c *checkContext) rebuild(partial *schema.PartialResource, changes map[string]interface{}) *schema.Resource {
+	item, ok := (*terraform.GetResourceRegistryMap())[partial.Type]
+	if !ok || item.NoPrice {
+		return nil
+	}
+
+	values := partial.RawValues
+	if len(changes) > 0 {
+		var err error
+		values, err = setValues(values, changes)
+		if err != nil {
+			return nil
+		}
+	}
+
+	d := schema.NewResourceData(partial.Type, "", partial.Address, partial.Tags, values)
+	d.UsageData = partial.UsageData
+	for attr, addresses := range partial.References {
+		for _, address := range addresses {
+			if ref, ok := c.addresses[address]; ok {
+				d.AddReference(attr, schema.NewResourceData(ref.Type, "", ref.Address, ref.Tags, ref.RawValues), nil)
+			}
+		}
+	}
+
+	var r *schema.Resource
+	if item.CoreRFunc != nil {
+		cr := item.CoreRFunc(d)
+		if cr == nil {
+			return nil
+		}
+
+		r = schema.BuildResource(schema.NewPartialResource(d, nil, cr, nil), nil)
+	} else {
+		r = item.RFunc(d, partial.UsageData)
+		if r == nil {
+			return nil
+		}
+		r.ResourceType = partial.Type
+	}
+
+	if r.IsSkipped {
+		return nil
+	}
+
+	return r
+}
+
+// setValues returns a copy of the values with the changes applied. The keys
+// of changes are gjson paths made of object keys and array indexes.
+func setValues(values gjson.Result, changes map[string]interface{}) (gjson.Result, error) {
+	var v interface{}
+	if values.Raw != "" {
+		err := json.Unmarshal([]byte(values.Raw), &v)
+		if err != nil {
+			return gjson.Result{}, err
+		}
+	}
+
+	if v == nil {
+		v = map[string]interface{}{}
+	}
+
+	for path, value := range changes {
+		v = setValue(v, strings.Split(path, "."), value)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	return gjson.ParseBytes(b), nil
+}
+
+func setValue(v interface{}, path []string, value interface{}) interface{} {
+	if len(path) == 0 {
+		return value
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		t[path[0]] = setValue(t[path[0]], path[1:], value)
+		return t
+	case []interface{}:
+		i, err := strconv.Atoi(path[0])
+		if err != nil || i < 0 || i >= len(t) {
+			return t
+		}
+
+		t[i] = setValue(t[i], path[1:], value)
+		return t
+	default:
+		return map[string]interface{}{path[0]: setValue(nil, path[1:], value)}
+	}
+}
+
+// hasPrices returns true if a price was found for all the cost components of
+// the resource and its sub resources.
+func hasPrices(r *schema.Resource) bool {
+	for _, c := range r.CostComponents {
+		if c.CustomPrice() == nil && c.PriceHash() == "" {
+			return false
+		}
+	}
+
+	for _, s := range r.SubResources {
+		if !hasPrices(s) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func monthlyCost(r *schema.Resource) decimal.Decimal {
+	if r.MonthlyCost == nil {
+		return decimal.Zero
+	}
+
+	return *r.MonthlyCost
+}
+
+// sortedAddresses returns the addresses of the partial resources in order.
+func sortedAddresses(partials []*schema.PartialResource) []string {
+	addresses := make([]string, 0, len(partials))
+	for _, p := range partials {
+		addresses = append(addresses, p.Address)
+	}
+
+	sort.Strings(addresses)
+
+	return addresses
+}
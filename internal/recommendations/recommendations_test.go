@@ -0,0 +1,177 @@
+package recommendations
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// testSource is a PriceSource that prices cost components by their instance
+// type, volume type or SKU. Components of other products have a zero price,
+// and unknown instance types, volume types and SKUs have no products.
+type testSource struct {
+	prices map[string]string
+}
+
+var testPricedAttributes = []string{"instanceType", "volumeApiName", "skuName"}
+
+func (s *testSource) GetPrices(keys []apiclient.PriceQueryKey) ([]apiclient.PriceQueryResult, error) {
+	res := make([]apiclient.PriceQueryResult, len(keys))
+	for i, key := range keys {
+		price, ok := "0", true
+
+		for _, f := range key.CostComponent.ProductFilter.AttributeFilters {
+			if !contains(testPricedAttributes, f.Key) {
+				continue
+			}
+
+			v := ""
+			if f.Value != nil {
+				v = *f.Value
+			} else if f.ValueRegex != nil {
+				v = strings.TrimSuffix(strings.TrimPrefix(*f.ValueRegex, "/"), "/i")
+			}
+
+			price, ok = s.prices[v]
+		}
+
+		raw := `{"data":{"products":[]}}`
+		if ok {
+			raw = `{"data":{"products":[{"prices":[{"priceHash":"hash","USD":"` + price + `"}]}]}}`
+		}
+
+		res[i] = apiclient.PriceQueryResult{PriceQueryKey: key, Result: gjson.Parse(raw)}
+	}
+
+	return res, nil
+}
+
+func contains(arr []string, s string) bool {
+	for _, a := range arr {
+		if a == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func testProject(resources ...*schema.PartialResource) *schema.Project {
+	project := schema.NewProject("test", &schema.ProjectMetadata{})
+	project.PartialResources = resources
+	project.BuildResources(schema.UsageMap{})
+
+	for _, r := range project.Resources {
+		for _, c := range r.CostComponents {
+			c.SetPrice(decimal.NewFromInt(1))
+		}
+	}
+	schema.CalculateCosts(project)
+
+	return project
+}
+
+func testPartial(resourceType, address, values string, references map[string][]string) *schema.PartialResource {
+	return &schema.PartialResource{
+		Type:       resourceType,
+		Address:    address,
+		RawValues:  gjson.Parse(values),
+		References: references,
+		Resource:   &schema.Resource{Name: address},
+	}
+}
+
+func TestPopulate(t *testing.T) {
+	source := &testSource{prices: map[string]string{
+		"gp2":          "0.10",
+		"gp3":          "0.08",
+		"t2.micro":     "0.0116",
+		"t3.micro":     "0.0104",
+		"m4.large":     "0.10",
+		"P10 LRS":      "19.71",
+		"E10 LRS":      "9.60",
+		"db.m5.large":  "0.171",
+		"db.m6g.large": "0.152",
+		"db.r5.large":  "0.25",
+		"db.r6g.large": "0.225",
+		"S4 LRS":       "1.54",
+		"E4 LRS":       "2.40",
+	}}
+
+	natGateway := testPartial("aws_nat_gateway", "aws_nat_gateway.b", `{"region":"us-east-1"}`, nil)
+	natGateway.Resource = &schema.Resource{
+		Name: "aws_nat_gateway.b",
+		CostComponents: []*schema.CostComponent{
+			{Name: "NAT gateway", HourlyQuantity: decimalPtr(decimal.NewFromInt(1))},
+		},
+	}
+
+	project := testProject(
+		testPartial("aws_ebs_volume", "aws_ebs_volume.gp2", `{"region":"us-east-1","type":"gp2","size":100}`, nil),
+		testPartial("aws_ebs_volume", "aws_ebs_volume.gp3", `{"region":"us-east-1","type":"gp3","size":100}`, nil),
+		testPartial("aws_instance", "aws_instance.t2", `{"region":"us-east-1","instance_type":"t2.micro"}`, nil),
+		testPartial("aws_instance", "aws_instance.m4", `{"region":"us-east-1","instance_type":"m4.large"}`, nil),
+		testPartial("aws_db_instance", "aws_db_instance.mysql", `{"region":"us-east-1","instance_class":"db.m5.large","engine":"mysql"}`, nil),
+		testPartial("aws_db_instance", "aws_db_instance.sqlserver", `{"region":"us-east-1","instance_class":"db.r5.large","engine":"sqlserver-se"}`, nil),
+		testPartial("aws_s3_bucket", "aws_s3_bucket.logs", `{"region":"us-east-1"}`, nil),
+		testPartial("aws_s3_bucket", "aws_s3_bucket.data", `{"region":"us-east-1"}`, nil),
+		testPartial("aws_s3_bucket_lifecycle_configuration", "aws_s3_bucket_lifecycle_configuration.data", `{"region":"us-east-1"}`, map[string][]string{"bucket": {"aws_s3_bucket.data"}}),
+		testPartial("aws_nat_gateway", "aws_nat_gateway.a", `{"region":"us-east-1"}`, nil),
+		natGateway,
+		testPartial("azurerm_managed_disk", "azurerm_managed_disk.premium", `{"location":"eastus","storage_account_type":"Premium_LRS","disk_size_gb":128}`, nil),
+		testPartial("azurerm_managed_disk", "azurerm_managed_disk.standard", `{"location":"eastus","storage_account_type":"Standard_LRS","disk_size_gb":32}`, nil),
+	)
+
+	err := populate(config.EmptyRunContext(), source, project)
+	require.NoError(t, err)
+
+	type result struct {
+		ResourceName string
+		Title        string
+		Savings      string
+	}
+
+	var actual []result
+	for _, r := range project.Recommendations {
+		savings := ""
+		if r.MonthlySavings != nil {
+			savings = r.MonthlySavings.String()
+		}
+
+		actual = append(actual, result{r.ResourceName, r.Title, savings})
+	}
+
+	assert.Equal(t, []result{
+		{"aws_ebs_volume.gp2", "Use gp3 instead of gp2 EBS volumes", "2"},
+		{"aws_instance.t2", "Use current generation instance type", "0.88"},
+		{"aws_db_instance.mysql", "Use Graviton instance type", "13.87"},
+		{"aws_s3_bucket.logs", "Add lifecycle rules to S3 bucket", ""},
+		{"aws_nat_gateway.b", "Share NAT gateways across availability zones", "730"},
+		{"azurerm_managed_disk.premium", "Use Standard SSD managed disk", "10.11"},
+	}, actual)
+}
+
+func TestSetValues(t *testing.T) {
+	values := gjson.Parse(`{"instance_type":"t2.micro","root_block_device":[{"volume_type":"gp2"}]}`)
+
+	changed, err := setValues(values, map[string]interface{}{
+		"instance_type":                   "t3.micro",
+		"root_block_device.0.volume_type": "gp3",
+	})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"instance_type":"t3.micro","root_block_device":[{"volume_type":"gp3"}]}`, changed.Raw)
+	assert.Equal(t, "t2.micro", values.Get("instance_type").String())
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
@@ -1,16 +1,21 @@
 package aws
 
 import (
+	"context"
+	"math"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
 
 	"github.com/infracost/infracost/internal/usage"
+	"github.com/infracost/infracost/internal/usage/aws"
 	"github.com/shopspring/decimal"
 )
 
 type APIGatewayRestAPI struct {
 	Address         string
 	Region          string
+	Name            string
 	MonthlyRequests *int64 `infracost_usage:"monthly_requests"`
 }
 
@@ -49,10 +54,20 @@ func (r *APIGatewayRestAPI) BuildResource() *schema.Resource {
 		costComponents = append(costComponents, r.requestsCostComponent("Requests (first 333M)", "0", monthlyRequests))
 	}
 
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		requests, err := aws.APIGatewayGetRequests(ctx, r.Region, r.Name)
+		if err != nil {
+			return err
+		}
+		values["monthly_requests"] = int64(math.Round(requests))
+		return nil
+	}
+
 	return &schema.Resource{
 		Name:           r.Address,
 		CostComponents: costComponents,
 		UsageSchema:    APIGatewayRestAPIUsageSchema,
+		EstimateUsage:  estimate,
 	}
 }
 
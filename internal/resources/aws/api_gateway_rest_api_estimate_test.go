@@ -0,0 +1,20 @@
+package aws_test
+
+import (
+	"testing"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIGatewayRestAPI(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+
+	stub.stubMetric("Count", "Sum", 4567.0, "Name=ApiName", "Value=my-api")
+
+	args := &resources.APIGatewayRestAPI{Region: "us-east-1", Name: "my-api"}
+	resource := args.BuildResource()
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, int64(4567), estimates.usage["monthly_requests"])
+}
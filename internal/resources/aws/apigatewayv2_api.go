@@ -4,17 +4,21 @@ import (
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
 
+	"context"
 	"fmt"
+	"math"
 
 	"strings"
 
 	"github.com/infracost/infracost/internal/usage"
+	"github.com/infracost/infracost/internal/usage/aws"
 	"github.com/shopspring/decimal"
 )
 
 type APIGatewayV2API struct {
 	Address               string
 	Region                string
+	ID                    string
 	ProtocolType          string
 	MessageSizeKB         *int64 `infracost_usage:"message_size_kb"`
 	MonthlyConnectionMins *int64 `infracost_usage:"monthly_connection_mins"`
@@ -46,10 +50,29 @@ func (r *APIGatewayV2API) BuildResource() *schema.Resource {
 		costComponents = r.httpAPICostComponent()
 	}
 
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		switch strings.ToLower(r.ProtocolType) {
+		case "websocket":
+			messages, err := aws.APIGatewayV2GetMessages(ctx, r.Region, r.ID)
+			if err != nil {
+				return err
+			}
+			values["monthly_messages"] = int64(math.Round(messages))
+		case "http":
+			requests, err := aws.APIGatewayV2GetRequests(ctx, r.Region, r.ID)
+			if err != nil {
+				return err
+			}
+			values["monthly_requests"] = int64(math.Round(requests))
+		}
+		return nil
+	}
+
 	return &schema.Resource{
 		Name:           r.Address,
 		CostComponents: costComponents,
 		UsageSchema:    APIGatewayV2APIUsageSchema,
+		EstimateUsage:  estimate,
 	}
 }
 
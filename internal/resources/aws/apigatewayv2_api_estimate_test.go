@@ -0,0 +1,34 @@
+package aws_test
+
+import (
+	"testing"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIGatewayV2APIHTTP(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+
+	stub.stubMetric("Count", "Sum", 1200.0, "Name=ApiId", "Value=a1b2c3")
+
+	args := &resources.APIGatewayV2API{Region: "us-east-1", ID: "a1b2c3", ProtocolType: "HTTP"}
+	resource := args.BuildResource()
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, int64(1200), estimates.usage["monthly_requests"])
+	assert.Nil(t, estimates.usage["monthly_messages"])
+}
+
+func TestAPIGatewayV2APIWebsocket(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+
+	stub.stubMetric("MessageCount", "Sum", 98765.0, "Name=ApiId", "Value=a1b2c3")
+
+	args := &resources.APIGatewayV2API{Region: "us-east-1", ID: "a1b2c3", ProtocolType: "WEBSOCKET"}
+	resource := args.BuildResource()
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, int64(98765), estimates.usage["monthly_messages"])
+	assert.Nil(t, estimates.usage["monthly_requests"])
+}
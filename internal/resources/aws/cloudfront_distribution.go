@@ -4,7 +4,9 @@ import (
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
 
+	"context"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -12,11 +14,13 @@ import (
 	"github.com/shopspring/decimal"
 
 	"github.com/infracost/infracost/internal/usage"
+	"github.com/infracost/infracost/internal/usage/aws"
 )
 
 type CloudfrontDistribution struct {
 	Address string
 	Region  string
+	ID      string
 
 	IsOriginShieldEnabled     bool
 	IsSSLSupportMethodVIP     bool
@@ -170,9 +174,96 @@ func (r *CloudfrontDistribution) BuildResource() *schema.Resource {
 		Name:           r.Address,
 		CostComponents: components,
 		SubResources:   subResources,
+		UsageSchema:    CloudfrontDistributionUsageSchema,
+		EstimateUsage:  r.estimateUsage,
 	}
 }
 
+// estimateUsage sets the requests and data transfer of the distribution from
+// its CloudWatch metrics. CloudFront doesn't report these by location or
+// protocol, so the totals are split with the ratios of the existing usage, or
+// are all assigned to HTTPS requests from the US if there is no usage yet.
+func (r *CloudfrontDistribution) estimateUsage(ctx context.Context, values map[string]interface{}) error {
+	requests, err := aws.CloudFrontGetRequests(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+	split := splitByExistingUsage(requests, values["monthly_https_requests"], values["monthly_http_requests"])
+	values["monthly_https_requests"] = roundedUsage(split[0])
+	values["monthly_http_requests"] = roundedUsage(split[1])
+
+	downloaded, err := aws.CloudFrontGetBytesDownloaded(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+	values["monthly_data_transfer_to_internet_gb"] = gibUsage(splitByExistingUsage(downloaded, values["monthly_data_transfer_to_internet_gb"])[0])
+
+	uploaded, err := aws.CloudFrontGetBytesUploaded(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+	values["monthly_data_transfer_to_origin_gb"] = gibUsage(splitByExistingUsage(uploaded, values["monthly_data_transfer_to_origin_gb"])[0])
+
+	return nil
+}
+
+// splitByExistingUsage splits the total between the regions of the existing
+// sub-resource usages with the same ratios, or assigns it all to the US region
+// of the first usage if the existing usages are all zero.
+func splitByExistingUsage(total float64, existing ...interface{}) []map[string]float64 {
+	split := make([]map[string]float64, len(existing))
+	sum := 0.0
+
+	for i, e := range existing {
+		split[i] = make(map[string]float64)
+
+		m, _ := e.(map[string]interface{})
+		for k, v := range m {
+			switch n := v.(type) {
+			case int64:
+				split[i][k] = float64(n)
+			case float64:
+				split[i][k] = n
+			case int:
+				split[i][k] = float64(n)
+			}
+			sum += split[i][k]
+		}
+	}
+
+	if sum <= 0 {
+		for i := range split {
+			split[i] = make(map[string]float64)
+		}
+		split[0]["us"] = total
+		return split
+	}
+
+	for _, m := range split {
+		for k, v := range m {
+			m[k] = total * v / sum
+		}
+	}
+
+	return split
+}
+
+func roundedUsage(m map[string]float64) map[string]interface{} {
+	values := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		values[k] = int64(math.Round(v))
+	}
+	return values
+}
+
+func gibUsage(m map[string]float64) map[string]interface{} {
+	values := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		values[k] = asGiBFloat(v)
+	}
+	return values
+}
+
 type cloudfrontDistributionRegionData struct {
 	awsGroupedName                  string
 	priceRegion                     string
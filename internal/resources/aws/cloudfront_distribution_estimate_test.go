@@ -0,0 +1,51 @@
+package aws_test
+
+import (
+	"testing"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubCloudfrontMetrics(stub *stubbedAWS) {
+	stub.stubMetric("Requests", "Sum", 1000000.0, "Name=DistributionId", "Value=E2QWRUHAPOMQZL", "Value=Global")
+	stub.stubMetric("BytesDownloaded", "Sum", 100*1024*1024*1024, "Value=E2QWRUHAPOMQZL")
+	stub.stubMetric("BytesUploaded", "Sum", 10*1024*1024*1024, "Value=E2QWRUHAPOMQZL")
+}
+
+func TestCloudfrontDistribution(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+	stubCloudfrontMetrics(stub)
+
+	args := &resources.CloudfrontDistribution{Region: "global", ID: "E2QWRUHAPOMQZL"}
+	resource := args.BuildResource()
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, map[string]interface{}{"us": int64(1000000)}, estimates.usage["monthly_https_requests"])
+	assert.Equal(t, map[string]interface{}{}, estimates.usage["monthly_http_requests"])
+	assert.Equal(t, map[string]interface{}{"us": 100.0}, estimates.usage["monthly_data_transfer_to_internet_gb"])
+	assert.Equal(t, map[string]interface{}{"us": 10.0}, estimates.usage["monthly_data_transfer_to_origin_gb"])
+}
+
+func TestCloudfrontDistributionExistingSplit(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+	stubCloudfrontMetrics(stub)
+
+	args := &resources.CloudfrontDistribution{Region: "global", ID: "E2QWRUHAPOMQZL"}
+	resource := args.BuildResource()
+
+	values := map[string]interface{}{
+		"monthly_https_requests":               map[string]interface{}{"us": int64(600), "europe": int64(200)},
+		"monthly_http_requests":                map[string]interface{}{"us": 200.0},
+		"monthly_data_transfer_to_internet_gb": map[string]interface{}{"europe": int64(3), "japan": int64(1)},
+	}
+	require.NoError(t, resource.EstimateUsage(stub.ctx, values))
+
+	// The totals are split with the same ratios as the existing usage.
+	assert.Equal(t, map[string]interface{}{"us": int64(600000), "europe": int64(200000)}, values["monthly_https_requests"])
+	assert.Equal(t, map[string]interface{}{"us": int64(200000)}, values["monthly_http_requests"])
+	assert.Equal(t, map[string]interface{}{"europe": 75.0, "japan": 25.0}, values["monthly_data_transfer_to_internet_gb"])
+	assert.Equal(t, map[string]interface{}{"us": 10.0}, values["monthly_data_transfer_to_origin_gb"])
+}
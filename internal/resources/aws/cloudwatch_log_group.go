@@ -1,8 +1,11 @@
 package aws
 
 import (
+	"context"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/aws"
 
 	"github.com/shopspring/decimal"
 )
@@ -10,6 +13,7 @@ import (
 type CloudwatchLogGroup struct {
 	Address               string
 	Region                string
+	Name                  string
 	MonthlyDataIngestedGB *float64 `infracost_usage:"monthly_data_ingested_gb"`
 	StorageGB             *float64 `infracost_usage:"storage_gb"`
 	MonthlyDataScannedGB  *float64 `infracost_usage:"monthly_data_scanned_gb"`
@@ -42,6 +46,15 @@ func (r *CloudwatchLogGroup) BuildResource() *schema.Resource {
 		gbDataScanned = decimalPtr(decimal.NewFromFloat(*r.MonthlyDataScannedGB))
 	}
 
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		ingested, err := aws.LogsGetIncomingBytes(ctx, r.Region, r.Name)
+		if err != nil {
+			return err
+		}
+		values["monthly_data_ingested_gb"] = asGiBFloat(ingested)
+		return nil
+	}
+
 	return &schema.Resource{
 		Name: r.Address,
 		CostComponents: []*schema.CostComponent{
@@ -91,6 +104,7 @@ func (r *CloudwatchLogGroup) BuildResource() *schema.Resource {
 				},
 			},
 		},
-		UsageSchema: CloudwatchLogGroupUsageSchema,
+		UsageSchema:   CloudwatchLogGroupUsageSchema,
+		EstimateUsage: estimate,
 	}
 }
@@ -0,0 +1,21 @@
+package aws_test
+
+import (
+	"testing"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloudwatchLogGroup(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+
+	stub.stubMetric("IncomingBytes", "Sum", 1.25*1024*1024*1024, "Name=LogGroupName", "Value=%2Faws%2Flambda%2Fapi")
+
+	args := &resources.CloudwatchLogGroup{Region: "us-east-1", Name: "/aws/lambda/api"}
+	resource := args.BuildResource()
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, 1.25, estimates.usage["monthly_data_ingested_gb"])
+	assert.Nil(t, estimates.usage["storage_gb"])
+}
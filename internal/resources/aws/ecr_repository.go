@@ -1,8 +1,11 @@
 package aws
 
 import (
+	"context"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/aws"
 
 	"github.com/shopspring/decimal"
 )
@@ -10,6 +13,7 @@ import (
 type ECRRepository struct {
 	Address   string
 	Region    string
+	Name      string
 	StorageGB *float64 `infracost_usage:"storage_gb"`
 }
 
@@ -35,6 +39,15 @@ func (r *ECRRepository) BuildResource() *schema.Resource {
 		storageSize = decimalPtr(decimal.NewFromFloat(*r.StorageGB))
 	}
 
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		storage, err := aws.ECRGetStorageBytes(ctx, r.Region, r.Name)
+		if err != nil {
+			return err
+		}
+		values["storage_gb"] = asGiBFloat(float64(storage))
+		return nil
+	}
+
 	return &schema.Resource{
 		Name: r.Address,
 		CostComponents: []*schema.CostComponent{
@@ -51,6 +64,7 @@ func (r *ECRRepository) BuildResource() *schema.Resource {
 				},
 			},
 		},
-		UsageSchema: ECRRepositoryUsageSchema,
+		UsageSchema:   ECRRepositoryUsageSchema,
+		EstimateUsage: estimate,
 	}
 }
@@ -0,0 +1,43 @@
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	resources "github.com/infracost/infracost/internal/resources/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestECRRepository(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+
+	stub.WhenBody(`"repositoryName":"my-repo"`, `"nextToken":"page-2"`).Then(200, `{
+		"imageDetails": [{"imageSizeInBytes": 1073741824}]
+	}`)
+	stub.WhenBody(`"repositoryName":"my-repo"`).Then(200, `{
+		"imageDetails": [{"imageSizeInBytes": 536870912}, {"imageSizeInBytes": 1073741824}],
+		"nextToken": "page-2"
+	}`)
+
+	args := &resources.ECRRepository{Region: "us-east-1", Name: "my-repo"}
+	resource := args.BuildResource()
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, 2.5, estimates.usage["storage_gb"])
+}
+
+func TestECRRepositoryError(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+
+	stub.WhenBody(`"repositoryName":"missing"`).Then(400, `{
+		"__type": "RepositoryNotFoundException",
+		"message": "The repository with name 'missing' does not exist"
+	}`)
+
+	args := &resources.ECRRepository{Region: "us-east-1", Name: "missing"}
+	resource := args.BuildResource()
+	err := resource.EstimateUsage(stub.ctx, map[string]interface{}{})
+	var notFound *types.RepositoryNotFoundException
+	assert.ErrorAs(t, err, &notFound)
+}
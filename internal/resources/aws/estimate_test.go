@@ -153,3 +153,25 @@ func stubAWS(t *testing.T) *stubbedAWS {
 	stub.ctx = awsusage.WithTestEndpoint(context.TODO(), stub.server.URL)
 	return stub
 }
+
+// stubMetric stubs the CloudWatch statistic of a metric, with the other body
+// fragments used to match the dimensions of the request.
+func (sa *stubbedAWS) stubMetric(metric string, statistic string, value float64, fragments ...string) {
+	fragments = append([]string{"GetMetricStatistics", "MetricName=" + metric, "Statistics.member.1=" + statistic}, fragments...)
+	sa.WhenBody(fragments...).Then(200, fmt.Sprintf(`
+		<GetMetricStatisticsResponse xmlns="http://monitoring.amazonaws.com/doc/2010-08-01/">
+		  <GetMetricStatisticsResult>
+		    <Datapoints>
+		      <member>
+		        <%[2]s>%[3]f</%[2]s>
+		        <Timestamp>1970-01-01T00:00:00Z</Timestamp>
+		      </member>
+		    </Datapoints>
+		    <Label>%[1]s</Label>
+		  </GetMetricStatisticsResult>
+		  <ResponseMetadata>
+		    <RequestId>00000000-0000-0000-0000-000000000000</RequestId>
+		  </ResponseMetadata>
+		</GetMetricStatisticsResponse>
+	`, metric, statistic, value))
+}
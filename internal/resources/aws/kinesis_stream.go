@@ -1,10 +1,13 @@
 package aws
 
 import (
+	"context"
+
 	"github.com/shopspring/decimal"
 
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/aws"
 )
 
 // KinesisStream struct represents Kinesis Data Streams a fully managed, serverless streaming data service
@@ -14,6 +17,7 @@ import (
 type KinesisStream struct {
 	Address    string
 	Region     string
+	Name       string
 	StreamMode string
 	ShardCount int64
 
@@ -95,6 +99,7 @@ func (r *KinesisStream) BuildResource() *schema.Resource {
 		Name:           r.Address,
 		UsageSchema:    r.UsageSchema(),
 		CostComponents: costComponents,
+		EstimateUsage:  r.estimateUsage,
 	}
 }
 
@@ -384,3 +389,34 @@ func (r *KinesisStream) provisionedEfoConsumersCostComponent() *schema.CostCompo
 		},
 	}
 }
+
+// estimateUsage sets the data put into and read from an on-demand stream, or
+// the PUT payload units of a provisioned stream, from its CloudWatch metrics.
+func (r *KinesisStream) estimateUsage(ctx context.Context, values map[string]interface{}) error {
+	if r.StreamMode == provisionedStreamName {
+		// Each record is at least one 25KB PUT payload unit, larger records
+		// are rare enough that the number of records is used.
+		records, err := aws.KinesisGetIncomingRecords(ctx, r.Region, r.Name)
+		if err != nil {
+			return err
+		}
+		values["monthly_provisioned_put_units"] = records
+		return nil
+	}
+
+	if r.StreamMode == onDemandStreamName {
+		in, err := aws.KinesisGetIncomingBytes(ctx, r.Region, r.Name)
+		if err != nil {
+			return err
+		}
+		values["monthly_on_demand_data_in_gb"] = asGiBFloat(in)
+
+		out, err := aws.KinesisGetOutgoingBytes(ctx, r.Region, r.Name)
+		if err != nil {
+			return err
+		}
+		values["monthly_on_demand_data_out_gb"] = asGiBFloat(out)
+	}
+
+	return nil
+}
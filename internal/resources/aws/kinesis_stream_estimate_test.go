@@ -0,0 +1,35 @@
+package aws_test
+
+import (
+	"testing"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKinesisStreamOnDemand(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+
+	stub.stubMetric("IncomingBytes", "Sum", 5*1024*1024*1024, "Value=my-stream")
+	stub.stubMetric("GetRecords.Bytes", "Sum", 12.5*1024*1024*1024, "Value=my-stream")
+
+	args := &resources.KinesisStream{Region: "us-east-1", Name: "my-stream", StreamMode: "ON_DEMAND"}
+	resource := args.BuildResource()
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, 5.0, estimates.usage["monthly_on_demand_data_in_gb"])
+	assert.Equal(t, 12.5, estimates.usage["monthly_on_demand_data_out_gb"])
+}
+
+func TestKinesisStreamProvisioned(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+
+	stub.stubMetric("IncomingRecords", "Sum", 3000000.0, "Value=my-stream")
+
+	args := &resources.KinesisStream{Region: "us-east-1", Name: "my-stream", StreamMode: "PROVISIONED", ShardCount: 2}
+	resource := args.BuildResource()
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, 3000000.0, estimates.usage["monthly_provisioned_put_units"])
+	assert.Nil(t, estimates.usage["monthly_on_demand_data_in_gb"])
+}
@@ -1,8 +1,11 @@
 package aws
 
 import (
+	"context"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/aws"
 
 	"strings"
 
@@ -13,6 +16,7 @@ type LB struct {
 	Address           string
 	LoadBalancerType  string
 	Region            string
+	ARNSuffix         string
 	RuleEvaluations   *int64   `infracost_usage:"rule_evaluations"`
 	NewConnections    *int64   `infracost_usage:"new_connections"`
 	ActiveConnections *int64   `infracost_usage:"active_connections"`
@@ -73,7 +77,45 @@ func (r *LB) BuildResource() *schema.Resource {
 		Name:           r.Address,
 		CostComponents: costComponents,
 		UsageSchema:    LBUsageSchema,
+		EstimateUsage:  r.estimateUsage,
+	}
+}
+
+// estimateUsage sets the LCU dimensions of the load balancer from its
+// CloudWatch metrics over the last 30 days, converted to the rates used by the
+// usage file: new connections and rule evaluations per second, active
+// connections per minute and GB processed per hour.
+func (r *LB) estimateUsage(ctx context.Context, values map[string]interface{}) error {
+	hours := float64(24 * 30)
+	seconds := hours * 60 * 60
+
+	newConnections, err := aws.ELBv2GetNewConnections(ctx, r.Region, r.LoadBalancerType, r.ARNSuffix)
+	if err != nil {
+		return err
 	}
+	values["new_connections"] = ceil64(newConnections / seconds)
+
+	activeConnections, err := aws.ELBv2GetActiveConnectionsPerMinute(ctx, r.Region, r.LoadBalancerType, r.ARNSuffix)
+	if err != nil {
+		return err
+	}
+	values["active_connections"] = ceil64(activeConnections)
+
+	processedBytes, err := aws.ELBv2GetProcessedBytes(ctx, r.Region, r.LoadBalancerType, r.ARNSuffix)
+	if err != nil {
+		return err
+	}
+	values["processed_bytes_gb"] = asGiBFloat(processedBytes / hours)
+
+	if strings.ToLower(r.LoadBalancerType) == "application" {
+		ruleEvaluations, err := aws.ELBv2GetRuleEvaluations(ctx, r.Region, r.LoadBalancerType, r.ARNSuffix)
+		if err != nil {
+			return err
+		}
+		values["rule_evaluations"] = ceil64(ruleEvaluations / seconds)
+	}
+
+	return nil
 }
 
 func (r *LB) applicationLBCostComponents(maxLCU *decimal.Decimal) []*schema.CostComponent {
@@ -0,0 +1,45 @@
+package aws_test
+
+import (
+	"testing"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+const secondsInMonth = 60 * 60 * 24 * 30
+
+func TestLBApplication(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+
+	stub.stubMetric("NewConnectionCount", "Sum", 25*secondsInMonth, "Value=app%2Fmy-lb%2F50dc6c495c0c9188")
+	stub.stubMetric("ActiveConnectionCount", "Sum", 3000*60*24*30, "Value=app%2Fmy-lb%2F50dc6c495c0c9188")
+	stub.stubMetric("ProcessedBytes", "Sum", 2*720*1024*1024*1024, "Value=app%2Fmy-lb%2F50dc6c495c0c9188")
+	stub.stubMetric("RuleEvaluations", "Sum", 1000*secondsInMonth, "Value=app%2Fmy-lb%2F50dc6c495c0c9188")
+
+	args := &resources.LB{Region: "us-east-1", LoadBalancerType: "application", ARNSuffix: "app/my-lb/50dc6c495c0c9188"}
+	resource := args.BuildResource()
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, int64(25), estimates.usage["new_connections"])
+	assert.Equal(t, int64(3000), estimates.usage["active_connections"])
+	assert.Equal(t, 2.0, estimates.usage["processed_bytes_gb"])
+	assert.Equal(t, int64(1000), estimates.usage["rule_evaluations"])
+}
+
+func TestLBNetwork(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+
+	stub.stubMetric("NewFlowCount", "Sum", 800*secondsInMonth, "Namespace=AWS%2FNetworkELB")
+	stub.stubMetric("ActiveFlowCount", "Average", 100000.0, "Namespace=AWS%2FNetworkELB")
+	stub.stubMetric("ProcessedBytes", "Sum", 720*1024*1024*1024, "Namespace=AWS%2FNetworkELB")
+
+	args := &resources.LB{Region: "us-east-1", LoadBalancerType: "network", ARNSuffix: "net/my-lb/50dc6c495c0c9188"}
+	resource := args.BuildResource()
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, int64(800), estimates.usage["new_connections"])
+	assert.Equal(t, int64(100000), estimates.usage["active_connections"])
+	assert.Equal(t, 1.0, estimates.usage["processed_bytes_gb"])
+	assert.Nil(t, estimates.usage["rule_evaluations"])
+}
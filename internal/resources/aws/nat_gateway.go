@@ -1,14 +1,18 @@
 package aws
 
 import (
+	"context"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/aws"
 	"github.com/shopspring/decimal"
 )
 
 type NATGateway struct {
 	Address string
 	Region  string
+	ID      string
 
 	MonthlyDataProcessedGB *float64 `infracost_usage:"monthly_data_processed_gb"`
 }
@@ -27,9 +31,19 @@ func (a *NATGateway) BuildResource() *schema.Resource {
 		gbDataProcessed = decimalPtr(decimal.NewFromFloat(*a.MonthlyDataProcessedGB))
 	}
 
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		processed, err := aws.NATGatewayGetProcessedBytes(ctx, a.Region, a.ID)
+		if err != nil {
+			return err
+		}
+		values["monthly_data_processed_gb"] = asGiBFloat(processed)
+		return nil
+	}
+
 	return &schema.Resource{
-		Name:        a.Address,
-		UsageSchema: NATGatewayUsageSchema,
+		Name:          a.Address,
+		UsageSchema:   NATGatewayUsageSchema,
+		EstimateUsage: estimate,
 		CostComponents: []*schema.CostComponent{
 			{
 				Name:           "NAT gateway",
@@ -0,0 +1,21 @@
+package aws_test
+
+import (
+	"testing"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNATGateway(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+
+	stub.stubMetric("BytesInFromSource", "Sum", 3*1024*1024*1024, "Name=NatGatewayId", "Value=nat-0123456789")
+	stub.stubMetric("BytesInFromDestination", "Sum", 7*1024*1024*1024, "Name=NatGatewayId", "Value=nat-0123456789")
+
+	args := &resources.NATGateway{Region: "us-east-1", ID: "nat-0123456789"}
+	resource := args.BuildResource()
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, 10.0, estimates.usage["monthly_data_processed_gb"])
+}
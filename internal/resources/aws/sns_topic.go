@@ -1,11 +1,13 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"math"
 
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/aws"
 
 	"github.com/shopspring/decimal"
 )
@@ -17,6 +19,7 @@ import (
 type SNSTopic struct {
 	Address                 string
 	Region                  string
+	Name                    string
 	RequestSizeKB           *float64 `infracost_usage:"request_size_kb"`
 	MonthlyRequests         *int64   `infracost_usage:"monthly_requests"`
 	HTTPSubscriptions       *int64   `infracost_usage:"http_subscriptions"`
@@ -224,6 +227,7 @@ func (r *SNSTopic) BuildResource() *schema.Resource {
 		Name:           r.Address,
 		CostComponents: components,
 		UsageSchema:    SNSTopicUsageSchema,
+		EstimateUsage:  snsTopicEstimateUsage(r.Region, r.Name),
 	}
 }
 
@@ -284,6 +288,7 @@ func (r *SNSTopic) notificationsCostComponent(name, unit string, multiplier int6
 type SNSFIFOTopic struct {
 	Address         string
 	Region          string
+	Name            string
 	Subscriptions   int64
 	RequestSizeKB   *float64 `infracost_usage:"request_size_kb"`
 	MonthlyRequests *int64   `infracost_usage:"monthly_requests"`
@@ -445,5 +450,27 @@ func (r *SNSFIFOTopic) BuildResource() *schema.Resource {
 		Name:           r.Address,
 		CostComponents: components,
 		UsageSchema:    SNSTopicUsageSchema,
+		EstimateUsage:  snsTopicEstimateUsage(r.Region, r.Name),
+	}
+}
+
+// snsTopicEstimateUsage returns the func that estimates the requests to a
+// standard or FIFO topic from the messages published to it.
+func snsTopicEstimateUsage(region, name string) schema.EstimateFunc {
+	return func(ctx context.Context, values map[string]interface{}) error {
+		requests, err := aws.SNSGetPublishedMessages(ctx, region, name)
+		if err != nil {
+			return err
+		}
+		values["monthly_requests"] = int64(math.Round(requests))
+
+		size, err := aws.SNSGetPublishSizeBytes(ctx, region, name)
+		if err != nil {
+			return err
+		}
+		if size > 0 {
+			values["request_size_kb"] = math.Round(size/1024*100) / 100
+		}
+		return nil
 	}
 }
@@ -0,0 +1,36 @@
+package aws_test
+
+import (
+	"testing"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSNSTopic(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+
+	stub.stubMetric("NumberOfMessagesPublished", "Sum", 250000.0, "Name=TopicName", "Value=my-topic")
+	stub.stubMetric("PublishSize", "Average", 2048.0, "Name=TopicName", "Value=my-topic")
+
+	args := &resources.SNSTopic{Region: "us-east-1", Name: "my-topic"}
+	resource := args.BuildResource()
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, int64(250000), estimates.usage["monthly_requests"])
+	assert.Equal(t, 2.0, estimates.usage["request_size_kb"])
+}
+
+func TestSNSFIFOTopic(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+
+	stub.stubMetric("NumberOfMessagesPublished", "Sum", 1500.0, "Value=my-topic.fifo")
+	stub.stubMetric("PublishSize", "Average", 0.0, "Value=my-topic.fifo")
+
+	args := &resources.SNSFIFOTopic{Region: "us-east-1", Name: "my-topic.fifo", Subscriptions: 1}
+	resource := args.BuildResource()
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, int64(1500), estimates.usage["monthly_requests"])
+	assert.Nil(t, estimates.usage["request_size_kb"])
+}
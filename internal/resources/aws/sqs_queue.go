@@ -1,8 +1,11 @@
 package aws
 
 import (
+	"context"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/aws"
 
 	"github.com/shopspring/decimal"
 )
@@ -10,6 +13,7 @@ import (
 type SQSQueue struct {
 	Address         string
 	Region          string
+	Name            string
 	FifoQueue       bool
 	MonthlyRequests *float64 `infracost_usage:"monthly_requests"`
 	RequestSizeKB   *int64   `infracost_usage:"request_size_kb"`
@@ -43,8 +47,26 @@ func (r *SQSQueue) BuildResource() *schema.Resource {
 		requests = decimalPtr(r.calculateRequests(requestSize, decimal.NewFromFloat(*r.MonthlyRequests)))
 	}
 
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		requests, err := aws.SQSGetRequests(ctx, r.Region, r.Name)
+		if err != nil {
+			return err
+		}
+		values["monthly_requests"] = requests
+
+		size, err := aws.SQSGetMessageSizeBytes(ctx, r.Region, r.Name)
+		if err != nil {
+			return err
+		}
+		if size > 0 {
+			values["request_size_kb"] = ceil64(size / 1024)
+		}
+		return nil
+	}
+
 	return &schema.Resource{
-		Name: r.Address,
+		Name:          r.Address,
+		EstimateUsage: estimate,
 		CostComponents: []*schema.CostComponent{
 			{
 				Name:            "Requests",
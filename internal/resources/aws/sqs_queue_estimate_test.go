@@ -0,0 +1,25 @@
+package aws_test
+
+import (
+	"testing"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQSQueue(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+
+	stub.stubMetric("NumberOfMessagesSent", "Sum", 1000.0, "Value=my-queue")
+	stub.stubMetric("NumberOfMessagesReceived", "Sum", 900.0, "Value=my-queue")
+	stub.stubMetric("NumberOfMessagesDeleted", "Sum", 800.0, "Value=my-queue")
+	stub.stubMetric("NumberOfEmptyReceives", "Sum", 300.0, "Value=my-queue")
+	stub.stubMetric("SentMessageSize", "Average", 70000.0, "Value=my-queue", "Unit=Bytes")
+
+	args := &resources.SQSQueue{Region: "us-east-1", Name: "my-queue"}
+	resource := args.BuildResource()
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, 3000.0, estimates.usage["monthly_requests"])
+	assert.Equal(t, int64(69), estimates.usage["request_size_kb"])
+}
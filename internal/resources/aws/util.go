@@ -84,6 +84,12 @@ func asGiB(i int64) int64 {
 	return i
 }
 
+// asGiBFloat converts bytes to GiB rounded to two decimal places, for the
+// usage values that are not whole numbers of GiB.
+func asGiBFloat(b float64) float64 {
+	return math.Round(b/(1024*1024*1024)*100) / 100
+}
+
 func ceil64(f float64) int64 {
 	return int64(math.Ceil(f))
 }
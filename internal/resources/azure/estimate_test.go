@@ -0,0 +1,149 @@
+package azure_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	azureusage "github.com/infracost/infracost/internal/usage/azure"
+)
+
+type estimates struct {
+	t     *testing.T
+	usage map[string]interface{}
+}
+
+func newEstimates(ctx context.Context, t *testing.T, resource *schema.Resource) estimates {
+	u := make(map[string]interface{})
+	err := resource.EstimateUsage(ctx, u)
+	if err != nil {
+		t.Fatalf("Expected %T.EstimateUsage to succeed, got %s", resource, err)
+	}
+
+	for _, item := range resource.UsageSchema {
+		value := u[item.Key]
+		if value == nil {
+			continue
+		}
+		switch item.ValueType {
+		case schema.Int64:
+			if _, ok := value.(int64); !ok {
+				t.Errorf("Expected %T %s of type an int64, got a %T", resource, item.Key, value)
+			}
+		case schema.Float64:
+			if _, ok := value.(float64); !ok {
+				t.Errorf("Expected %T %s of type float64, got a %T", resource, item.Key, value)
+			}
+		default:
+			t.Errorf("Unexpected UsageItem.ValueType %v", item.ValueType)
+		}
+	}
+
+	return estimates{
+		t:     t,
+		usage: u,
+	}
+}
+
+// stubbedSeries is a time series of a stubbed metric, with the value of the
+// dimension it's split by.
+type stubbedSeries struct {
+	dimension string
+	value     string
+	points    []float64
+}
+
+type stubbedMetric struct {
+	resourceID  string
+	metric      string
+	aggregation string
+	filter      string
+	series      []stubbedSeries
+}
+
+type stubbedAzure struct {
+	t       *testing.T
+	server  *httptest.Server
+	ctx     context.Context
+	metrics []*stubbedMetric
+}
+
+func (sa *stubbedAzure) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	for _, m := range sa.metrics {
+		if r.URL.Path != m.resourceID+"/providers/Microsoft.Insights/metrics" ||
+			q.Get("metricnames") != m.metric ||
+			!strings.EqualFold(q.Get("aggregation"), m.aggregation) ||
+			q.Get("$filter") != m.filter {
+			continue
+		}
+
+		timeseries := make([]interface{}, 0, len(m.series))
+		for _, s := range m.series {
+			data := make([]map[string]interface{}, 0, len(s.points))
+			for _, p := range s.points {
+				data = append(data, map[string]interface{}{
+					"timeStamp":                    "1970-01-01T00:00:00Z",
+					strings.ToLower(m.aggregation): p,
+				})
+			}
+
+			ts := map[string]interface{}{"data": data}
+			if s.dimension != "" {
+				ts["metadatavalues"] = []interface{}{
+					map[string]interface{}{"name": map[string]string{"value": s.dimension}, "value": s.value},
+				}
+			}
+			timeseries = append(timeseries, ts)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"value": []interface{}{
+				map[string]interface{}{
+					"name":       map[string]string{"value": m.metric},
+					"errorCode":  "Success",
+					"timeseries": timeseries,
+				},
+			},
+		})
+		if err != nil {
+			sa.t.Fatalf("Cannot write stubbed HTTP response: %s", err)
+		}
+		return
+	}
+
+	sa.t.Fatalf("received unexpected stubbed Azure call: %s %s", r.Method, r.URL)
+}
+
+// stubMetric stubs the data points of a metric of the resource, with the
+// filter used to select or split the time series.
+func (sa *stubbedAzure) stubMetric(resourceID, metric, aggregation, filter string, series ...stubbedSeries) {
+	sa.metrics = append(sa.metrics, &stubbedMetric{
+		resourceID:  resourceID,
+		metric:      metric,
+		aggregation: aggregation,
+		filter:      filter,
+		series:      series,
+	})
+}
+
+func (sa *stubbedAzure) Close() {
+	sa.server.Close()
+}
+
+func stubAzure(t *testing.T) *stubbedAzure {
+	stub := &stubbedAzure{t: t}
+	stub.server = httptest.NewServer(stub)
+	stub.ctx = azureusage.WithTestEndpoint(context.TODO(), stub.server.URL)
+	return stub
+}
+
+func points(values ...float64) stubbedSeries {
+	return stubbedSeries{points: values}
+}
@@ -1,13 +1,16 @@
 package azure
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/shopspring/decimal"
 
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/azure"
 )
 
 var (
@@ -31,6 +34,7 @@ var (
 // Pricing information: https://azure.microsoft.com/en-us/pricing/details/app-service/windows/
 type FunctionApp struct {
 	Address string
+	ID      string
 	Region  string
 
 	SKUName string
@@ -99,9 +103,45 @@ func (r *FunctionApp) BuildResource() *schema.Resource {
 		Name:           r.Address,
 		CostComponents: costComponents,
 		UsageSchema:    r.UsageSchema(),
+		EstimateUsage:  r.estimateUsage,
 	}
 }
 
+// estimateUsage estimates the executions of a consumption function app and
+// their duration from the execution units in Azure Monitor. The execution
+// units are the memory used multiplied by the duration, so the duration is
+// calculated for the memory in the usage file or the minimum billed memory.
+func (r *FunctionApp) estimateUsage(ctx context.Context, values map[string]interface{}) error {
+	if r.ID == "" {
+		// The function app hasn't been created yet so it has no metrics.
+		return nil
+	}
+
+	executions, err := azure.FunctionsGetExecutions(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+	values["monthly_executions"] = int64(executions)
+
+	if executions == 0 {
+		return nil
+	}
+
+	units, err := azure.FunctionsGetExecutionUnits(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+
+	memoryMb := int64(128)
+	if r.MemoryMb != nil && *r.MemoryMb > 0 {
+		memoryMb = *r.MemoryMb
+	}
+	values["memory_mb"] = memoryMb
+	values["execution_duration_ms"] = int64(math.Ceil(units / executions / float64(memoryMb)))
+
+	return nil
+}
+
 func (r *FunctionApp) appFunctionPremiumCPUCostComponent() *schema.CostComponent {
 	var skuCPU *int64
 
@@ -0,0 +1,35 @@
+package azure_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/resources/azure"
+)
+
+const functionAppID = "/subscriptions/0000/resourceGroups/rg/providers/Microsoft.Web/sites/my-app"
+
+func TestFunctionAppEstimate(t *testing.T) {
+	stub := stubAzure(t)
+	defer stub.Close()
+
+	stub.stubMetric(functionAppID, "FunctionExecutionCount", "Total", "", points(400, 600))
+	stub.stubMetric(functionAppID, "FunctionExecutionUnits", "Total", "", points(25600000, 25600000))
+
+	memory := int64(256)
+	args := &azure.FunctionApp{ID: functionAppID, Region: "eastus", Tier: "standard", MemoryMb: &memory}
+	estimates := newEstimates(stub.ctx, t, args.BuildResource())
+	assert.Equal(t, int64(1000), estimates.usage["monthly_executions"])
+	assert.Equal(t, int64(256), estimates.usage["memory_mb"])
+	assert.Equal(t, int64(200), estimates.usage["execution_duration_ms"])
+}
+
+func TestFunctionAppEstimateNotCreated(t *testing.T) {
+	stub := stubAzure(t)
+	defer stub.Close()
+
+	args := &azure.FunctionApp{Region: "eastus", Tier: "standard"}
+	estimates := newEstimates(stub.ctx, t, args.BuildResource())
+	assert.Empty(t, estimates.usage)
+}
@@ -1,7 +1,9 @@
 package azure
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/shopspring/decimal"
@@ -12,6 +14,7 @@ import (
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/usage"
+	"github.com/infracost/infracost/internal/usage/azure"
 )
 
 // StorageAccount represents Azure data storage services.
@@ -27,6 +30,7 @@ import (
 //	File Storage: https://azure.microsoft.com/en-us/pricing/details/storage/files/
 type StorageAccount struct {
 	Address string
+	ID      string
 	Region  string
 
 	AccessTier             string
@@ -125,9 +129,44 @@ func (r *StorageAccount) BuildResource() *schema.Resource {
 		Name:           r.Address,
 		UsageSchema:    r.UsageSchema(),
 		CostComponents: costComponents,
+		EstimateUsage:  r.estimateUsage,
 	}
 }
 
+// estimateUsage estimates the storage of the account and its blob operations
+// from Azure Monitor. The operations of file shares aren't estimated since
+// they're billed by different groups of APIs.
+func (r *StorageAccount) estimateUsage(ctx context.Context, values map[string]interface{}) error {
+	if r.ID == "" {
+		// The storage account hasn't been created yet so it has no metrics.
+		return nil
+	}
+
+	capacity, err := azure.StorageGetUsedCapacityBytes(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+	storageGB := math.Round(capacity/(1024*1024*1024)*100) / 100
+
+	// File shares are billed for the data at rest.
+	if r.isFileStorage() {
+		values["data_at_rest_storage_gb"] = storageGB
+		return nil
+	}
+	values["storage_gb"] = storageGB
+
+	ops, err := azure.StorageGetBlobOperations(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+	values["monthly_write_operations"] = int64(ops.Write)
+	values["monthly_list_and_create_container_operations"] = int64(ops.ListAndCreateContainer)
+	values["monthly_read_operations"] = int64(ops.Read)
+	values["monthly_other_operations"] = int64(ops.Other)
+
+	return nil
+}
+
 // buildProductFilter returns a product filter for the Storage Account's products.
 func (r *StorageAccount) buildProductFilter(meterName string) *schema.ProductFilter {
 	var productName string
@@ -0,0 +1,60 @@
+package azure_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/resources/azure"
+)
+
+const storageAccountID = "/subscriptions/0000/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/mystorage"
+
+func TestStorageAccountEstimate(t *testing.T) {
+	stub := stubAzure(t)
+	defer stub.Close()
+
+	stub.stubMetric(storageAccountID, "UsedCapacity", "Average", "", points(10*1024*1024*1024, 12*1024*1024*1024))
+	stub.stubMetric(storageAccountID+"/blobServices/default", "Transactions", "Total", "ApiName eq '*'",
+		stubbedSeries{dimension: "ApiName", value: "PutBlob", points: []float64{100, 50}},
+		stubbedSeries{dimension: "ApiName", value: "PutBlockList", points: []float64{25}},
+		stubbedSeries{dimension: "ApiName", value: "ListBlobs", points: []float64{30}},
+		stubbedSeries{dimension: "ApiName", value: "GetBlob", points: []float64{500}},
+		stubbedSeries{dimension: "ApiName", value: "DeleteBlob", points: []float64{80}},
+		stubbedSeries{dimension: "ApiName", value: "GetBlobProperties", points: []float64{60}},
+	)
+
+	args := &azure.StorageAccount{
+		ID:                     storageAccountID,
+		Region:                 "eastus",
+		AccountKind:            "StorageV2",
+		AccountTier:            "Standard",
+		AccountReplicationType: "LRS",
+		AccessTier:             "Hot",
+	}
+	estimates := newEstimates(stub.ctx, t, args.BuildResource())
+	assert.Equal(t, 11.0, estimates.usage["storage_gb"])
+	assert.Equal(t, int64(175), estimates.usage["monthly_write_operations"])
+	assert.Equal(t, int64(30), estimates.usage["monthly_list_and_create_container_operations"])
+	assert.Equal(t, int64(500), estimates.usage["monthly_read_operations"])
+	assert.Equal(t, int64(60), estimates.usage["monthly_other_operations"])
+}
+
+func TestStorageAccountEstimateFileStorage(t *testing.T) {
+	stub := stubAzure(t)
+	defer stub.Close()
+
+	stub.stubMetric(storageAccountID, "UsedCapacity", "Average", "", points(5*1024*1024*1024))
+
+	args := &azure.StorageAccount{
+		ID:                     storageAccountID,
+		Region:                 "eastus",
+		AccountKind:            "FileStorage",
+		AccountTier:            "Premium",
+		AccountReplicationType: "LRS",
+	}
+	estimates := newEstimates(stub.ctx, t, args.BuildResource())
+	assert.Equal(t, 5.0, estimates.usage["data_at_rest_storage_gb"])
+	assert.NotContains(t, estimates.usage, "storage_gb")
+	assert.NotContains(t, estimates.usage, "monthly_read_operations")
+}
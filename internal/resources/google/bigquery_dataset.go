@@ -3,7 +3,9 @@ package google
 import (
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/google"
 
+	"context"
 	"fmt"
 
 	"github.com/shopspring/decimal"
@@ -12,6 +14,7 @@ import (
 type BigQueryDataset struct {
 	Address          string
 	Region           string
+	Project          string
 	MonthlyQueriesTB *float64 `infracost_usage:"monthly_queries_tb"`
 }
 
@@ -51,6 +54,25 @@ func (r *BigQueryDataset) BuildResource() *schema.Resource {
 				},
 			},
 		},
-		UsageSchema: BigQueryDatasetUsageSchema,
+		UsageSchema:   BigQueryDatasetUsageSchema,
+		EstimateUsage: r.estimateUsage,
 	}
 }
+
+// estimateUsage estimates the data scanned by queries from Cloud Monitoring.
+// The scanned bytes aren't split by dataset, so this is the data scanned by
+// the queries of the whole project.
+func (r *BigQueryDataset) estimateUsage(ctx context.Context, values map[string]interface{}) error {
+	if r.Project == "" {
+		// The dataset hasn't been created yet so it has no metrics.
+		return nil
+	}
+
+	bytes, err := google.BigQueryGetScannedBytesBilled(ctx, r.Project)
+	if err != nil {
+		return err
+	}
+	values["monthly_queries_tb"] = asTiB(bytes)
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package google_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/resources/google"
+)
+
+func TestBigQueryDatasetEstimate(t *testing.T) {
+	stub := stubGoogle(t)
+	defer stub.Close()
+
+	stub.stubMetric("my-project", "ALIGN_SUM", []string{"query/scanned_bytes_billed"}, value(3*1024*1024*1024*1024))
+
+	args := &google.BigQueryDataset{Project: "my-project", Region: "US"}
+	estimates := newEstimates(stub.ctx, t, args.BuildResource())
+	assert.Equal(t, 3.0, estimates.usage["monthly_queries_tb"])
+}
@@ -1,8 +1,12 @@
 package google
 
 import (
+	"context"
+	"math"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/google"
 
 	"github.com/shopspring/decimal"
 )
@@ -10,6 +14,8 @@ import (
 type CloudFunctionsFunction struct {
 	Address                    string
 	Region                     string
+	Project                    string
+	Name                       string
 	AvailableMemoryMB          *int64
 	RequestDurationMs          *int64   `infracost_usage:"request_duration_ms"`
 	MonthlyFunctionInvocations *int64   `infracost_usage:"monthly_function_invocations"`
@@ -131,7 +137,8 @@ func (r *CloudFunctionsFunction) BuildResource() *schema.Resource {
 				},
 			},
 		},
-		UsageSchema: CloudFunctionsFunctionUsageSchema,
+		UsageSchema:   CloudFunctionsFunctionUsageSchema,
+		EstimateUsage: r.estimateUsage,
 	}
 }
 
@@ -146,3 +153,36 @@ func (r *CloudFunctionsFunction) calculateGHzSeconds(memorySize decimal.Decimal,
 	seconds := averageRequestDuration.Div(decimal.NewFromInt(1000))
 	return monthlyRequests.Mul(gb).Mul(seconds)
 }
+
+// estimateUsage estimates the invocations of the function, their median
+// duration and the data sent by the function from Cloud Monitoring.
+func (r *CloudFunctionsFunction) estimateUsage(ctx context.Context, values map[string]interface{}) error {
+	if r.Project == "" || r.Name == "" {
+		// The function hasn't been created yet so it has no metrics.
+		return nil
+	}
+
+	invocations, err := google.CloudFunctionsGetInvocations(ctx, r.Project, r.Region, r.Name)
+	if err != nil {
+		return err
+	}
+	values["monthly_function_invocations"] = int64(invocations)
+
+	if invocations == 0 {
+		return nil
+	}
+
+	duration, err := google.CloudFunctionsGetMedianExecutionTimeMs(ctx, r.Project, r.Region, r.Name)
+	if err != nil {
+		return err
+	}
+	values["request_duration_ms"] = int64(math.Ceil(duration))
+
+	outbound, err := google.CloudFunctionsGetOutboundBytes(ctx, r.Project, r.Region, r.Name)
+	if err != nil {
+		return err
+	}
+	values["monthly_outbound_data_gb"] = asGiB(outbound)
+
+	return nil
+}
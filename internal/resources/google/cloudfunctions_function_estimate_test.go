@@ -0,0 +1,34 @@
+package google_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/resources/google"
+)
+
+func TestCloudFunctionsFunctionEstimate(t *testing.T) {
+	stub := stubGoogle(t)
+	defer stub.Close()
+
+	labels := []string{`resource.labels.function_name = "my-function"`, `resource.labels.region = "us-central1"`}
+	stub.stubMetric("my-project", "ALIGN_SUM", append([]string{"function/execution_count"}, labels...), value(250000))
+	stub.stubMetric("my-project", "ALIGN_PERCENTILE_50", append([]string{"function/execution_times"}, labels...), value(120500000))
+	stub.stubMetric("my-project", "ALIGN_SUM", append([]string{"function/network_egress"}, labels...), value(3*1024*1024*1024))
+
+	args := &google.CloudFunctionsFunction{Project: "my-project", Region: "us-central1", Name: "my-function"}
+	estimates := newEstimates(stub.ctx, t, args.BuildResource())
+	assert.Equal(t, int64(250000), estimates.usage["monthly_function_invocations"])
+	assert.Equal(t, int64(121), estimates.usage["request_duration_ms"])
+	assert.Equal(t, 3.0, estimates.usage["monthly_outbound_data_gb"])
+}
+
+func TestCloudFunctionsFunctionEstimateNotCreated(t *testing.T) {
+	stub := stubGoogle(t)
+	defer stub.Close()
+
+	args := &google.CloudFunctionsFunction{Region: "us-central1"}
+	estimates := newEstimates(stub.ctx, t, args.BuildResource())
+	assert.Empty(t, estimates.usage)
+}
@@ -0,0 +1,134 @@
+package google_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	googleusage "github.com/infracost/infracost/internal/usage/google"
+)
+
+type estimates struct {
+	t     *testing.T
+	usage map[string]interface{}
+}
+
+func newEstimates(ctx context.Context, t *testing.T, resource *schema.Resource) estimates {
+	u := make(map[string]interface{})
+	err := resource.EstimateUsage(ctx, u)
+	if err != nil {
+		t.Fatalf("Expected %T.EstimateUsage to succeed, got %s", resource, err)
+	}
+
+	for _, item := range resource.UsageSchema {
+		value := u[item.Key]
+		if value == nil {
+			continue
+		}
+		switch item.ValueType {
+		case schema.Int64:
+			if _, ok := value.(int64); !ok {
+				t.Errorf("Expected %T %s of type an int64, got a %T", resource, item.Key, value)
+			}
+		case schema.Float64:
+			if _, ok := value.(float64); !ok {
+				t.Errorf("Expected %T %s of type float64, got a %T", resource, item.Key, value)
+			}
+		case schema.SubResourceUsage:
+			if _, ok := value.(map[string]interface{}); !ok {
+				t.Errorf("Expected %T %s of type map[string]interface{}, got a %T", resource, item.Key, value)
+			}
+		default:
+			t.Errorf("Unexpected UsageItem.ValueType %v", item.ValueType)
+		}
+	}
+
+	return estimates{
+		t:     t,
+		usage: u,
+	}
+}
+
+// stubbedSeries is a time series of a stubbed metric with a single point for
+// the month, and the metric labels it's grouped by.
+type stubbedSeries struct {
+	labels map[string]string
+	value  float64
+}
+
+type stubbedMetric struct {
+	project         string
+	filterFragments []string
+	aligner         string
+	series          []stubbedSeries
+}
+
+type stubbedGoogle struct {
+	t       *testing.T
+	server  *httptest.Server
+	ctx     context.Context
+	metrics []*stubbedMetric
+}
+
+func (sg *stubbedGoogle) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	for _, m := range sg.metrics {
+		match := r.URL.Path == "/v3/projects/"+m.project+"/timeSeries" && q.Get("aggregation.perSeriesAligner") == m.aligner
+		for _, fragment := range m.filterFragments {
+			match = match && strings.Contains(q.Get("filter"), fragment)
+		}
+		if !match {
+			continue
+		}
+
+		timeseries := make([]interface{}, 0, len(m.series))
+		for _, s := range m.series {
+			timeseries = append(timeseries, map[string]interface{}{
+				"metric": map[string]interface{}{"labels": s.labels},
+				"points": []interface{}{
+					map[string]interface{}{"value": map[string]interface{}{"doubleValue": s.value}},
+				},
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(map[string]interface{}{"timeSeries": timeseries})
+		if err != nil {
+			sg.t.Fatalf("Cannot write stubbed HTTP response: %s", err)
+		}
+		return
+	}
+
+	sg.t.Fatalf("received unexpected stubbed Google call: %s %s", r.Method, r.URL)
+}
+
+// stubMetric stubs the time series of the project with the aligner and whose
+// filter contains the fragments.
+func (sg *stubbedGoogle) stubMetric(project, aligner string, filterFragments []string, series ...stubbedSeries) {
+	sg.metrics = append(sg.metrics, &stubbedMetric{
+		project:         project,
+		filterFragments: filterFragments,
+		aligner:         aligner,
+		series:          series,
+	})
+}
+
+func (sg *stubbedGoogle) Close() {
+	sg.server.Close()
+}
+
+func stubGoogle(t *testing.T) *stubbedGoogle {
+	stub := &stubbedGoogle{t: t}
+	stub.server = httptest.NewServer(stub)
+	stub.ctx = googleusage.WithTestEndpoint(context.TODO(), stub.server.URL)
+	return stub
+}
+
+func value(v float64) stubbedSeries {
+	return stubbedSeries{value: v}
+}
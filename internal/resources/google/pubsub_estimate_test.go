@@ -0,0 +1,33 @@
+package google_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/resources/google"
+)
+
+func TestPubSubTopicEstimate(t *testing.T) {
+	stub := stubGoogle(t)
+	defer stub.Close()
+
+	stub.stubMetric("my-project", "ALIGN_SUM", []string{"topic/byte_cost", `resource.labels.topic_id = "my-topic"`}, value(2.5*1024*1024*1024*1024))
+
+	args := &google.PubSubTopic{Project: "my-project", Name: "my-topic"}
+	estimates := newEstimates(stub.ctx, t, args.BuildResource())
+	assert.Equal(t, 2.5, estimates.usage["monthly_message_data_tb"])
+}
+
+func TestPubSubSubscriptionEstimate(t *testing.T) {
+	stub := stubGoogle(t)
+	defer stub.Close()
+
+	stub.stubMetric("my-project", "ALIGN_SUM", []string{"subscription/byte_cost", `resource.labels.subscription_id = "my-subscription"`}, value(0.5*1024*1024*1024*1024))
+	stub.stubMetric("my-project", "ALIGN_MEAN", []string{"subscription/retained_acked_bytes", `resource.labels.subscription_id = "my-subscription"`}, value(20*1024*1024*1024))
+
+	args := &google.PubSubSubscription{Project: "my-project", Name: "my-subscription"}
+	estimates := newEstimates(stub.ctx, t, args.BuildResource())
+	assert.Equal(t, 0.5, estimates.usage["monthly_message_data_tb"])
+	assert.Equal(t, 20.0, estimates.usage["storage_gb"])
+}
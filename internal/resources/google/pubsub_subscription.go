@@ -1,14 +1,19 @@
 package google
 
 import (
+	"context"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/google"
 
 	"github.com/shopspring/decimal"
 )
 
 type PubSubSubscription struct {
 	Address              string
+	Project              string
+	Name                 string
 	MonthlyMessageDataTB *float64 `infracost_usage:"monthly_message_data_tb"`
 	StorageGB            *float64 `infracost_usage:"storage_gb"`
 	SnapshotStorageGB    *float64 `infracost_usage:"snapshot_storage_gb"`
@@ -97,6 +102,30 @@ func (r *PubSubSubscription) BuildResource() *schema.Resource {
 				},
 			},
 		},
-		UsageSchema: PubSubSubscriptionUsageSchema,
+		UsageSchema:   PubSubSubscriptionUsageSchema,
+		EstimateUsage: r.estimateUsage,
 	}
 }
+
+// estimateUsage estimates the message data delivered to the subscription and
+// the retained acknowledged messages from Cloud Monitoring.
+func (r *PubSubSubscription) estimateUsage(ctx context.Context, values map[string]interface{}) error {
+	if r.Project == "" || r.Name == "" {
+		// The subscription hasn't been created yet so it has no metrics.
+		return nil
+	}
+
+	bytes, err := google.PubSubGetSubscriptionBytes(ctx, r.Project, r.Name)
+	if err != nil {
+		return err
+	}
+	values["monthly_message_data_tb"] = asTiB(bytes)
+
+	retained, err := google.PubSubGetRetainedAckedBytes(ctx, r.Project, r.Name)
+	if err != nil {
+		return err
+	}
+	values["storage_gb"] = asGiB(retained)
+
+	return nil
+}
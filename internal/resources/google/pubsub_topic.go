@@ -1,14 +1,19 @@
 package google
 
 import (
+	"context"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/google"
 
 	"github.com/shopspring/decimal"
 )
 
 type PubSubTopic struct {
 	Address              string
+	Project              string
+	Name                 string
 	MonthlyMessageDataTB *float64 `infracost_usage:"monthly_message_data_tb"`
 }
 
@@ -49,6 +54,24 @@ func (r *PubSubTopic) BuildResource() *schema.Resource {
 				},
 			},
 		},
-		UsageSchema: PubSubTopicUsageSchema,
+		UsageSchema:   PubSubTopicUsageSchema,
+		EstimateUsage: r.estimateUsage,
+	}
+}
+
+// estimateUsage estimates the message data published to the topic from Cloud
+// Monitoring.
+func (r *PubSubTopic) estimateUsage(ctx context.Context, values map[string]interface{}) error {
+	if r.Project == "" || r.Name == "" {
+		// The topic hasn't been created yet so it has no metrics.
+		return nil
 	}
+
+	bytes, err := google.PubSubGetTopicBytes(ctx, r.Project, r.Name)
+	if err != nil {
+		return err
+	}
+	values["monthly_message_data_tb"] = asTiB(bytes)
+
+	return nil
 }
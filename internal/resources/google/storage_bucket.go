@@ -4,7 +4,9 @@ import (
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/usage"
+	"github.com/infracost/infracost/internal/usage/google"
 
+	"context"
 	"fmt"
 	"strings"
 
@@ -14,6 +16,8 @@ import (
 type StorageBucket struct {
 	Address                     string
 	Region                      string
+	Project                     string
+	Name                        string
 	Location                    string
 	StorageClass                string
 	StorageGB                   *float64                         `infracost_usage:"storage_gb"`
@@ -62,6 +66,7 @@ func (r *StorageBucket) BuildResource() *schema.Resource {
 		SubResources: []*schema.Resource{
 			r.MonthlyEgressDataTransferGB.BuildResource(),
 		}, UsageSchema: StorageBucketUsageSchema,
+		EstimateUsage: r.estimateUsage,
 	}
 }
 
@@ -243,3 +248,27 @@ func dataRetrievalCostComponent(r *StorageBucket) *schema.CostComponent {
 		},
 	}
 }
+
+// estimateUsage estimates the storage of the bucket and its Class A and Class
+// B operations from Cloud Monitoring.
+func (r *StorageBucket) estimateUsage(ctx context.Context, values map[string]interface{}) error {
+	if r.Project == "" || r.Name == "" {
+		// The bucket hasn't been created yet so it has no metrics.
+		return nil
+	}
+
+	storage, err := google.StorageGetBytes(ctx, r.Project, r.Name)
+	if err != nil {
+		return err
+	}
+	values["storage_gb"] = asGiB(storage)
+
+	classA, classB, err := google.StorageGetOperations(ctx, r.Project, r.Name)
+	if err != nil {
+		return err
+	}
+	values["monthly_class_a_operations"] = int64(classA)
+	values["monthly_class_b_operations"] = int64(classB)
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package google_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/resources/google"
+)
+
+func TestStorageBucketEstimate(t *testing.T) {
+	stub := stubGoogle(t)
+	defer stub.Close()
+
+	stub.stubMetric("my-project", "ALIGN_MEAN", []string{"storage/total_bytes", `resource.labels.bucket_name = "my-bucket"`}, value(1.5*1024*1024*1024))
+	stub.stubMetric("my-project", "ALIGN_SUM", []string{"api/request_count", `resource.labels.bucket_name = "my-bucket"`},
+		stubbedSeries{labels: map[string]string{"method": "WriteObject"}, value: 1000},
+		stubbedSeries{labels: map[string]string{"method": "ListObjects"}, value: 200},
+		stubbedSeries{labels: map[string]string{"method": "ReadObject"}, value: 5000},
+		stubbedSeries{labels: map[string]string{"method": "GetObjectMetadata"}, value: 300},
+		stubbedSeries{labels: map[string]string{"method": "DeleteObject"}, value: 400},
+	)
+
+	args := &google.StorageBucket{Project: "my-project", Name: "my-bucket", Location: "US", StorageClass: "STANDARD"}
+	estimates := newEstimates(stub.ctx, t, args.BuildResource())
+	assert.Equal(t, 1.5, estimates.usage["storage_gb"])
+	assert.Equal(t, int64(1200), estimates.usage["monthly_class_a_operations"])
+	assert.Equal(t, int64(5300), estimates.usage["monthly_class_b_operations"])
+}
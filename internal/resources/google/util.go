@@ -2,6 +2,7 @@ package google
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"regexp"
 	"strings"
@@ -29,6 +30,16 @@ func decimalPtr(d decimal.Decimal) *decimal.Decimal {
 	return &d
 }
 
+// asGiB returns the bytes in GiB rounded to 2 decimal places.
+func asGiB(b float64) float64 {
+	return math.Round(b/(1024*1024*1024)*100) / 100
+}
+
+// asTiB returns the bytes in TiB rounded to 4 decimal places.
+func asTiB(b float64) float64 {
+	return math.Round(b/(1024*1024*1024*1024)*10000) / 10000
+}
+
 func intPtrToDecimalPtr(i *int64) *decimal.Decimal {
 	if i == nil {
 		return nil
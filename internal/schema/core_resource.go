@@ -57,6 +57,13 @@ type PartialResource struct {
 	UsageData *UsageData
 	Metadata  map[string]gjson.Result
 
+	// RawValues are the planned attributes of the resource. These are kept so
+	// they can be used as input to policies.
+	RawValues gjson.Result
+	// References contains the addresses of the resources referenced by each
+	// attribute of the resource.
+	References map[string][]string
+
 	// CoreResource is the new/preferred struct for providing an intermediate-object
 	// that contains all provider-derived information, but has not yet been built into
 	// a Resource.
@@ -78,12 +85,31 @@ func NewPartialResource(d *ResourceData, r *Resource, cr CoreResource, cloudReso
 		Tags:             d.Tags,
 		UsageData:        d.UsageData,
 		Metadata:         d.Metadata,
+		RawValues:        d.RawValues,
+		References:       referenceAddresses(d.ReferencesMap),
 		CoreResource:     cr,
 		Resource:         r,
 		CloudResourceIDs: cloudResourceIds,
 	}
 }
 
+func referenceAddresses(refs map[string][]*ResourceData) map[string][]string {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	addresses := make(map[string][]string, len(refs))
+	for attr, rds := range refs {
+		for _, rd := range rds {
+			if rd != nil {
+				addresses[attr] = append(addresses[attr], rd.Address)
+			}
+		}
+	}
+
+	return addresses
+}
+
 // BuildResource create a new Resource from the CoreResource, or (for backward compatibility) returns
 // a previously built Resource
 func BuildResource(partial *PartialResource, fetchedUsage *UsageData) *Resource {
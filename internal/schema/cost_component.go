@@ -20,9 +20,13 @@ type CostComponent struct {
 	MonthlyDiscountPerc  float64
 	price                decimal.Decimal
 	customPrice          *decimal.Decimal
+	listPrice            *decimal.Decimal
 	priceHash            string
 	HourlyCost           *decimal.Decimal
 	MonthlyCost          *decimal.Decimal
+	// ListMonthlyCost is the monthly cost at the list price. This is only set
+	// if a pricing override changed the price of the cost component.
+	ListMonthlyCost *decimal.Decimal
 }
 
 func (c *CostComponent) CalculateCosts() {
@@ -33,6 +37,9 @@ func (c *CostComponent) CalculateCosts() {
 	if c.MonthlyQuantity != nil {
 		discountMul := decimal.NewFromFloat(1.0 - c.MonthlyDiscountPerc)
 		c.MonthlyCost = decimalPtr(c.price.Mul(*c.MonthlyQuantity).Mul(discountMul))
+		if c.listPrice != nil {
+			c.ListMonthlyCost = decimalPtr(c.listPrice.Mul(*c.MonthlyQuantity).Mul(discountMul))
+		}
 	}
 }
 
@@ -68,6 +75,29 @@ func (c *CostComponent) CustomPrice() *decimal.Decimal {
 	return c.customPrice
 }
 
+// SetListPrice sets the list price of the cost component. This is used when
+// the price has been changed by a pricing override, so the output can show
+// both the list price and the effective price.
+func (c *CostComponent) SetListPrice(price *decimal.Decimal) {
+	c.listPrice = price
+}
+
+// ListPrice returns the list price of the cost component, or nil if the price
+// has not been changed by a pricing override.
+func (c *CostComponent) ListPrice() *decimal.Decimal {
+	return c.listPrice
+}
+
+// UnitMultiplierListPrice returns the list price in the unit shown in the
+// output, or nil if the price has not been changed by a pricing override.
+func (c *CostComponent) UnitMultiplierListPrice() *decimal.Decimal {
+	if c.listPrice == nil {
+		return nil
+	}
+
+	return decimalPtr(c.listPrice.Mul(c.UnitMultiplier))
+}
+
 func (c *CostComponent) UnitMultiplierPrice() decimal.Decimal {
 	// Round the final number to 16 decimal places to avoid floating point issues.
 	return c.Price().Mul(c.UnitMultiplier)
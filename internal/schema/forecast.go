@@ -0,0 +1,9 @@
+package schema
+
+// ForecastMonth holds the resources of a project built with the usage of a
+// month of a cost forecast, with the growth rates from the usage file
+// applied. Month 0 is the current month.
+type ForecastMonth struct {
+	Month     int
+	Resources []*Resource
+}
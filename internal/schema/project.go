@@ -347,6 +347,17 @@ type Project struct {
 	Resources            []*Resource
 	Diff                 []*Resource
 	HasDiff              bool
+	// Recommendations are the cost saving recommendations for the resources
+	// of the project. They are only populated if recommendations are enabled.
+	Recommendations []*Recommendation
+	// UsageProfiles are the resources of the project built with the usage of
+	// each usage profile in the usage file, including the expected usage. They
+	// are only populated if the usage file defines usage profiles.
+	UsageProfiles []*UsageProfile
+	// Forecast are the resources of the project built with the usage of each
+	// month of the cost forecast. They are only populated if a forecast is
+	// requested.
+	Forecast []*ForecastMonth
 }
 
 func (p *Project) AddProviderMetadata(metadatas []ProviderMetadata) {
@@ -445,6 +456,35 @@ func (p *Project) BuildResources(usageMap UsageMap) {
 	p.Resources = resources
 }
 
+// RebuildResources builds the resources from the partial resources again with
+// usageMap instead of currentUsageMap, which the resources were built with.
+// Only the core resources whose usage differs are built again, the current
+// resources are reused for the rest since the other resources use the usage
+// when they are parsed. It returns the resources and the ones that were built
+// again, so only those need to be priced. The Resources field is not changed.
+func (p *Project) RebuildResources(currentUsageMap, usageMap, fetchedUsageMap UsageMap) ([]*Resource, []*Resource) {
+	current := make(map[string]*Resource, len(p.Resources))
+	for _, r := range p.Resources {
+		current[r.Name] = r
+	}
+
+	resources := make([]*Resource, 0, len(p.PartialResources))
+	var rebuilt []*Resource
+
+	for _, partial := range p.PartialResources {
+		r, ok := current[partial.Address]
+		if !ok || (partial.CoreResource != nil && !currentUsageMap.Get(partial.Address).Equal(usageMap.Get(partial.Address))) {
+			cp := *partial
+			cp.UsageData = usageMap.Get(partial.Address)
+			r = BuildResource(&cp, fetchedUsageMap.Get(partial.Address))
+			rebuilt = append(rebuilt, r)
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, rebuilt
+}
+
 // CalculateDiff calculates the diff of past and current resources
 func (p *Project) CalculateDiff() {
 	if p.HasDiff {
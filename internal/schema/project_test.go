@@ -9,6 +9,7 @@ import (
 	"github.com/go-git/go-billy/v5/osfs"
 	"github.com/go-git/go-billy/v5/util"
 	"github.com/go-git/go-git/v5"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -157,3 +158,61 @@ func tmpDir(t *testing.T, path string) (string, func()) {
 		assert.NoError(t, err)
 	}
 }
+
+type requestsCoreResource struct {
+	Address         string
+	MonthlyRequests *float64
+}
+
+func (r *requestsCoreResource) CoreType() string          { return "requests" }
+func (r *requestsCoreResource) UsageSchema() []*UsageItem { return nil }
+func (r *requestsCoreResource) PopulateUsage(u *UsageData) {
+	r.MonthlyRequests = u.GetFloat("monthly_requests")
+}
+func (r *requestsCoreResource) BuildResource() *Resource {
+	var q *decimal.Decimal
+	if r.MonthlyRequests != nil {
+		q = decimalPtr(decimal.NewFromFloat(*r.MonthlyRequests))
+	}
+
+	return &Resource{
+		Name:           r.Address,
+		CostComponents: []*CostComponent{{Name: "Requests", MonthlyQuantity: q}},
+	}
+}
+
+func TestProjectRebuildResources(t *testing.T) {
+	currentUsage := NewUsageMapFromInterface(map[string]interface{}{
+		"fn.changed":   map[string]interface{}{"monthly_requests": 100},
+		"fn.unchanged": map[string]interface{}{"monthly_requests": 100},
+		"fn.legacy":    map[string]interface{}{"monthly_requests": 100},
+	})
+	usage := NewUsageMapFromInterface(map[string]interface{}{
+		"fn.changed":   map[string]interface{}{"monthly_requests": 200},
+		"fn.unchanged": map[string]interface{}{"monthly_requests": 100},
+		"fn.legacy":    map[string]interface{}{"monthly_requests": 200},
+	})
+
+	project := &Project{
+		PartialResources: []*PartialResource{
+			{Address: "fn.changed", UsageData: currentUsage.Get("fn.changed"), CoreResource: &requestsCoreResource{Address: "fn.changed"}},
+			{Address: "fn.unchanged", UsageData: currentUsage.Get("fn.unchanged"), CoreResource: &requestsCoreResource{Address: "fn.unchanged"}},
+			{Address: "fn.legacy", UsageData: currentUsage.Get("fn.legacy"), Resource: &Resource{Name: "fn.legacy"}},
+		},
+	}
+	project.BuildResources(UsageMap{})
+	current := append([]*Resource{}, project.Resources...)
+
+	resources, rebuilt := project.RebuildResources(currentUsage, usage, UsageMap{})
+
+	require.Len(t, resources, 3)
+	require.Len(t, rebuilt, 1)
+	assert.Same(t, rebuilt[0], resources[0])
+	assert.Equal(t, "fn.changed", resources[0].Name)
+	assert.Equal(t, "200", resources[0].CostComponents[0].MonthlyQuantity.String())
+	assert.Same(t, current[1], resources[1])
+	assert.Same(t, current[2], resources[2])
+
+	assert.Equal(t, current, project.Resources)
+	assert.Equal(t, "100", project.Resources[0].CostComponents[0].MonthlyQuantity.String())
+}
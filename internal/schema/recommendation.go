@@ -0,0 +1,15 @@
+package schema
+
+import "github.com/shopspring/decimal"
+
+// Recommendation is a suggested change to a resource that follows cloud cost
+// best practices, e.g. using a current generation instance type.
+type Recommendation struct {
+	ResourceName string
+	ResourceType string
+	Title        string
+	Description  string
+	// MonthlySavings is the estimated monthly savings of the change. It is nil
+	// if the savings depend on usage that can't be estimated.
+	MonthlySavings *decimal.Decimal
+}
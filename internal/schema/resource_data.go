@@ -32,13 +32,13 @@ func NewResourceData(resourceType string, providerName string, address string, t
 	}
 }
 
-func NewCFResourceData(resourceType string, providerName string, address string, tags *map[string]string, cfResource cloudformation.Resource) *ResourceData {
+func NewCFResourceData(resourceType string, providerName string, address string, tags *map[string]string, rawValues gjson.Result, cfResource cloudformation.Resource) *ResourceData {
 	return &ResourceData{
 		Type:          resourceType,
 		ProviderName:  providerName,
 		Address:       address,
 		Tags:          tags,
-		RawValues:     gjson.Result{},
+		RawValues:     rawValues,
 		ReferencesMap: make(map[string][]*ResourceData),
 		CFResource:    cfResource,
 	}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"reflect"
 	"regexp"
 	"sort"
 	"strings"
@@ -76,6 +77,27 @@ func (u *UsageData) Merge(other *UsageData) *UsageData {
 	return newU
 }
 
+// Equal returns true if the usage data has the same attributes with the same
+// values as other. Nil usage data is only equal to nil usage data.
+func (u *UsageData) Equal(other *UsageData) bool {
+	if u == nil || other == nil {
+		return u == other
+	}
+
+	if len(u.Attributes) != len(other.Attributes) {
+		return false
+	}
+
+	for k, v := range u.Attributes {
+		o, ok := other.Attributes[k]
+		if !ok || !reflect.DeepEqual(v.Value(), o.Value()) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (u *UsageData) Get(key string) gjson.Result {
 	if u.Attributes[key].Type != gjson.Null {
 		return u.Attributes[key]
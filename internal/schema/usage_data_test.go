@@ -213,3 +213,27 @@ func TestUsageMap_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestUsageData_Equal(t *testing.T) {
+	a := NewUsageData("aws_lambda_function.fn", ParseAttributes(map[string]interface{}{
+		"monthly_requests":    100,
+		"request_duration_ms": 250.0,
+	}))
+	b := NewUsageData("aws_lambda_function.fn", ParseAttributes(map[string]interface{}{
+		"request_duration_ms": 250,
+		"monthly_requests":    100.0,
+	}))
+	c := NewUsageData("aws_lambda_function.fn", ParseAttributes(map[string]interface{}{
+		"monthly_requests":    110,
+		"request_duration_ms": 250,
+	}))
+	d := NewUsageData("aws_lambda_function.fn", ParseAttributes(map[string]interface{}{
+		"monthly_requests": 100,
+	}))
+
+	assert.True(t, a.Equal(b))
+	assert.False(t, a.Equal(c))
+	assert.False(t, a.Equal(d))
+	assert.False(t, a.Equal(nil))
+	assert.True(t, (*UsageData)(nil).Equal(nil))
+}
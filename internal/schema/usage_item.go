@@ -17,4 +17,15 @@ type UsageItem struct {
 	Value        interface{}
 	ValueType    UsageVariableType
 	Description  string
+	// Expression is set if the value was evaluated from a formula in the
+	// usage file, so the formula can be written back instead of the value.
+	Expression *UsageExpression
+}
+
+// UsageExpression is a usage value that is written as a formula in the usage
+// file, e.g. ${aws_lambda_function.api.monthly_requests} * 1.2.
+type UsageExpression struct {
+	Value string
+	// Line is the line of the formula in the usage file.
+	Line int
 }
@@ -0,0 +1,13 @@
+package schema
+
+// ExpectedUsageProfile is the name of the usage profile that is used for the
+// cost estimate. If the usage file doesn't define it, the expected usage is
+// the usage outside of the usage profiles.
+const ExpectedUsageProfile = "expected"
+
+// UsageProfile holds the resources of a project built with the usage of a
+// usage profile from the usage file, e.g. low or peak usage.
+type UsageProfile struct {
+	Name      string
+	Resources []*Resource
+}
@@ -0,0 +1,42 @@
+//nolint:deadcode,unused
+package aws
+
+import (
+	"context"
+)
+
+// APIGatewayGetRequests returns the number of requests to a REST API in the
+// last month.
+func APIGatewayGetRequests(ctx context.Context, region string, apiName string) (float64, error) {
+	return cloudwatchGetMonthlyStat(ctx, statsRequest{
+		region:     region,
+		namespace:  "AWS/ApiGateway",
+		metric:     "Count",
+		dimensions: map[string]string{"ApiName": apiName},
+		statistic:  statSum,
+		unit:       unitCount,
+	})
+}
+
+// APIGatewayV2GetRequests returns the number of requests to an HTTP API in
+// the last month.
+func APIGatewayV2GetRequests(ctx context.Context, region string, apiID string) (float64, error) {
+	return apigatewayv2GetCount(ctx, region, apiID, "Count")
+}
+
+// APIGatewayV2GetMessages returns the number of messages sent to and from a
+// WebSocket API in the last month.
+func APIGatewayV2GetMessages(ctx context.Context, region string, apiID string) (float64, error) {
+	return apigatewayv2GetCount(ctx, region, apiID, "MessageCount")
+}
+
+func apigatewayv2GetCount(ctx context.Context, region string, apiID string, metric string) (float64, error) {
+	return cloudwatchGetMonthlyStat(ctx, statsRequest{
+		region:     region,
+		namespace:  "AWS/ApiGateway",
+		metric:     metric,
+		dimensions: map[string]string{"ApiId": apiID},
+		statistic:  statSum,
+		unit:       unitCount,
+	})
+}
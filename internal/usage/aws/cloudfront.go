@@ -0,0 +1,44 @@
+//nolint:deadcode,unused
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// CloudFront metrics are global and only published to us-east-1.
+const cloudfrontMetricsRegion = "us-east-1"
+
+func cloudfrontGetMonthlySum(ctx context.Context, distributionID string, metric string) (float64, error) {
+	return cloudwatchGetMonthlyStat(ctx, statsRequest{
+		region:    cloudfrontMetricsRegion,
+		namespace: "AWS/CloudFront",
+		metric:    metric,
+		dimensions: map[string]string{
+			"DistributionId": distributionID,
+			"Region":         "Global",
+		},
+		statistic: statSum,
+		// CloudFront metrics are published without a unit
+		unit: types.StandardUnitNone,
+	})
+}
+
+// CloudFrontGetRequests returns the number of viewer requests to the
+// distribution in the last month.
+func CloudFrontGetRequests(ctx context.Context, distributionID string) (float64, error) {
+	return cloudfrontGetMonthlySum(ctx, distributionID, "Requests")
+}
+
+// CloudFrontGetBytesDownloaded returns the number of bytes downloaded by
+// viewers from the distribution in the last month.
+func CloudFrontGetBytesDownloaded(ctx context.Context, distributionID string) (float64, error) {
+	return cloudfrontGetMonthlySum(ctx, distributionID, "BytesDownloaded")
+}
+
+// CloudFrontGetBytesUploaded returns the number of bytes uploaded by viewers
+// to the origin of the distribution in the last month.
+func CloudFrontGetBytesUploaded(ctx context.Context, distributionID string) (float64, error) {
+	return cloudfrontGetMonthlySum(ctx, distributionID, "BytesUploaded")
+}
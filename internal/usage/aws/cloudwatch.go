@@ -3,17 +3,22 @@ package aws
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/rs/zerolog/log"
 )
 
 const statAvg = types.StatisticAverage
 const statSum = types.StatisticSum
 
 const unitCount = types.StandardUnitCount
+const unitBytes = types.StandardUnitBytes
 
 func cloudwatchNewClient(ctx context.Context, region string) (*cloudwatch.Client, error) {
 	cfg, err := getConfig(ctx, region)
@@ -57,3 +62,29 @@ func cloudwatchGetMonthlyStats(ctx context.Context, req statsRequest) (*cloudwat
 		Dimensions: dim,
 	})
 }
+
+// cloudwatchGetMonthlyStat returns the statistic of the metric over the last
+// month, or 0 if there are no datapoints for the metric.
+func cloudwatchGetMonthlyStat(ctx context.Context, req statsRequest) (float64, error) {
+	dims := make([]string, 0, len(req.dimensions))
+	for k, v := range req.dimensions {
+		dims = append(dims, fmt.Sprintf("%s: %s", k, v))
+	}
+	sort.Strings(dims)
+
+	log.Debug().Msgf("Querying AWS CloudWatch: %s %s (region: %s, %s)", req.namespace, req.metric, req.region, strings.Join(dims, ", "))
+	stats, err := cloudwatchGetMonthlyStats(ctx, req)
+	if err != nil {
+		return 0, err
+	} else if len(stats.Datapoints) == 0 {
+		return 0, nil
+	}
+
+	datapoint := stats.Datapoints[0]
+	if req.statistic == statAvg && datapoint.Average != nil {
+		return *datapoint.Average, nil
+	} else if req.statistic == statSum && datapoint.Sum != nil {
+		return *datapoint.Sum, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,46 @@
+//nolint:deadcode,unused
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/rs/zerolog/log"
+)
+
+func ecrNewClient(ctx context.Context, region string) (*ecr.Client, error) {
+	cfg, err := getConfig(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	return ecr.NewFromConfig(cfg), nil
+}
+
+// ECRGetStorageBytes returns the total size of the images in the repository.
+func ECRGetStorageBytes(ctx context.Context, region string, repository string) (int64, error) {
+	client, err := ecrNewClient(ctx, region)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Debug().Msgf("Querying AWS ECR API: DescribeImages(region: %s, repositoryName: %s)", region, repository)
+
+	var size int64
+	paginator := ecr.NewDescribeImagesPaginator(client, &ecr.DescribeImagesInput{
+		RepositoryName: strPtr(repository),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, image := range page.ImageDetails {
+			if image.ImageSizeInBytes != nil {
+				size += *image.ImageSizeInBytes
+			}
+		}
+	}
+
+	return size, nil
+}
@@ -0,0 +1,71 @@
+//nolint:deadcode,unused
+package aws
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// elbv2Namespaces are the CloudWatch namespaces of the metrics of each type of
+// load balancer.
+var elbv2Namespaces = map[string]string{
+	"application": "AWS/ApplicationELB",
+	"network":     "AWS/NetworkELB",
+	"gateway":     "AWS/GatewayELB",
+}
+
+func elbv2GetMonthlyStat(ctx context.Context, region string, lbType string, arnSuffix string, metric string, statistic types.Statistic, unit types.StandardUnit) (float64, error) {
+	namespace, ok := elbv2Namespaces[strings.ToLower(lbType)]
+	if !ok {
+		return 0, nil
+	}
+
+	return cloudwatchGetMonthlyStat(ctx, statsRequest{
+		region:     region,
+		namespace:  namespace,
+		metric:     metric,
+		dimensions: map[string]string{"LoadBalancer": arnSuffix},
+		statistic:  statistic,
+		unit:       unit,
+	})
+}
+
+// ELBv2GetNewConnections returns the number of new connections, or flows for
+// network and gateway load balancers, in the last month.
+func ELBv2GetNewConnections(ctx context.Context, region string, lbType string, arnSuffix string) (float64, error) {
+	metric := "NewFlowCount"
+	if strings.ToLower(lbType) == "application" {
+		metric = "NewConnectionCount"
+	}
+	return elbv2GetMonthlyStat(ctx, region, lbType, arnSuffix, metric, statSum, unitCount)
+}
+
+// ELBv2GetActiveConnectionsPerMinute returns the average number of active
+// connections, or flows for network and gateway load balancers, per minute
+// over the last month.
+func ELBv2GetActiveConnectionsPerMinute(ctx context.Context, region string, lbType string, arnSuffix string) (float64, error) {
+	if strings.ToLower(lbType) == "application" {
+		// ActiveConnectionCount is the number of connections active in each
+		// minute, so its monthly sum is divided by the minutes in the month.
+		count, err := elbv2GetMonthlyStat(ctx, region, lbType, arnSuffix, "ActiveConnectionCount", statSum, unitCount)
+		return count / timeMonth.Minutes(), err
+	}
+	return elbv2GetMonthlyStat(ctx, region, lbType, arnSuffix, "ActiveFlowCount", statAvg, unitCount)
+}
+
+// ELBv2GetProcessedBytes returns the number of bytes processed by the load
+// balancer in the last month.
+func ELBv2GetProcessedBytes(ctx context.Context, region string, lbType string, arnSuffix string) (float64, error) {
+	return elbv2GetMonthlyStat(ctx, region, lbType, arnSuffix, "ProcessedBytes", statSum, unitBytes)
+}
+
+// ELBv2GetRuleEvaluations returns the number of rules processed by an
+// application load balancer in the last month.
+func ELBv2GetRuleEvaluations(ctx context.Context, region string, lbType string, arnSuffix string) (float64, error) {
+	if strings.ToLower(lbType) != "application" {
+		return 0, nil
+	}
+	return elbv2GetMonthlyStat(ctx, region, lbType, arnSuffix, "RuleEvaluations", statSum, unitCount)
+}
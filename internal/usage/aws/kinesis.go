@@ -0,0 +1,37 @@
+//nolint:deadcode,unused
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+func kinesisGetMonthlySum(ctx context.Context, region string, stream string, metric string, unit types.StandardUnit) (float64, error) {
+	return cloudwatchGetMonthlyStat(ctx, statsRequest{
+		region:     region,
+		namespace:  "AWS/Kinesis",
+		metric:     metric,
+		dimensions: map[string]string{"StreamName": stream},
+		statistic:  statSum,
+		unit:       unit,
+	})
+}
+
+// KinesisGetIncomingBytes returns the number of bytes put into the stream in
+// the last month.
+func KinesisGetIncomingBytes(ctx context.Context, region string, stream string) (float64, error) {
+	return kinesisGetMonthlySum(ctx, region, stream, "IncomingBytes", unitBytes)
+}
+
+// KinesisGetIncomingRecords returns the number of records put into the stream
+// in the last month.
+func KinesisGetIncomingRecords(ctx context.Context, region string, stream string) (float64, error) {
+	return kinesisGetMonthlySum(ctx, region, stream, "IncomingRecords", unitCount)
+}
+
+// KinesisGetOutgoingBytes returns the number of bytes read from the stream
+// with GetRecords in the last month.
+func KinesisGetOutgoingBytes(ctx context.Context, region string, stream string) (float64, error) {
+	return kinesisGetMonthlySum(ctx, region, stream, "GetRecords.Bytes", unitBytes)
+}
@@ -0,0 +1,19 @@
+//nolint:deadcode,unused
+package aws
+
+import (
+	"context"
+)
+
+// LogsGetIncomingBytes returns the number of bytes ingested by the log group
+// in the last month.
+func LogsGetIncomingBytes(ctx context.Context, region string, logGroup string) (float64, error) {
+	return cloudwatchGetMonthlyStat(ctx, statsRequest{
+		region:     region,
+		namespace:  "AWS/Logs",
+		metric:     "IncomingBytes",
+		dimensions: map[string]string{"LogGroupName": logGroup},
+		statistic:  statSum,
+		unit:       unitBytes,
+	})
+}
@@ -0,0 +1,27 @@
+//nolint:deadcode,unused
+package aws
+
+import (
+	"context"
+)
+
+// NATGatewayGetProcessedBytes returns the number of bytes processed by the
+// NAT gateway in the last month, in both directions.
+func NATGatewayGetProcessedBytes(ctx context.Context, region string, natGatewayID string) (float64, error) {
+	var processed float64
+	for _, metric := range []string{"BytesInFromSource", "BytesInFromDestination"} {
+		bytes, err := cloudwatchGetMonthlyStat(ctx, statsRequest{
+			region:     region,
+			namespace:  "AWS/NATGateway",
+			metric:     metric,
+			dimensions: map[string]string{"NatGatewayId": natGatewayID},
+			statistic:  statSum,
+			unit:       unitBytes,
+		})
+		if err != nil {
+			return 0, err
+		}
+		processed += bytes
+	}
+	return processed, nil
+}
@@ -0,0 +1,32 @@
+//nolint:deadcode,unused
+package aws
+
+import (
+	"context"
+)
+
+// SNSGetPublishedMessages returns the number of messages published to the
+// topic in the last month.
+func SNSGetPublishedMessages(ctx context.Context, region string, topic string) (float64, error) {
+	return cloudwatchGetMonthlyStat(ctx, statsRequest{
+		region:     region,
+		namespace:  "AWS/SNS",
+		metric:     "NumberOfMessagesPublished",
+		dimensions: map[string]string{"TopicName": topic},
+		statistic:  statSum,
+		unit:       unitCount,
+	})
+}
+
+// SNSGetPublishSizeBytes returns the average size of the messages published
+// to the topic in the last month.
+func SNSGetPublishSizeBytes(ctx context.Context, region string, topic string) (float64, error) {
+	return cloudwatchGetMonthlyStat(ctx, statsRequest{
+		region:     region,
+		namespace:  "AWS/SNS",
+		metric:     "PublishSize",
+		dimensions: map[string]string{"TopicName": topic},
+		statistic:  statAvg,
+		unit:       unitBytes,
+	})
+}
@@ -0,0 +1,49 @@
+//nolint:deadcode,unused
+package aws
+
+import (
+	"context"
+)
+
+// sqsRequestMetrics are the metrics of the billable requests to a queue.
+// Every receive is billed, including the ones that return no messages.
+var sqsRequestMetrics = []string{
+	"NumberOfMessagesSent",
+	"NumberOfMessagesReceived",
+	"NumberOfMessagesDeleted",
+	"NumberOfEmptyReceives",
+}
+
+// SQSGetRequests returns the number of requests to the queue in the last
+// month.
+func SQSGetRequests(ctx context.Context, region string, queue string) (float64, error) {
+	var requests float64
+	for _, metric := range sqsRequestMetrics {
+		count, err := cloudwatchGetMonthlyStat(ctx, statsRequest{
+			region:     region,
+			namespace:  "AWS/SQS",
+			metric:     metric,
+			dimensions: map[string]string{"QueueName": queue},
+			statistic:  statSum,
+			unit:       unitCount,
+		})
+		if err != nil {
+			return 0, err
+		}
+		requests += count
+	}
+	return requests, nil
+}
+
+// SQSGetMessageSizeBytes returns the average size of the messages sent to the
+// queue in the last month.
+func SQSGetMessageSizeBytes(ctx context.Context, region string, queue string) (float64, error) {
+	return cloudwatchGetMonthlyStat(ctx, statsRequest{
+		region:     region,
+		namespace:  "AWS/SQS",
+		metric:     "SentMessageSize",
+		dimensions: map[string]string{"QueueName": queue},
+		statistic:  statAvg,
+		unit:       unitBytes,
+	})
+}
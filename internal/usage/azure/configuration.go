@@ -0,0 +1,96 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+
+	"github.com/infracost/infracost/internal/usage"
+)
+
+type ctxConfigKeyType struct{}
+
+var ctxConfigKey = &ctxConfigKeyType{}
+
+var configMux sync.Mutex
+
+// config is the credential and the client options, including the Azure
+// cloud, used to call the Azure APIs.
+type config struct {
+	credential    azcore.TokenCredential
+	clientOptions azcore.ClientOptions
+}
+
+// getConfig returns the config for the Azure cloud and credentials from the
+// environment variables, which can be set in the Infracost config file. The
+// default Azure credential chain is used, so the Azure CLI credentials are
+// used if no service principal or managed identity is set.
+func getConfig(ctx context.Context) (config, error) {
+	if cfg, ok := ctx.Value(ctxConfigKey).(config); ok {
+		return cfg, nil
+	}
+
+	// We want to set the OS env so that the Azure credentials pick up any
+	// AZURE_* env vars set in the Infracost config file. We use a mutex for this
+	// since it's run in parallel and os.Setenv sets the global OS env for the
+	// process.
+	configMux.Lock()
+	defer configMux.Unlock()
+
+	var oldEnv []string
+
+	env, hasEnv := ctx.Value(usage.ContextEnv{}).(map[string]string)
+	if hasEnv {
+		oldEnv = os.Environ()
+		for k, v := range env {
+			os.Setenv(k, v)
+		}
+		defer resetEnv(oldEnv)
+	}
+
+	cloudCfg, err := cloudFromName(os.Getenv("AZURE_ENVIRONMENT"))
+	if err != nil {
+		return config{}, err
+	}
+	clientOptions := azcore.ClientOptions{Cloud: cloudCfg}
+
+	credential, err := azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{
+		ClientOptions: clientOptions,
+	})
+	if err != nil {
+		return config{}, err
+	}
+
+	return config{credential: credential, clientOptions: clientOptions}, nil
+}
+
+// cloudFromName returns the Azure cloud with the environment name used by the
+// Azure CLI and SDKs, e.g. AzureUSGovernmentCloud. The public cloud is used if
+// the name is empty.
+func cloudFromName(name string) (cloud.Configuration, error) {
+	switch strings.ToUpper(name) {
+	case "", "AZUREPUBLICCLOUD", "AZURECLOUD":
+		return cloud.AzurePublic, nil
+	case "AZURECHINACLOUD":
+		return cloud.AzureChina, nil
+	case "AZUREUSGOVERNMENTCLOUD", "AZUREUSGOVERNMENT":
+		return cloud.AzureGovernment, nil
+	}
+	return cloud.Configuration{}, fmt.Errorf("unknown Azure environment %s", name)
+}
+
+func resetEnv(items []string) {
+	os.Clearenv()
+	for _, item := range items {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) == 2 {
+			os.Setenv(parts[0], parts[1])
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package azure
+
+import (
+	"context"
+)
+
+// CosmosDBGetRequestUnits returns the request units consumed by the database
+// or collection of the Cosmos DB account in the last month. The collection
+// can be empty to include all the collections of the database, and both can
+// be empty to include the whole account.
+func CosmosDBGetRequestUnits(ctx context.Context, accountID, database, collection string) (float64, error) {
+	return monitorGetMonthlyTotal(ctx, metricsRequest{
+		resourceID:  accountID,
+		metric:      "TotalRequestUnits",
+		aggregation: aggregationTotal,
+		filter:      dimensionFilter([2]string{"DatabaseName", database}, [2]string{"CollectionName", collection}),
+	})
+}
+
+// CosmosDBGetMaxRUUtilization returns the average of the hourly maximum
+// utilization of the provisioned request units of the database or collection
+// in the last month, as a percentage.
+func CosmosDBGetMaxRUUtilization(ctx context.Context, accountID, database, collection string) (float64, error) {
+	return monitorGetMonthlyAverage(ctx, metricsRequest{
+		resourceID:  accountID,
+		metric:      "NormalizedRUConsumption",
+		aggregation: aggregationMaximum,
+		interval:    "PT1H",
+		filter:      dimensionFilter([2]string{"DatabaseName", database}, [2]string{"CollectionName", collection}),
+	})
+}
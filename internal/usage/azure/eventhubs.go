@@ -0,0 +1,15 @@
+package azure
+
+import (
+	"context"
+)
+
+// EventHubsGetIngressEvents returns the number of events sent to the event
+// hubs of the namespace in the last month.
+func EventHubsGetIngressEvents(ctx context.Context, namespaceID string) (float64, error) {
+	return monitorGetMonthlyTotal(ctx, metricsRequest{
+		resourceID:  namespaceID,
+		metric:      "IncomingMessages",
+		aggregation: aggregationTotal,
+	})
+}
@@ -0,0 +1,25 @@
+package azure
+
+import (
+	"context"
+)
+
+// FunctionsGetExecutions returns the number of executions of the functions of
+// the function app in the last month.
+func FunctionsGetExecutions(ctx context.Context, appID string) (float64, error) {
+	return monitorGetMonthlyTotal(ctx, metricsRequest{
+		resourceID:  appID,
+		metric:      "FunctionExecutionCount",
+		aggregation: aggregationTotal,
+	})
+}
+
+// FunctionsGetExecutionUnits returns the execution units of the functions of
+// the function app in the last month, in MB-milliseconds.
+func FunctionsGetExecutionUnits(ctx context.Context, appID string) (float64, error) {
+	return monitorGetMonthlyTotal(ctx, metricsRequest{
+		resourceID:  appID,
+		metric:      "FunctionExecutionUnits",
+		aggregation: aggregationTotal,
+	})
+}
@@ -0,0 +1,177 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/monitor/azquery"
+	"github.com/rs/zerolog/log"
+)
+
+const timeMonth = time.Hour * 24 * 30
+
+const (
+	aggregationTotal   = azquery.AggregationTypeTotal
+	aggregationAverage = azquery.AggregationTypeAverage
+	aggregationMaximum = azquery.AggregationTypeMaximum
+)
+
+func monitorNewClient(ctx context.Context) (*azquery.MetricsClient, error) {
+	cfg, err := getConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return azquery.NewMetricsClient(cfg.credential, &azquery.MetricsClientOptions{ClientOptions: cfg.clientOptions})
+}
+
+type metricsRequest struct {
+	resourceID  string
+	metric      string
+	aggregation azquery.AggregationType
+	// interval is the ISO 8601 duration of each data point, e.g. P1D.
+	interval string
+	// filter selects or splits the time series by the dimensions of the
+	// metric, e.g. ApiName eq '*'.
+	filter string
+}
+
+// monitorGetMonthlyTimeseries returns the time series of the metric over the
+// last month.
+func monitorGetMonthlyTimeseries(ctx context.Context, req metricsRequest) ([]*azquery.TimeSeriesElement, error) {
+	client, err := monitorNewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug().Msgf("Querying Azure Monitor: %s %s (resource: %s, filter: %s)", req.metric, req.aggregation, req.resourceID, req.filter)
+
+	end := time.Now().UTC()
+	opts := &azquery.MetricsClientQueryResourceOptions{
+		Timespan:    to.Ptr(azquery.NewTimeInterval(end.Add(-timeMonth), end)),
+		Interval:    to.Ptr("P1D"),
+		MetricNames: to.Ptr(req.metric),
+		Aggregation: []*azquery.AggregationType{to.Ptr(req.aggregation)},
+		ResultType:  to.Ptr(azquery.ResultTypeData),
+	}
+	if req.interval != "" {
+		opts.Interval = to.Ptr(req.interval)
+	}
+	if req.filter != "" {
+		opts.Filter = to.Ptr(req.filter)
+	}
+
+	resp, err := client.QueryResource(ctx, strings.TrimPrefix(req.resourceID, "/"), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var timeseries []*azquery.TimeSeriesElement
+	for _, m := range resp.Value {
+		if m.ErrorCode != nil && *m.ErrorCode != "Success" {
+			msg := *m.ErrorCode
+			if m.ErrorMessage != nil {
+				msg = *m.ErrorMessage
+			}
+			return nil, fmt.Errorf("error querying Azure Monitor metric %s: %s", req.metric, msg)
+		}
+
+		timeseries = append(timeseries, m.TimeSeries...)
+	}
+
+	return timeseries, nil
+}
+
+// monitorGetMonthlyTotal returns the sum of the data points of the metric
+// over the last month.
+func monitorGetMonthlyTotal(ctx context.Context, req metricsRequest) (float64, error) {
+	totals, err := monitorGetMonthlyTotalsByDimension(ctx, req, "")
+	return totals[""], err
+}
+
+// monitorGetMonthlyTotalsByDimension returns the sum of the data points of
+// the metric over the last month for each value of the dimension. The
+// dimension must also be split by the filter of the request.
+func monitorGetMonthlyTotalsByDimension(ctx context.Context, req metricsRequest, dimension string) (map[string]float64, error) {
+	timeseries, err := monitorGetMonthlyTimeseries(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]float64)
+	for _, ts := range timeseries {
+		key := ""
+		if dimension != "" {
+			key = metadataValue(ts, dimension)
+		}
+
+		for _, v := range ts.Data {
+			if p := aggregationValue(v, req.aggregation); p != nil {
+				totals[key] += *p
+			}
+		}
+	}
+
+	return totals, nil
+}
+
+// monitorGetMonthlyAverage returns the average of the data points of the
+// metric over the last month.
+func monitorGetMonthlyAverage(ctx context.Context, req metricsRequest) (float64, error) {
+	timeseries, err := monitorGetMonthlyTimeseries(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	sum := 0.0
+	count := 0
+	for _, ts := range timeseries {
+		for _, v := range ts.Data {
+			if p := aggregationValue(v, req.aggregation); p != nil {
+				sum += *p
+				count++
+			}
+		}
+	}
+
+	if count == 0 {
+		return 0, nil
+	}
+	return sum / float64(count), nil
+}
+
+func metadataValue(ts *azquery.TimeSeriesElement, name string) string {
+	for _, m := range ts.MetadataValues {
+		if m.Name != nil && m.Name.Value != nil && strings.EqualFold(*m.Name.Value, name) && m.Value != nil {
+			return *m.Value
+		}
+	}
+	return ""
+}
+
+func aggregationValue(v *azquery.MetricValue, aggregation azquery.AggregationType) *float64 {
+	switch aggregation {
+	case aggregationTotal:
+		return v.Total
+	case aggregationAverage:
+		return v.Average
+	case aggregationMaximum:
+		return v.Maximum
+	}
+	return nil
+}
+
+// dimensionFilter returns the filter that selects the time series with the
+// values of the dimensions, skipping the dimensions without a value.
+func dimensionFilter(dimensions ...[2]string) string {
+	var parts []string
+	for _, d := range dimensions {
+		if d[1] != "" {
+			parts = append(parts, fmt.Sprintf("%s eq '%s'", d[0], strings.ReplaceAll(d[1], "'", "''")))
+		}
+	}
+	return strings.Join(parts, " and ")
+}
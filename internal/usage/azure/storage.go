@@ -0,0 +1,100 @@
+package azure
+
+import (
+	"context"
+)
+
+// storageWriteAPIs are the blob APIs billed as write operations.
+var storageWriteAPIs = map[string]bool{
+	"PutBlob":                  true,
+	"PutBlock":                 true,
+	"PutBlockList":             true,
+	"PutBlockFromURL":          true,
+	"PutBlobFromURL":           true,
+	"PutPage":                  true,
+	"PutPageFromURL":           true,
+	"AppendBlock":              true,
+	"AppendBlockFromURL":       true,
+	"CopyBlob":                 true,
+	"CopyBlobFromURL":          true,
+	"IncrementalCopyBlob":      true,
+	"SnapshotBlob":             true,
+	"SetBlobTier":              true,
+	"SetBlobMetadata":          true,
+	"SetBlobProperties":        true,
+	"SetBlobTags":              true,
+	"SetContainerMetadata":     true,
+	"SetContainerACL":          true,
+	"SetBlobServiceProperties": true,
+}
+
+// storageListAndCreateContainerAPIs are the blob APIs billed as list and
+// create container operations.
+var storageListAndCreateContainerAPIs = map[string]bool{
+	"ListBlobs":       true,
+	"ListContainers":  true,
+	"CreateContainer": true,
+}
+
+// storageReadAPIs are the blob APIs billed as read operations.
+var storageReadAPIs = map[string]bool{
+	"GetBlob": true,
+}
+
+// storageFreeAPIs are the blob APIs that aren't billed.
+var storageFreeAPIs = map[string]bool{
+	"DeleteBlob":      true,
+	"DeleteContainer": true,
+}
+
+// StorageOperations are the monthly blob operations of a storage account
+// grouped by how they are billed.
+type StorageOperations struct {
+	Write                  float64
+	ListAndCreateContainer float64
+	Read                   float64
+	Other                  float64
+}
+
+// StorageGetUsedCapacityBytes returns the average capacity used by the
+// storage account in the last month.
+func StorageGetUsedCapacityBytes(ctx context.Context, accountID string) (float64, error) {
+	return monitorGetMonthlyAverage(ctx, metricsRequest{
+		resourceID:  accountID,
+		metric:      "UsedCapacity",
+		aggregation: aggregationAverage,
+		interval:    "PT1H",
+	})
+}
+
+// StorageGetBlobOperations returns the blob operations of the storage account
+// in the last month.
+func StorageGetBlobOperations(ctx context.Context, accountID string) (StorageOperations, error) {
+	var ops StorageOperations
+
+	totals, err := monitorGetMonthlyTotalsByDimension(ctx, metricsRequest{
+		resourceID:  accountID + "/blobServices/default",
+		metric:      "Transactions",
+		aggregation: aggregationTotal,
+		filter:      "ApiName eq '*'",
+	}, "ApiName")
+	if err != nil {
+		return ops, err
+	}
+
+	for api, count := range totals {
+		switch {
+		case storageWriteAPIs[api]:
+			ops.Write += count
+		case storageListAndCreateContainerAPIs[api]:
+			ops.ListAndCreateContainer += count
+		case storageReadAPIs[api]:
+			ops.Read += count
+		case storageFreeAPIs[api]:
+		default:
+			ops.Other += count
+		}
+	}
+
+	return ops, nil
+}
@@ -0,0 +1,32 @@
+package azure
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/monitor/azquery"
+)
+
+type testCredential struct{}
+
+func (testCredential) GetToken(ctx context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "test", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+// WithTestEndpoint returns a context that sends the Azure API requests to
+// the URL with a fake token, for testing.
+func WithTestEndpoint(ctx context.Context, url string) context.Context {
+	return context.WithValue(ctx, ctxConfigKey, config{
+		credential: testCredential{},
+		clientOptions: azcore.ClientOptions{
+			Cloud: cloud.Configuration{
+				Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+					azquery.ServiceNameMetrics: {Audience: url, Endpoint: url},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,586 @@
+package usage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// Usage values can be written as formulas in the usage file, e.g.
+//
+//	variables:
+//	  peak_factor: 1.2
+//	resource_usage:
+//	  aws_lambda_function.api:
+//	    monthly_requests: ${aws_api_gateway_rest_api.main.monthly_requests} * ${var.peak_factor}
+//	  aws_api_gateway_rest_api.main:
+//	    monthly_requests: 30M
+//
+// Formulas support +, -, *, / and parentheses, numbers with a unit suffix and
+// references to other usage values. References use the resource address or
+// resource type followed by the usage key, with nested keys separated by dots,
+// and variables are referenced with var.<name>. A reference to a resource
+// falls back to the wildcard and resource type default usage, the same as
+// the usage of the resource does.
+
+// expressionUnits are the unit suffixes supported by numbers in formulas.
+// Count units are in thousands, millions and billions, and data units are
+// converted to GB using decimal units, e.g. 2TB is 2000 GB. Percentages are
+// converted to fractions, e.g. 5% is 0.05.
+var expressionUnits = map[string]decimal.Decimal{
+	"%":  decimal.New(1, -2),
+	"k":  decimal.New(1, 3),
+	"m":  decimal.New(1, 6),
+	"b":  decimal.New(1, 9),
+	"kb": decimal.New(1, -6),
+	"mb": decimal.New(1, -3),
+	"gb": decimal.New(1, 0),
+	"tb": decimal.New(1, 3),
+	"pb": decimal.New(1, 6),
+}
+
+// ExpressionError is an error evaluating a formula in the usage file.
+type ExpressionError struct {
+	// Path is the path of the usage file, if it was loaded from a file.
+	Path string
+	Line int
+	// Name is the name of the usage value, e.g. aws_lambda_function.api.monthly_requests.
+	Name string
+	Err  error
+}
+
+func (e *ExpressionError) Error() string {
+	if e.Path != "" {
+		return fmt.Sprintf("%s:%d: invalid value for %s: %v", e.Path, e.Line, e.Name, e.Err)
+	}
+
+	return fmt.Sprintf("line %d: invalid value for %s: %v", e.Line, e.Name, e.Err)
+}
+
+type exprNode interface{}
+
+type numberExpr struct {
+	value decimal.Decimal
+	unit  string
+}
+
+type refExpr struct {
+	path string
+}
+
+type unaryExpr struct {
+	op byte
+	x  exprNode
+}
+
+type binaryExpr struct {
+	op   byte
+	x, y exprNode
+}
+
+// isExpression returns true if the string value from the usage file should be
+// evaluated as a formula. Strings that are not valid formulas are only treated
+// as formulas if they contain a reference, so other string values, e.g.
+// storage classes, are not affected.
+func isExpression(s string) bool {
+	if strings.Contains(s, "${") {
+		return true
+	}
+
+	node, err := parseExpression(s)
+	if err != nil {
+		return false
+	}
+
+	// Quoted numbers without a unit are kept as strings.
+	n, ok := node.(*numberExpr)
+	return !ok || n.unit != ""
+}
+
+type exprParser struct {
+	s   string
+	pos int
+}
+
+func parseExpression(s string) (exprNode, error) {
+	p := &exprParser{s: s}
+
+	node, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.s[p.pos:], p.pos+1)
+	}
+
+	return node, nil
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *exprParser) peek() byte {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return 0
+	}
+
+	return p.s[p.pos]
+}
+
+func (p *exprParser) parseSum() (exprNode, error) {
+	x, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return x, nil
+		}
+		p.pos++
+
+		y, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+
+		x = &binaryExpr{op: op, x: x, y: y}
+	}
+}
+
+func (p *exprParser) parseProduct() (exprNode, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := p.peek()
+		if op != '*' && op != '/' {
+			return x, nil
+		}
+		p.pos++
+
+		y, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		x = &binaryExpr{op: op, x: x, y: y}
+	}
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	op := p.peek()
+	if op == '+' || op == '-' {
+		p.pos++
+
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &unaryExpr{op: op, x: x}, nil
+	}
+
+	return p.parseOperand()
+}
+
+func (p *exprParser) parseOperand() (exprNode, error) {
+	c := p.peek()
+
+	switch {
+	case c == 0:
+		return nil, errors.New("unexpected end of formula")
+	case c == '(':
+		p.pos++
+
+		x, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing ) at position %d", p.pos+1)
+		}
+		p.pos++
+
+		return x, nil
+	case c == '$':
+		return p.parseReference()
+	case isDigit(c) || c == '.':
+		return p.parseNumber()
+	}
+
+	return nil, fmt.Errorf("unexpected %q at position %d", p.s[p.pos:], p.pos+1)
+}
+
+func (p *exprParser) parseReference() (exprNode, error) {
+	start := p.pos
+	if !strings.HasPrefix(p.s[p.pos:], "${") {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.s[p.pos:], p.pos+1)
+	}
+
+	end := strings.Index(p.s[p.pos:], "}")
+	if end == -1 {
+		return nil, fmt.Errorf("missing } for reference at position %d", start+1)
+	}
+
+	path := strings.TrimSpace(p.s[p.pos+2 : p.pos+end])
+	if path == "" {
+		return nil, fmt.Errorf("empty reference at position %d", start+1)
+	}
+	p.pos += end + 1
+
+	return &refExpr{path: path}, nil
+}
+
+func (p *exprParser) parseNumber() (exprNode, error) {
+	start := p.pos
+	for p.pos < len(p.s) && (isDigit(p.s[p.pos]) || p.s[p.pos] == '.' || p.s[p.pos] == '_') {
+		p.pos++
+	}
+
+	value, err := decimal.NewFromString(strings.ReplaceAll(p.s[start:p.pos], "_", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %q at position %d", p.s[start:p.pos], start+1)
+	}
+
+	unitStart := p.pos
+	if p.pos < len(p.s) && p.s[p.pos] == '%' {
+		p.pos++
+	}
+	for p.pos < len(p.s) && isLetter(p.s[p.pos]) {
+		p.pos++
+	}
+
+	unit := strings.ToLower(p.s[unitStart:p.pos])
+	if unit == "" {
+		return &numberExpr{value: value}, nil
+	}
+
+	multiplier, ok := expressionUnits[unit]
+	if !ok {
+		return nil, fmt.Errorf("unknown unit %q at position %d, expected one of K, M, B, KB, MB, GB, TB, PB or %%", p.s[unitStart:p.pos], unitStart+1)
+	}
+
+	return &numberExpr{value: value.Mul(multiplier), unit: unit}, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func evalExpression(node exprNode, resolve func(path string) (decimal.Decimal, error)) (decimal.Decimal, error) {
+	switch n := node.(type) {
+	case *numberExpr:
+		return n.value, nil
+	case *refExpr:
+		return resolve(n.path)
+	case *unaryExpr:
+		x, err := evalExpression(n.x, resolve)
+		if err != nil {
+			return decimal.Zero, err
+		}
+
+		if n.op == '-' {
+			return x.Neg(), nil
+		}
+
+		return x, nil
+	case *binaryExpr:
+		x, err := evalExpression(n.x, resolve)
+		if err != nil {
+			return decimal.Zero, err
+		}
+
+		y, err := evalExpression(n.y, resolve)
+		if err != nil {
+			return decimal.Zero, err
+		}
+
+		switch n.op {
+		case '+':
+			return x.Add(y), nil
+		case '-':
+			return x.Sub(y), nil
+		case '*':
+			return x.Mul(y), nil
+		case '/':
+			if y.IsZero() {
+				return decimal.Zero, errors.New("division by zero")
+			}
+
+			return x.Div(y), nil
+		}
+	}
+
+	return decimal.Zero, fmt.Errorf("unexpected formula node %T", node)
+}
+
+// numberValue returns the value of a usage item as a decimal, if it is a
+// number.
+func numberValue(v interface{}) (decimal.Decimal, bool) {
+	switch n := v.(type) {
+	case int:
+		return decimal.NewFromInt(int64(n)), true
+	case int64:
+		return decimal.NewFromInt(n), true
+	case float64:
+		return decimal.NewFromFloat(n), true
+	}
+
+	return decimal.Zero, false
+}
+
+const (
+	exprUnvisited = iota
+	exprVisiting
+	exprEvaluated
+)
+
+// expressionEvaluator evaluates the formulas of a set of usage values,
+// evaluating referenced formulas first.
+type expressionEvaluator struct {
+	variables          *ResourceUsage
+	resourceTypeUsages map[string]*ResourceUsage
+	resourceUsages     map[string]*ResourceUsage
+
+	state map[*schema.UsageItem]int
+	stack []string
+}
+
+// evaluateUsageExpressions evaluates the formulas of the variables and usage,
+// setting the value of each usage item with a formula to the result. Formulas
+// that were already evaluated are evaluated again, so the result reflects any
+// values that have been changed, e.g. by a usage profile.
+func evaluateUsageExpressions(variables *ResourceUsage, resourceTypeUsages []*ResourceUsage, resourceUsages []*ResourceUsage) error {
+	e := &expressionEvaluator{
+		variables:          variables,
+		resourceTypeUsages: resourceUsagesMap(resourceTypeUsages),
+		resourceUsages:     resourceUsagesMap(resourceUsages),
+		state:              make(map[*schema.UsageItem]int),
+	}
+
+	all := append([]*ResourceUsage{}, resourceTypeUsages...)
+	all = append(all, resourceUsages...)
+	if variables != nil {
+		all = append([]*ResourceUsage{variables}, all...)
+	}
+
+	for _, r := range all {
+		err := e.evaluateResourceUsage(r.Name, r)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *expressionEvaluator) evaluateResourceUsage(prefix string, r *ResourceUsage) error {
+	for _, item := range r.Items {
+		name := prefix + "." + item.Key
+
+		if item.ValueType == schema.SubResourceUsage {
+			if sub, ok := item.Value.(*ResourceUsage); ok {
+				err := e.evaluateResourceUsage(name, sub)
+				if err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		err := e.evaluateItem(name, item)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *expressionEvaluator) evaluateItem(name string, item *schema.UsageItem) error {
+	if item.Expression == nil || e.state[item] == exprEvaluated {
+		return nil
+	}
+
+	if e.state[item] == exprVisiting {
+		cycle := append([]string{}, e.stack...)
+		for i, n := range cycle {
+			if n == name {
+				cycle = cycle[i:]
+				break
+			}
+		}
+
+		return &ExpressionError{
+			Line: item.Expression.Line,
+			Name: name,
+			Err:  fmt.Errorf("formula references itself: %s", strings.Join(append(cycle, name), " -> ")),
+		}
+	}
+
+	e.state[item] = exprVisiting
+	e.stack = append(e.stack, name)
+
+	value, err := e.evaluate(item.Expression.Value)
+	if err != nil {
+		var exprErr *ExpressionError
+		if errors.As(err, &exprErr) {
+			return err
+		}
+
+		return &ExpressionError{Line: item.Expression.Line, Name: name, Err: err}
+	}
+
+	e.stack = e.stack[:len(e.stack)-1]
+	e.state[item] = exprEvaluated
+
+	setNumberValue(item, value)
+
+	return nil
+}
+
+func (e *expressionEvaluator) evaluate(s string) (decimal.Decimal, error) {
+	node, err := parseExpression(s)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return evalExpression(node, e.resolve)
+}
+
+// resolve returns the value of the usage value with the given reference.
+func (e *expressionEvaluator) resolve(path string) (decimal.Decimal, error) {
+	name, item := e.findReference(path)
+	if item == nil {
+		return decimal.Zero, fmt.Errorf("unknown reference ${%s}", path)
+	}
+
+	err := e.evaluateItem(name, item)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	value, ok := numberValue(item.Value)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("reference ${%s} is not a number", path)
+	}
+
+	return value, nil
+}
+
+// findReference returns the usage item with the given reference and its
+// name. The longest resource address or resource type that matches the
+// start of the reference is used, so keys can't be confused with parts of a
+// module address.
+func (e *expressionEvaluator) findReference(path string) (string, *schema.UsageItem) {
+	parts := splitReference(path)
+
+	for i := len(parts) - 1; i > 0; i-- {
+		prefix := strings.Join(parts[:i], ".")
+		keys := parts[i:]
+
+		var candidates []*ResourceUsage
+		if prefix == "var" {
+			candidates = append(candidates, e.variables)
+		} else {
+			candidates = append(candidates, e.resourceUsages[prefix])
+
+			if strings.HasSuffix(prefix, "]") {
+				candidates = append(candidates, e.resourceUsages[prefix[:strings.LastIndex(prefix, "[")]+"[*]"])
+			}
+
+			if i == 1 {
+				candidates = append(candidates, e.resourceTypeUsages[prefix])
+			} else {
+				candidates = append(candidates, e.resourceTypeUsages[parts[i-2]])
+			}
+		}
+
+		for _, r := range candidates {
+			if item := findUsageItem(r, keys); item != nil {
+				return r.Name + "." + strings.Join(keys, "."), item
+			}
+		}
+	}
+
+	return "", nil
+}
+
+func findUsageItem(r *ResourceUsage, keys []string) *schema.UsageItem {
+	if r == nil || len(keys) == 0 {
+		return nil
+	}
+
+	for _, item := range r.Items {
+		if item.Key != keys[0] {
+			continue
+		}
+
+		if len(keys) == 1 {
+			return item
+		}
+
+		sub, ok := item.Value.(*ResourceUsage)
+		if !ok {
+			return nil
+		}
+
+		return findUsageItem(sub, keys[1:])
+	}
+
+	return nil
+}
+
+// splitReference splits a reference on the dots that are not within brackets,
+// e.g. module.a["b.c"].aws_s3_bucket.d.key has five parts.
+func splitReference(path string) []string {
+	var parts []string
+
+	depth := 0
+	start := 0
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '.':
+			if depth == 0 {
+				parts = append(parts, path[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, path[start:])
+}
+
+func sameNumber(a, b interface{}) bool {
+	x, ok := numberValue(a)
+	if !ok {
+		return false
+	}
+
+	y, ok := numberValue(b)
+	return ok && x.Equal(y)
+}
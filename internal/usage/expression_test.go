@@ -0,0 +1,241 @@
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    string
+		wantErr string
+	}{
+		{expr: "30M", want: "30000000"},
+		{expr: "1.5k", want: "1500"},
+		{expr: "2TB", want: "2000"},
+		{expr: "512MB", want: "0.512"},
+		{expr: "120% * 10", want: "12"},
+		{expr: "1_000 * 2", want: "2000"},
+		{expr: "10 - 2 * 3", want: "4"},
+		{expr: "(10 - 2) * 3", want: "24"},
+		{expr: "-(4 + 1) / 2", want: "-2.5"},
+		{expr: "2XB", wantErr: `unknown unit "XB" at position 2`},
+		{expr: "(1 + 2", wantErr: "missing ) at position 7"},
+		{expr: "1 +", wantErr: "unexpected end of formula"},
+		{expr: "1 / 0", wantErr: "division by zero"},
+		{expr: "${a.b", wantErr: "missing } for reference at position 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			e := &expressionEvaluator{}
+
+			got, err := e.evaluate(tt.expr)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestIsExpression(t *testing.T) {
+	assert.True(t, isExpression("30M"))
+	assert.True(t, isExpression("10 * 2"))
+	assert.True(t, isExpression("${var.requests}"))
+	assert.True(t, isExpression("${var.requests"))
+	assert.False(t, isExpression("100"))
+	assert.False(t, isExpression("STANDARD_IA"))
+	assert.False(t, isExpression("us-east-1"))
+	assert.False(t, isExpression("t3.micro"))
+}
+
+func TestSplitReference(t *testing.T) {
+	assert.Equal(t, []string{"aws_s3_bucket", "logs", "standard", "storage_gb"}, splitReference("aws_s3_bucket.logs.standard.storage_gb"))
+	assert.Equal(t, []string{"module", `a["b.c"]`, "aws_lambda_function", "api[0]", "monthly_requests"}, splitReference(`module.a["b.c"].aws_lambda_function.api[0].monthly_requests`))
+}
+
+const expressionsUsageFile = `
+version: 0.1
+variables:
+  daily_users: 10K
+  requests_per_user: 20
+  monthly_requests: ${var.daily_users} * ${var.requests_per_user} * 30
+resource_type_default_usage:
+  aws_lambda_function:
+    request_duration_ms: 250
+resource_usage:
+  aws_api_gateway_rest_api.main:
+    monthly_requests: ${var.monthly_requests}
+  aws_lambda_function.api:
+    monthly_requests: ${aws_api_gateway_rest_api.main.monthly_requests} * 1.2
+    request_duration_ms: ${aws_lambda_function.worker.request_duration_ms} / 2
+  aws_lambda_function.worker[*]:
+    monthly_requests: ${aws_lambda_function.api.monthly_requests} / 3
+  aws_s3_bucket.logs:
+    standard:
+      storage_gb: 2TB
+    infrequent_access:
+      storage_gb: ${aws_s3_bucket.logs.standard.storage_gb} / 4
+    storage_class: STANDARD
+usage_profiles:
+  peak:
+    variables:
+      daily_users: 50K
+`
+
+func TestUsageExpressions(t *testing.T) {
+	usageFile, err := LoadUsageFileFromString(expressionsUsageFile)
+	require.NoError(t, err)
+
+	m := usageFile.ExpectedUsageDataMap()
+	assert.Equal(t, int64(6000000), m.Get("aws_api_gateway_rest_api.main").Get("monthly_requests").Int())
+	assert.Equal(t, int64(7200000), m.Get("aws_lambda_function.api").Get("monthly_requests").Int())
+	assert.Equal(t, 125.0, m.Get("aws_lambda_function.api").Get("request_duration_ms").Float())
+	assert.Equal(t, int64(2400000), m.Get("aws_lambda_function.worker[*]").Get("monthly_requests").Int())
+	assert.Equal(t, int64(2000), m.Get("aws_s3_bucket.logs").Get("standard").Get("storage_gb").Int())
+	assert.Equal(t, int64(500), m.Get("aws_s3_bucket.logs").Get("infrequent_access").Get("storage_gb").Int())
+	assert.Equal(t, "STANDARD", m.Get("aws_s3_bucket.logs").Get("storage_class").String())
+
+	// Formulas are evaluated again with the values of the usage profile.
+	peak := usageFile.ProfileUsageDataMap("peak")
+	assert.Equal(t, int64(30000000), peak.Get("aws_api_gateway_rest_api.main").Get("monthly_requests").Int())
+	assert.Equal(t, int64(36000000), peak.Get("aws_lambda_function.api").Get("monthly_requests").Int())
+
+	// The usage profile doesn't change the values of the usage file.
+	m = usageFile.ToUsageDataMap()
+	assert.Equal(t, int64(7200000), m.Get("aws_lambda_function.api").Get("monthly_requests").Int())
+}
+
+func TestUsageExpressionsInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		usage   string
+		wantErr string
+	}{
+		{
+			name: "cycle",
+			usage: `
+version: 0.1
+resource_usage:
+  aws_lambda_function.a:
+    monthly_requests: ${aws_lambda_function.b.monthly_requests} + 1
+  aws_lambda_function.b:
+    monthly_requests: ${aws_lambda_function.a.monthly_requests} * 2
+`,
+			wantErr: "line 5: invalid value for aws_lambda_function.a.monthly_requests: formula references itself: aws_lambda_function.a.monthly_requests -> aws_lambda_function.b.monthly_requests -> aws_lambda_function.a.monthly_requests",
+		},
+		{
+			name: "unknown reference",
+			usage: `
+version: 0.1
+resource_usage:
+  aws_lambda_function.a:
+    monthly_requests: ${aws_lambda_function.missing.monthly_requests}
+`,
+			wantErr: "line 5: invalid value for aws_lambda_function.a.monthly_requests: unknown reference ${aws_lambda_function.missing.monthly_requests}",
+		},
+		{
+			name: "string reference",
+			usage: `
+version: 0.1
+resource_usage:
+  aws_s3_bucket.a:
+    storage_class: STANDARD
+    monthly_requests: ${aws_s3_bucket.a.storage_class} * 2
+`,
+			wantErr: "line 6: invalid value for aws_s3_bucket.a.monthly_requests: reference ${aws_s3_bucket.a.storage_class} is not a number",
+		},
+		{
+			name: "invalid variable",
+			usage: `
+version: 0.1
+variables:
+  region: us-east-1
+`,
+			wantErr: "variable region on line 4 must be a number or a formula",
+		},
+		{
+			name: "invalid formula in usage profile",
+			usage: `
+version: 0.1
+usage_profiles:
+  peak:
+    variables:
+      requests: ${var.requests} * 2
+`,
+			wantErr: "Error evaluating usage profile peak: line 6: invalid value for var.requests: formula references itself",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadUsageFileFromString(tt.usage)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestUsageExpressionsErrorPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost-usage.yml")
+	require.NoError(t, os.WriteFile(path, []byte("version: 0.1\nresource_usage:\n  aws_lambda_function.a:\n    monthly_requests: 10 / (2 - 2)\n"), 0600))
+
+	_, err := LoadUsageFile(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), path+":4: invalid value for aws_lambda_function.a.monthly_requests: division by zero")
+}
+
+func TestUsageExpressionsWriteToPath(t *testing.T) {
+	usageFile, err := LoadUsageFileFromString(expressionsUsageFile)
+	require.NoError(t, err)
+
+	// Sync replaces the value types with the ones from the usage schema of the resource.
+	resourceUsage := &ResourceUsage{Name: "aws_lambda_function.api"}
+	replaceResourceUsages(resourceUsage, &ResourceUsage{Name: "aws_lambda_function.api", Items: []*schema.UsageItem{
+		{Key: "monthly_requests", ValueType: schema.Float64},
+	}}, ReplaceResourceUsagesOpts{OverrideValueType: true})
+	replaceResourceUsages(resourceUsage, resourceUsagesMap(usageFile.ResourceUsages)["aws_lambda_function.api"], ReplaceResourceUsagesOpts{})
+	usageFile.ResourceUsages[1] = resourceUsage
+
+	path := filepath.Join(t.TempDir(), "infracost-usage.yml")
+	require.NoError(t, usageFile.WriteToPath(path))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "variables:\n  daily_users: 10K\n")
+	assert.Contains(t, string(b), "monthly_requests: ${aws_api_gateway_rest_api.main.monthly_requests} * 1.2\n")
+	assert.Contains(t, string(b), "storage_gb: 2TB\n")
+
+	written, err := LoadUsageFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7200000), written.ToUsageDataMap().Get("aws_lambda_function.api").Get("monthly_requests").Int())
+}
+
+func TestMergeResourceUsageWithUsageDataExpression(t *testing.T) {
+	resourceUsage := &ResourceUsage{Name: "aws_lambda_function.api", Items: []*schema.UsageItem{
+		{Key: "monthly_requests", ValueType: schema.Int64, Value: int64(10), Expression: &schema.UsageExpression{Value: "${var.requests}"}},
+		{Key: "request_duration_ms", ValueType: schema.Int64, Value: int64(10), Expression: &schema.UsageExpression{Value: "${var.duration}"}},
+	}}
+
+	mergeResourceUsageWithUsageData(resourceUsage, schema.NewUsageData("aws_lambda_function.api", schema.ParseAttributes(map[string]interface{}{
+		"monthly_requests":    10,
+		"request_duration_ms": 20,
+	})))
+
+	// The formula is kept if the estimated value is the same as its result.
+	assert.NotNil(t, resourceUsage.Items[0].Expression)
+	assert.Nil(t, resourceUsage.Items[1].Expression)
+	assert.Equal(t, int64(20), resourceUsage.Items[1].Value)
+}
@@ -0,0 +1,17 @@
+package google
+
+import (
+	"context"
+)
+
+// BigQueryGetScannedBytesBilled returns the bytes billed for the queries of
+// the project in the last month. Cloud Monitoring doesn't split the scanned
+// bytes by dataset, so this includes the queries of all the datasets of the
+// project.
+func BigQueryGetScannedBytesBilled(ctx context.Context, project string) (float64, error) {
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project: project,
+		metric:  "bigquery.googleapis.com/query/scanned_bytes_billed",
+		aligner: alignSum,
+	})
+}
@@ -0,0 +1,32 @@
+package google
+
+import (
+	"context"
+
+	"google.golang.org/api/option"
+
+	"github.com/infracost/infracost/internal/usage"
+)
+
+type ctxConfigOptsKeyType struct{}
+
+var ctxConfigOptsKey = &ctxConfigOptsKeyType{}
+
+// getClientOptions returns the options for the Google API clients. The
+// application default credentials are used unless the credentials file is
+// set by GOOGLE_APPLICATION_CREDENTIALS in the environment variables of the
+// project, which can be set in the Infracost config file.
+func getClientOptions(ctx context.Context) []option.ClientOption {
+	if opts, ok := ctx.Value(ctxConfigOptsKey).([]option.ClientOption); ok {
+		return opts
+	}
+
+	var opts []option.ClientOption
+	if env, ok := ctx.Value(usage.ContextEnv{}).(map[string]string); ok {
+		if path := env["GOOGLE_APPLICATION_CREDENTIALS"]; path != "" {
+			opts = append(opts, option.WithCredentialsFile(path))
+		}
+	}
+
+	return opts
+}
@@ -0,0 +1,48 @@
+package google
+
+import (
+	"context"
+)
+
+func cloudFunctionLabels(region, name string) map[string]string {
+	return map[string]string{
+		"resource.type":                 "cloud_function",
+		"resource.labels.region":        region,
+		"resource.labels.function_name": name,
+	}
+}
+
+// CloudFunctionsGetInvocations returns the number of executions of the
+// function in the last month.
+func CloudFunctionsGetInvocations(ctx context.Context, project, region, name string) (float64, error) {
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project: project,
+		metric:  "cloudfunctions.googleapis.com/function/execution_count",
+		labels:  cloudFunctionLabels(region, name),
+		aligner: alignSum,
+	})
+}
+
+// CloudFunctionsGetMedianExecutionTimeMs returns the median execution time
+// of the function in the last month, in milliseconds.
+func CloudFunctionsGetMedianExecutionTimeMs(ctx context.Context, project, region, name string) (float64, error) {
+	nanos, err := monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project: project,
+		metric:  "cloudfunctions.googleapis.com/function/execution_times",
+		labels:  cloudFunctionLabels(region, name),
+		aligner: alignMedian,
+		reducer: reduceMean,
+	})
+	return nanos / 1e6, err
+}
+
+// CloudFunctionsGetOutboundBytes returns the bytes sent by the function in
+// the last month.
+func CloudFunctionsGetOutboundBytes(ctx context.Context, project, region, name string) (float64, error) {
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project: project,
+		metric:  "cloudfunctions.googleapis.com/function/network_egress",
+		labels:  cloudFunctionLabels(region, name),
+		aligner: alignSum,
+	})
+}
@@ -0,0 +1,134 @@
+package google
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	monitoring "google.golang.org/api/monitoring/v3"
+)
+
+const timeMonth = time.Hour * 24 * 30
+
+const (
+	alignSum    = "ALIGN_SUM"
+	alignMean   = "ALIGN_MEAN"
+	alignMedian = "ALIGN_PERCENTILE_50"
+	reduceSum   = "REDUCE_SUM"
+	reduceMean  = "REDUCE_MEAN"
+)
+
+type timeSeriesRequest struct {
+	project string
+	metric  string
+	// labels select the time series by the labels of the resource or metric,
+	// e.g. resource.labels.bucket_name.
+	labels  map[string]string
+	aligner string
+	// reducer combines the time series, grouped by the groupBy labels.
+	reducer string
+	groupBy []string
+}
+
+// monitoringGetMonthlyValues returns the value of the metric over the last
+// month for each value of the groupBy labels, joined by commas. The values of
+// the time series are aligned to a single point for the month.
+func monitoringGetMonthlyValues(ctx context.Context, req timeSeriesRequest) (map[string]float64, error) {
+	svc, err := monitoring.NewService(ctx, getClientOptions(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := timeSeriesFilter(req)
+	log.Debug().Msgf("Querying Google Cloud Monitoring: %s (project: %s)", filter, req.project)
+
+	end := time.Now().UTC()
+	call := svc.Projects.TimeSeries.List("projects/" + req.project).
+		Filter(filter).
+		IntervalStartTime(end.Add(-timeMonth).Format(time.RFC3339)).
+		IntervalEndTime(end.Format(time.RFC3339)).
+		AggregationAlignmentPeriod(fmt.Sprintf("%.0fs", timeMonth.Seconds())).
+		AggregationPerSeriesAligner(req.aligner)
+	if req.reducer != "" {
+		call = call.AggregationCrossSeriesReducer(req.reducer).AggregationGroupByFields(req.groupBy...)
+	}
+
+	values := make(map[string]float64)
+	err = call.Pages(ctx, func(resp *monitoring.ListTimeSeriesResponse) error {
+		for _, ts := range resp.TimeSeries {
+			key := groupKey(ts, req.groupBy)
+			for _, p := range ts.Points {
+				values[key] += pointValue(p)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// monitoringGetMonthlyValue returns the value of the metric over the last
+// month, combining the matching time series with the reducer of the request
+// or summing them if it has none.
+func monitoringGetMonthlyValue(ctx context.Context, req timeSeriesRequest) (float64, error) {
+	if req.reducer == "" {
+		req.reducer = reduceSum
+	}
+	req.groupBy = nil
+
+	values, err := monitoringGetMonthlyValues(ctx, req)
+	return values[""], err
+}
+
+func timeSeriesFilter(req timeSeriesRequest) string {
+	parts := []string{fmt.Sprintf("metric.type = %q", req.metric)}
+
+	keys := make([]string, 0, len(req.labels))
+	for k := range req.labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s = %q", k, req.labels[k]))
+	}
+
+	return strings.Join(parts, " AND ")
+}
+
+func groupKey(ts *monitoring.TimeSeries, groupBy []string) string {
+	values := make([]string, 0, len(groupBy))
+	for _, field := range groupBy {
+		var labels map[string]string
+		switch {
+		case strings.HasPrefix(field, "metric.labels.") && ts.Metric != nil:
+			labels = ts.Metric.Labels
+		case strings.HasPrefix(field, "resource.labels.") && ts.Resource != nil:
+			labels = ts.Resource.Labels
+		}
+		values = append(values, labels[field[strings.LastIndex(field, ".")+1:]])
+	}
+	return strings.Join(values, ",")
+}
+
+func pointValue(p *monitoring.Point) float64 {
+	if p.Value == nil {
+		return 0
+	}
+
+	switch {
+	case p.Value.DoubleValue != nil:
+		return *p.Value.DoubleValue
+	case p.Value.Int64Value != nil:
+		return float64(*p.Value.Int64Value)
+	case p.Value.DistributionValue != nil:
+		return p.Value.DistributionValue.Mean
+	}
+	return 0
+}
@@ -0,0 +1,48 @@
+package google
+
+import (
+	"context"
+)
+
+// PubSubGetTopicBytes returns the billable bytes published to the topic in
+// the last month.
+func PubSubGetTopicBytes(ctx context.Context, project, topic string) (float64, error) {
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project: project,
+		metric:  "pubsub.googleapis.com/topic/byte_cost",
+		labels: map[string]string{
+			"resource.type":            "pubsub_topic",
+			"resource.labels.topic_id": topic,
+		},
+		aligner: alignSum,
+	})
+}
+
+func pubSubSubscriptionLabels(subscription string) map[string]string {
+	return map[string]string{
+		"resource.type":                   "pubsub_subscription",
+		"resource.labels.subscription_id": subscription,
+	}
+}
+
+// PubSubGetSubscriptionBytes returns the billable bytes delivered to the
+// subscription in the last month.
+func PubSubGetSubscriptionBytes(ctx context.Context, project, subscription string) (float64, error) {
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project: project,
+		metric:  "pubsub.googleapis.com/subscription/byte_cost",
+		labels:  pubSubSubscriptionLabels(subscription),
+		aligner: alignSum,
+	})
+}
+
+// PubSubGetRetainedAckedBytes returns the average size of the acknowledged
+// messages retained by the subscription in the last month.
+func PubSubGetRetainedAckedBytes(ctx context.Context, project, subscription string) (float64, error) {
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project: project,
+		metric:  "pubsub.googleapis.com/subscription/retained_acked_bytes",
+		labels:  pubSubSubscriptionLabels(subscription),
+		aligner: alignMean,
+	})
+}
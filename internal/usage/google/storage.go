@@ -0,0 +1,82 @@
+package google
+
+import (
+	"context"
+)
+
+// storageClassAMethods are the JSON API methods billed as Class A operations.
+var storageClassAMethods = map[string]bool{
+	"WriteObject":              true,
+	"ComposeObject":            true,
+	"CopyObject":               true,
+	"RewriteObject":            true,
+	"UpdateObjectMetadata":     true,
+	"PatchObjectMetadata":      true,
+	"ListObjects":              true,
+	"ListBuckets":              true,
+	"CreateBucket":             true,
+	"UpdateBucketMetadata":     true,
+	"PatchBucketMetadata":      true,
+	"SetBucketIamPolicy":       true,
+	"SetObjectIamPolicy":       true,
+	"ListNotificationConfigs":  true,
+	"CreateNotificationConfig": true,
+	"ListHmacKeys":             true,
+	"CreateHmacKey":            true,
+	"WatchAllObjects":          true,
+}
+
+// storageFreeMethods are the JSON API methods that aren't billed.
+var storageFreeMethods = map[string]bool{
+	"DeleteObject":             true,
+	"DeleteBucket":             true,
+	"DeleteNotificationConfig": true,
+	"DeleteHmacKey":            true,
+}
+
+func storageBucketLabels(bucket string) map[string]string {
+	return map[string]string{
+		"resource.type":               "gcs_bucket",
+		"resource.labels.bucket_name": bucket,
+	}
+}
+
+// StorageGetBytes returns the average size of the objects in the bucket in
+// the last month.
+func StorageGetBytes(ctx context.Context, project, bucket string) (float64, error) {
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project: project,
+		metric:  "storage.googleapis.com/storage/total_bytes",
+		labels:  storageBucketLabels(bucket),
+		aligner: alignMean,
+	})
+}
+
+// StorageGetOperations returns the number of Class A and Class B operations
+// on the bucket in the last month.
+func StorageGetOperations(ctx context.Context, project, bucket string) (float64, float64, error) {
+	counts, err := monitoringGetMonthlyValues(ctx, timeSeriesRequest{
+		project: project,
+		metric:  "storage.googleapis.com/api/request_count",
+		labels:  storageBucketLabels(bucket),
+		aligner: alignSum,
+		reducer: reduceSum,
+		groupBy: []string{"metric.labels.method"},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var classA, classB float64
+	for method, count := range counts {
+		switch {
+		case storageClassAMethods[method]:
+			classA += count
+		case storageFreeMethods[method]:
+		default:
+			classB += count
+		}
+	}
+
+	return classA, classB, nil
+}
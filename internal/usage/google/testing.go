@@ -0,0 +1,18 @@
+package google
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/api/option"
+)
+
+// WithTestEndpoint returns a context that sends the Google API requests to
+// the URL without authentication, for testing.
+func WithTestEndpoint(ctx context.Context, url string) context.Context {
+	return context.WithValue(ctx, ctxConfigOptsKey, []option.ClientOption{
+		// The paths of the API are relative to the endpoint
+		option.WithEndpoint(strings.TrimSuffix(url, "/") + "/"),
+		option.WithoutAuthentication(),
+	})
+}
@@ -3,6 +3,7 @@ package usage
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -40,7 +41,7 @@ func (r *ResourceUsage) MergeResourceUsage(src *ResourceUsage) {
 	for _, srcItem := range src.Items {
 		destItem, ok := destItemMap[srcItem.Key]
 		if !ok {
-			destItem = &schema.UsageItem{Key: srcItem.Key}
+			destItem = &schema.UsageItem{Key: srcItem.Key, ValueType: srcItem.ValueType}
 			r.Items = append(r.Items, destItem)
 		}
 
@@ -82,6 +83,7 @@ func (r *ResourceUsage) MergeResourceUsage(src *ResourceUsage) {
 
 			if srcItem.Value != nil {
 				destItem.Value = srcItem.Value
+				destItem.Expression = srcItem.Expression
 			}
 		}
 	}
@@ -148,6 +150,36 @@ func ResourceUsagesFromYAML(raw yamlv3.Node) ([]*ResourceUsage, error) {
 	return resourceUsages, nil
 }
 
+// MergeWildcardResourceUsages merges the values of the wildcard resource
+// usages, e.g. aws_lambda_function.fn[*], into the resource usages of the same
+// resource with an index, e.g. aws_lambda_function.fn["a"], without
+// overriding the values of the indexed resource usages.
+func MergeWildcardResourceUsages(resourceUsages []*ResourceUsage) {
+	wildCardUsage := make(map[string]*ResourceUsage)
+	for _, us := range resourceUsages {
+		if strings.HasSuffix(us.Name, "[*]") {
+			wildCardUsage[wildcardPrefix(us.Name)] = us
+		}
+	}
+
+	for _, us := range resourceUsages {
+		if strings.HasSuffix(us.Name, "[*]") {
+			continue
+		}
+
+		if !strings.HasSuffix(us.Name, "]") {
+			continue
+		}
+
+		us.MergeResourceUsage(wildCardUsage[wildcardPrefix(us.Name)])
+	}
+}
+
+// wildcardPrefix returns the name of the resource without its last index.
+func wildcardPrefix(name string) string {
+	return name[:strings.LastIndex(name, "[")]
+}
+
 func resourceUsagesMap(resourceUsages []*ResourceUsage) map[string]*ResourceUsage {
 	m := make(map[string]*ResourceUsage)
 
@@ -272,6 +304,12 @@ func ResourceUsagesToYAML(resourceUsages []*ResourceUsage) (yamlv3.Node, bool) {
 				}
 			}
 
+			// Formulas are written back as they are in the usage file rather than their value
+			if item.Value != nil && item.Expression != nil {
+				tag = "!!str"
+				value = item.Expression.Value
+			}
+
 			itemKeyNode := &yamlv3.Node{
 				Kind:  yamlv3.ScalarNode,
 				Tag:   "!!str",
@@ -363,6 +401,7 @@ func usageItemFromYAML(keyNode *yamlv3.Node, valNode *yamlv3.Node) (*schema.Usag
 
 	var value interface{}
 	var usageValueType schema.UsageVariableType
+	var expression *schema.UsageExpression
 
 	if valNode.ShortTag() == "!!map" {
 		usageValueType = schema.SubResourceUsage
@@ -408,6 +447,11 @@ func usageItemFromYAML(keyNode *yamlv3.Node, valNode *yamlv3.Node) (*schema.Usag
 
 		default:
 			usageValueType = schema.String
+
+			// Formulas are evaluated once the whole usage file has been loaded
+			if s, ok := value.(string); ok && isExpression(s) {
+				expression = &schema.UsageExpression{Value: s, Line: valNode.Line}
+			}
 		}
 	}
 
@@ -416,5 +460,6 @@ func usageItemFromYAML(keyNode *yamlv3.Node, valNode *yamlv3.Node) (*schema.Usag
 		ValueType:   usageValueType,
 		Value:       value,
 		Description: valNode.LineComment,
+		Expression:  expression,
 	}, nil
 }
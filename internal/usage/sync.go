@@ -338,6 +338,7 @@ func replaceResourceUsages(dest *ResourceUsage, src *ResourceUsage, opts Replace
 
 			if srcItem.Value != nil {
 				destItem.Value = srcItem.Value
+				destItem.Expression = srcItem.Expression
 			}
 		}
 	}
@@ -412,6 +413,11 @@ func mergeResourceUsageWithUsageData(resourceUsage *ResourceUsage, usageData *sc
 		}
 
 		if val != nil {
+			// Keep the formula unless the estimated value is different from its result
+			if item.Expression != nil && !sameNumber(val, item.Value) {
+				item.Expression = nil
+			}
+
 			item.Value = val
 		}
 	}
@@ -20,6 +20,10 @@ const maxUsageFileVersion = "0.1"
 
 type UsageFile struct { // nolint:revive
 	Version string `yaml:"version"`
+	// We represent variables using a YAML node so they are written back unchanged when the usage file is synced
+	RawVariables yamlv3.Node `yaml:"variables"`
+	// The raw variables are then parsed into this struct, they are referenced in formulas as var.<name>
+	Variables *ResourceUsage `yaml:"-"`
 	// We represent resource type usage in using a YAML node so we have control over the comments
 	RawResourceTypeUsage yamlv3.Node `yaml:"resource_type_default_usage"`
 	// The raw usage is then parsed into this struct
@@ -28,6 +32,14 @@ type UsageFile struct { // nolint:revive
 	RawResourceUsage yamlv3.Node `yaml:"resource_usage"`
 	// The raw usage is then parsed into this struct
 	ResourceUsages []*ResourceUsage `yaml:"-"`
+	// We represent usage profiles using a YAML node so they are written back unchanged when the usage file is synced
+	RawUsageProfiles yamlv3.Node `yaml:"usage_profiles"`
+	// The raw usage profiles are then parsed into this struct
+	UsageProfiles []*UsageProfile `yaml:"-"`
+	// We represent the usage growth rates using a YAML node so they are written back unchanged when the usage file is synced
+	RawUsageGrowth yamlv3.Node `yaml:"usage_growth"`
+	// The raw usage growth rates are then parsed into this struct
+	UsageGrowth *UsageProfile `yaml:"-"`
 }
 
 // CreateUsageFile creates a blank usage file if it does not exists
@@ -62,6 +74,11 @@ func LoadUsageFile(path string) (*UsageFile, error) {
 
 	usageFile, err := LoadUsageFileFromString(string(contents))
 	if err != nil {
+		var exprErr *ExpressionError
+		if errors.As(err, &exprErr) {
+			exprErr.Path = path
+		}
+
 		return blankUsage, errors.Wrapf(err, "Error loading usage file")
 	}
 
@@ -138,12 +155,45 @@ See https://infracost.io/usage-file/ for docs`,
 			Kind:  yamlv3.ScalarNode,
 			Value: u.Version,
 		},
+	)
+
+	if len(u.RawVariables.Content) > 0 {
+		root.Content = append(root.Content,
+			&yamlv3.Node{
+				Kind:  yamlv3.ScalarNode,
+				Value: "variables",
+			},
+			&u.RawVariables,
+		)
+	}
+
+	root.Content = append(root.Content,
 		resourceTypeUsagesKeyNode,
 		&u.RawResourceTypeUsage,
 		resourceUsagesKeyNode,
 		&u.RawResourceUsage,
 	)
 
+	if len(u.RawUsageProfiles.Content) > 0 {
+		root.Content = append(root.Content,
+			&yamlv3.Node{
+				Kind:  yamlv3.ScalarNode,
+				Value: "usage_profiles",
+			},
+			&u.RawUsageProfiles,
+		)
+	}
+
+	if len(u.RawUsageGrowth.Content) > 0 {
+		root.Content = append(root.Content,
+			&yamlv3.Node{
+				Kind:  yamlv3.ScalarNode,
+				Value: "usage_growth",
+			},
+			&u.RawUsageGrowth,
+		)
+	}
+
 	// Add a comment to the first commented-out resource
 	for _, node := range u.RawResourceTypeUsage.Content {
 		if isNodeMarkedAsCommented(node) {
@@ -182,13 +232,17 @@ See https://infracost.io/usage-file/ for docs`,
 }
 
 func (u *UsageFile) ToUsageDataMap() schema.UsageMap {
+	return usageDataMap(u.ResourceTypeUsages, u.ResourceUsages)
+}
+
+func usageDataMap(resourceTypeUsages []*ResourceUsage, resourceUsages []*ResourceUsage) schema.UsageMap {
 	m := make(map[string]interface{})
 
-	for _, resourceUsage := range u.ResourceTypeUsages {
+	for _, resourceUsage := range resourceTypeUsages {
 		m[resourceUsage.Name] = resourceUsage.Map()
 	}
 
-	for _, resourceUsage := range u.ResourceUsages {
+	for _, resourceUsage := range resourceUsages {
 		m[resourceUsage.Name] = resourceUsage.Map()
 	}
 
@@ -214,7 +268,17 @@ func (u *UsageFile) InvalidKeys() ([]string, error) {
 		return invalidKeys, err
 	}
 
-	for _, resourceUsage := range u.ResourceUsages {
+	resourceUsages := append([]*ResourceUsage{}, u.ResourceUsages...)
+	resourceTypeUsages := append([]*ResourceUsage{}, u.ResourceTypeUsages...)
+	for _, p := range append(append([]*UsageProfile{}, u.UsageProfiles...), u.UsageGrowth) {
+		if p == nil {
+			continue
+		}
+		resourceUsages = append(resourceUsages, p.ResourceUsages...)
+		resourceTypeUsages = append(resourceTypeUsages, p.ResourceTypeUsages...)
+	}
+
+	for _, resourceUsage := range resourceUsages {
 		refResourceUsage := refFile.FindMatchingResourceUsage(resourceUsage.Name)
 		if refResourceUsage == nil {
 			continue
@@ -228,7 +292,7 @@ func (u *UsageFile) InvalidKeys() ([]string, error) {
 		}
 	}
 
-	for _, resourceUsage := range u.ResourceTypeUsages {
+	for _, resourceUsage := range resourceTypeUsages {
 		refResourceUsage := refFile.FindMatchingResourceTypeUsage(resourceUsage.Name)
 		if refResourceUsage == nil {
 			continue
@@ -289,9 +353,72 @@ func (u *UsageFile) parseResourceUsages() error {
 	if err != nil {
 		return errors.Wrapf(err, "Error parsing usage file")
 	}
+	u.Variables, err = variablesFromYAML(u.RawVariables)
+	if err != nil {
+		return errors.Wrapf(err, "Error parsing usage file")
+	}
+	err = u.parseUsageProfiles()
+	if err != nil {
+		return errors.Wrapf(err, "Error parsing usage file")
+	}
+	err = u.parseUsageGrowth()
+	if err != nil {
+		return errors.Wrapf(err, "Error parsing usage file")
+	}
+	return u.evaluateExpressions()
+}
+
+// evaluateExpressions evaluates the formulas in the usage file. The formulas
+// of each usage profile are evaluated with the values of the profile so any
+// errors are reported when the usage file is loaded.
+func (u *UsageFile) evaluateExpressions() error {
+	err := evaluateUsageExpressions(u.Variables, u.ResourceTypeUsages, u.ResourceUsages)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range u.UsageProfiles {
+		_, err := u.profileUsages(p)
+		if err != nil {
+			return errors.Wrapf(err, "Error evaluating usage profile %s", p.Name)
+		}
+	}
+
 	return nil
 }
 
+// variablesFromYAML parses the variables of the usage file into a resource
+// usage so they can be referenced in formulas the same way as other usage.
+func variablesFromYAML(raw yamlv3.Node) (*ResourceUsage, error) {
+	variables := &ResourceUsage{Name: "var"}
+
+	if raw.Kind == 0 || (raw.Kind == yamlv3.ScalarNode && raw.Tag == "!!null") {
+		return variables, nil
+	}
+
+	if raw.Kind != yamlv3.MappingNode {
+		return nil, fmt.Errorf("variables must be a map of variable names to values on line %d", raw.Line)
+	}
+
+	for i := 0; i+1 < len(raw.Content); i += 2 {
+		keyNode := raw.Content[i]
+		valNode := raw.Content[i+1]
+
+		item, err := usageItemFromYAML(keyNode, valNode)
+		if err != nil {
+			return nil, err
+		}
+
+		if item.Expression == nil && item.ValueType != schema.Int64 && item.ValueType != schema.Float64 {
+			return nil, fmt.Errorf("variable %s on line %d must be a number or a formula", keyNode.Value, valNode.Line)
+		}
+
+		variables.Items = append(variables.Items, item)
+	}
+
+	return variables, nil
+}
+
 func (u *UsageFile) dumpResourceUsages() (bool, bool) {
 	var allResourceTypesCommented bool
 	var allResourcesCommented bool
@@ -0,0 +1,233 @@
+package usage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// The usage_growth section of the usage file defines how much usage values
+// grow each month, which is used to forecast the costs, e.g.
+//
+//	usage_growth:
+//	  variables:
+//	    daily_users: 5%
+//	  resource_type_default_usage:
+//	    aws_lambda_function:
+//	      monthly_requests: 2%
+//	  resource_usage:
+//	    aws_s3_bucket.logs:
+//	      standard:
+//	        storage_gb: 10%
+//
+// It has the same structure as a usage profile, but the values are monthly
+// growth rates, either as percentages or fractions, e.g. 5% or 0.05. The
+// growth rates of a resource fall back to the wildcard and resource type
+// growth rates, the same as the usage does. Formulas are evaluated again each
+// month, so the usage that references a growing value grows with it.
+
+// HasUsageGrowth returns true if the usage file defines any growth rates.
+func (u *UsageFile) HasUsageGrowth() bool {
+	return u.UsageGrowth != nil
+}
+
+// ForecastUsageDataMap returns the expected usage data with the growth rates
+// applied for the given month of a forecast, where month 0 is the current
+// month. An error is returned if the formulas can't be evaluated with the
+// grown values, e.g. if a grown value makes a divisor zero.
+func (u *UsageFile) ForecastUsageDataMap(month int) (schema.UsageMap, error) {
+	if u.UsageGrowth == nil || month == 0 {
+		return u.ExpectedUsageDataMap(), nil
+	}
+
+	usages, err := u.forecastUsages(month)
+	if err != nil {
+		return schema.UsageMap{}, errors.Wrapf(err, "Error evaluating usage for forecast month %d", month+1)
+	}
+
+	return usageDataMap(usages.ResourceTypeUsages, usages.ResourceUsages), nil
+}
+
+// forecastUsages returns a copy of the expected variables and usage with the
+// growth rates applied for the given month.
+func (u *UsageFile) forecastUsages(month int) (*UsageProfile, error) {
+	expected := u.usageProfile(schema.ExpectedUsageProfile)
+	if expected == nil {
+		expected = &UsageProfile{Name: schema.ExpectedUsageProfile}
+	}
+
+	usages, err := u.profileUsages(expected)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &usageGrower{growth: u.UsageGrowth, month: month}
+
+	// The values are grown before the formulas are evaluated so the formulas
+	// use the grown values, and the formulas with their own growth rates are
+	// grown after.
+	g.growAll(usages, false)
+
+	err = evaluateUsageExpressions(usages.Variables, usages.ResourceTypeUsages, usages.ResourceUsages)
+	if err != nil {
+		return nil, err
+	}
+
+	g.growAll(usages, true)
+
+	return usages, nil
+}
+
+func (u *UsageFile) parseUsageGrowth() error {
+	u.UsageGrowth = nil
+
+	if u.RawUsageGrowth.Kind == 0 {
+		return nil
+	}
+
+	growth, err := usageProfileFromYAML("usage_growth", &u.RawUsageGrowth)
+	if err != nil {
+		return errors.Wrap(err, "Error parsing usage_growth")
+	}
+
+	err = evaluateUsageExpressions(growth.Variables, growth.ResourceTypeUsages, growth.ResourceUsages)
+	if err != nil {
+		return errors.Wrap(err, "Error evaluating usage_growth")
+	}
+
+	for _, r := range append(nonNilResourceUsages(growth.Variables), append(growth.ResourceTypeUsages, growth.ResourceUsages...)...) {
+		err := checkGrowthRates(r.Name, r)
+		if err != nil {
+			return errors.Wrap(err, "Error parsing usage_growth")
+		}
+	}
+
+	u.UsageGrowth = growth
+
+	return nil
+}
+
+func checkGrowthRates(prefix string, r *ResourceUsage) error {
+	for _, item := range r.Items {
+		name := prefix + "." + item.Key
+
+		if sub, ok := item.Value.(*ResourceUsage); ok {
+			err := checkGrowthRates(name, sub)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		rate, ok := numberValue(item.Value)
+		if !ok {
+			return fmt.Errorf("growth rate of %s must be a number or a percentage, e.g. 5%%", name)
+		}
+
+		if rate.LessThanOrEqual(decimal.NewFromInt(-1)) {
+			return fmt.Errorf("growth rate of %s must be greater than -100%%", name)
+		}
+	}
+
+	return nil
+}
+
+// usageGrower applies the growth rates to the usage for a month of a
+// forecast.
+type usageGrower struct {
+	growth *UsageProfile
+	month  int
+}
+
+func (g *usageGrower) growAll(usages *UsageProfile, expressions bool) {
+	if usages.Variables != nil {
+		g.grow(usages.Variables, nonNilResourceUsages(g.growth.Variables), expressions)
+	}
+
+	typeRates := resourceUsagesMap(g.growth.ResourceTypeUsages)
+	for _, r := range usages.ResourceTypeUsages {
+		g.grow(r, nonNilResourceUsages(typeRates[r.Name]), expressions)
+	}
+
+	rates := resourceUsagesMap(g.growth.ResourceUsages)
+	for _, r := range usages.ResourceUsages {
+		candidates := []*ResourceUsage{rates[r.Name]}
+		if strings.HasSuffix(r.Name, "]") {
+			candidates = append(candidates, rates[r.Name[:strings.LastIndex(r.Name, "[")]+"[*]"])
+		}
+		if parts := splitReference(r.Name); len(parts) >= 2 {
+			candidates = append(candidates, typeRates[parts[len(parts)-2]])
+		}
+
+		g.grow(r, nonNilResourceUsages(candidates...), expressions)
+	}
+}
+
+// grow multiplies the values of the usage by the growth rates from the first
+// of the candidate growth rates that defines a rate for each value. Only the
+// values with formulas are grown if expressions is true, otherwise only the
+// values without formulas are grown.
+func (g *usageGrower) grow(r *ResourceUsage, candidates []*ResourceUsage, expressions bool) {
+	if len(candidates) == 0 {
+		return
+	}
+
+	for _, item := range r.Items {
+		if sub, ok := item.Value.(*ResourceUsage); ok {
+			var subCandidates []*ResourceUsage
+			for _, c := range candidates {
+				if rates, ok := usageItemValue(findUsageItem(c, []string{item.Key})).(*ResourceUsage); ok {
+					subCandidates = append(subCandidates, rates)
+				}
+			}
+
+			g.grow(sub, subCandidates, expressions)
+			continue
+		}
+
+		if (item.Expression != nil) != expressions {
+			continue
+		}
+
+		value, ok := numberValue(item.Value)
+		if !ok {
+			continue
+		}
+
+		for _, c := range candidates {
+			rate, ok := numberValue(usageItemValue(findUsageItem(c, []string{item.Key})))
+			if !ok {
+				continue
+			}
+
+			setNumberValue(item, value.Mul(decimal.NewFromInt(1).Add(rate).Pow(decimal.NewFromInt(int64(g.month)))))
+			break
+		}
+	}
+}
+
+// setNumberValue sets the value of the usage item to the number, as an
+// integer if it is a whole number.
+func setNumberValue(item *schema.UsageItem, value decimal.Decimal) {
+	if value.IsInteger() {
+		item.Value = value.IntPart()
+		item.ValueType = schema.Int64
+		return
+	}
+
+	item.Value, _ = value.Float64()
+	item.ValueType = schema.Float64
+}
+
+func usageItemValue(item *schema.UsageItem) interface{} {
+	if item == nil {
+		return nil
+	}
+
+	return item.Value
+}
@@ -0,0 +1,165 @@
+package usage_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/usage"
+)
+
+const usageGrowthFile = `
+version: 0.1
+variables:
+  daily_users: 1000
+resource_type_default_usage:
+  aws_lambda_function:
+    monthly_requests: 1000
+resource_usage:
+  aws_lambda_function.api:
+    request_duration_ms: 100
+  aws_api_gateway_rest_api.main:
+    monthly_requests: ${var.daily_users} * 30
+  aws_sqs_queue.jobs:
+    monthly_requests: ${aws_api_gateway_rest_api.main.monthly_requests} * 2
+  aws_s3_bucket.logs:
+    standard:
+      storage_gb: 100
+usage_growth:
+  variables:
+    daily_users: 10%
+  resource_type_default_usage:
+    aws_lambda_function:
+      monthly_requests: 0.5
+  resource_usage:
+    aws_sqs_queue.jobs:
+      monthly_requests: 100%
+    aws_s3_bucket.logs:
+      standard:
+        storage_gb: 20%
+`
+
+func TestUsageGrowth(t *testing.T) {
+	usageFile, err := usage.LoadUsageFileFromString(usageGrowthFile)
+	require.NoError(t, err)
+	assert.True(t, usageFile.HasUsageGrowth())
+
+	current, err := usageFile.ForecastUsageDataMap(0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(30000), current.Get("aws_api_gateway_rest_api.main").Get("monthly_requests").Int())
+
+	m, err := usageFile.ForecastUsageDataMap(2)
+	require.NoError(t, err)
+	// Values that reference a variable grow with the variable.
+	assert.Equal(t, int64(36300), m.Get("aws_api_gateway_rest_api.main").Get("monthly_requests").Int())
+	// Formulas with their own growth rate are grown after they are evaluated.
+	assert.Equal(t, int64(290400), m.Get("aws_sqs_queue.jobs").Get("monthly_requests").Int())
+	// Resources use the growth rates of their resource type.
+	assert.Equal(t, int64(2250), m.Get("aws_lambda_function.api").Get("monthly_requests").Int())
+	assert.Equal(t, int64(100), m.Get("aws_lambda_function.api").Get("request_duration_ms").Int())
+	assert.Equal(t, float64(144), m.Get("aws_s3_bucket.logs").Get("standard").Get("storage_gb").Float())
+
+	// The usage file isn't changed by the forecast.
+	m = usageFile.ExpectedUsageDataMap()
+	assert.Equal(t, int64(1000), m.Get("aws_lambda_function.api").Get("monthly_requests").Int())
+	assert.Equal(t, int64(60000), m.Get("aws_sqs_queue.jobs").Get("monthly_requests").Int())
+}
+
+func TestUsageGrowthNotDefined(t *testing.T) {
+	usageFile, err := usage.LoadUsageFileFromString(usageProfilesFile)
+	require.NoError(t, err)
+	assert.False(t, usageFile.HasUsageGrowth())
+
+	m, err := usageFile.ForecastUsageDataMap(11)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), m.Get("aws_lambda_function.api").Get("monthly_requests").Int())
+}
+
+func TestUsageGrowthForecastError(t *testing.T) {
+	// The formula can be evaluated for the current month, but the grown
+	// variable makes the divisor zero in the next month.
+	usageFile, err := usage.LoadUsageFileFromString(`
+version: 0.1
+variables:
+  a: 5
+resource_usage:
+  aws_lambda_function.api:
+    monthly_requests: 1000 / (${var.a} - 10)
+usage_growth:
+  variables:
+    a: 100%
+`)
+	require.NoError(t, err)
+
+	_, err = usageFile.ForecastUsageDataMap(0)
+	require.NoError(t, err)
+
+	_, err = usageFile.ForecastUsageDataMap(1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Error evaluating usage for forecast month 2")
+}
+
+func TestUsageGrowthInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		usage   string
+		wantErr string
+	}{
+		{
+			name: "not a number",
+			usage: `
+version: 0.1
+usage_growth:
+  resource_usage:
+    aws_lambda_function.api:
+      monthly_requests: fast
+`,
+			wantErr: "growth rate of aws_lambda_function.api.monthly_requests must be a number or a percentage, e.g. 5%",
+		},
+		{
+			name: "shrinks to nothing",
+			usage: `
+version: 0.1
+usage_growth:
+  resource_type_default_usage:
+    aws_lambda_function:
+      monthly_requests: -100%
+`,
+			wantErr: "growth rate of aws_lambda_function.monthly_requests must be greater than -100%",
+		},
+		{
+			name: "unknown key",
+			usage: `
+version: 0.1
+usage_growth:
+  resources:
+    aws_lambda_function.api:
+      monthly_requests: 5%
+`,
+			wantErr: "Error parsing usage_growth: unknown key resources on line 4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := usage.LoadUsageFileFromString(tt.usage)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestUsageGrowthWriteToPath(t *testing.T) {
+	usageFile, err := usage.LoadUsageFileFromString(usageGrowthFile)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "infracost-usage.yml")
+	require.NoError(t, usageFile.WriteToPath(path))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "usage_growth:\n  variables:\n    daily_users: 10%\n")
+}
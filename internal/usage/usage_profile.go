@@ -0,0 +1,249 @@
+package usage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// UsageProfile represents a named set of usage values in the usage file,
+// e.g. low or peak usage. The values of a usage profile override the values
+// with the same key of the same resource or resource type in the
+// resource_type_default_usage and resource_usage sections.
+type UsageProfile struct { // nolint:revive
+	Name               string
+	Variables          *ResourceUsage
+	ResourceTypeUsages []*ResourceUsage
+	ResourceUsages     []*ResourceUsage
+}
+
+// UsageProfileNames returns the names of the usage profiles in the order they
+// are defined in the usage file. The expected usage profile is always
+// included, and is first if the usage file doesn't define it.
+func (u *UsageFile) UsageProfileNames() []string {
+	if len(u.UsageProfiles) == 0 {
+		return nil
+	}
+
+	var names []string
+	if u.usageProfile(schema.ExpectedUsageProfile) == nil {
+		names = append(names, schema.ExpectedUsageProfile)
+	}
+
+	for _, p := range u.UsageProfiles {
+		names = append(names, p.Name)
+	}
+
+	return names
+}
+
+// ExpectedUsageDataMap returns the usage data used for the cost estimate. This
+// is the usage of the expected usage profile if the usage file defines it.
+func (u *UsageFile) ExpectedUsageDataMap() schema.UsageMap {
+	if u.usageProfile(schema.ExpectedUsageProfile) == nil {
+		return u.ToUsageDataMap()
+	}
+
+	return u.ProfileUsageDataMap(schema.ExpectedUsageProfile)
+}
+
+// ProfileUsageDataMap returns the usage data of the usage profile with the
+// given name, with any values the profile doesn't define taken from the rest of
+// the usage file.
+func (u *UsageFile) ProfileUsageDataMap(name string) schema.UsageMap {
+	p := u.usageProfile(name)
+	if p == nil {
+		return u.ToUsageDataMap()
+	}
+
+	// Errors are reported when the usage file is loaded
+	usages, _ := u.profileUsages(p)
+
+	return usageDataMap(usages.ResourceTypeUsages, usages.ResourceUsages)
+}
+
+// profileUsages returns the variables and usage of the usage profile merged
+// with the rest of the usage file, with the formulas evaluated using the
+// values of the profile.
+func (u *UsageFile) profileUsages(p *UsageProfile) (*UsageProfile, error) {
+	usages := &UsageProfile{
+		Name:               p.Name,
+		ResourceTypeUsages: mergeProfileResourceUsages(p.ResourceTypeUsages, u.ResourceTypeUsages),
+		ResourceUsages:     mergeProfileResourceUsages(expandProfileWildcards(p.ResourceUsages, u.ResourceUsages), u.ResourceUsages),
+	}
+
+	variables := mergeProfileResourceUsages(nonNilResourceUsages(p.Variables), nonNilResourceUsages(u.Variables))
+	if len(variables) > 0 {
+		usages.Variables = variables[0]
+	}
+
+	err := evaluateUsageExpressions(usages.Variables, usages.ResourceTypeUsages, usages.ResourceUsages)
+
+	return usages, err
+}
+
+func (u *UsageFile) usageProfile(name string) *UsageProfile {
+	for _, p := range u.UsageProfiles {
+		if p.Name == name {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// expandProfileWildcards returns a copy of the profile resource usages with
+// the wildcard resource usages merged into the indexed resource usages of the
+// same resource, the same as the resource usages of the usage file. Indexed
+// resource usages are added for the indexed resources of the base resource
+// usages so the wildcard values of the profile override their values.
+func expandProfileWildcards(profile []*ResourceUsage, base []*ResourceUsage) []*ResourceUsage {
+	expanded := make([]*ResourceUsage, 0, len(profile))
+	wildcards := make(map[string]bool)
+	for _, p := range profile {
+		r := &ResourceUsage{Name: p.Name}
+		r.MergeResourceUsage(p)
+		expanded = append(expanded, r)
+
+		if strings.HasSuffix(p.Name, "[*]") {
+			wildcards[wildcardPrefix(p.Name)] = true
+		}
+	}
+
+	profileMap := resourceUsagesMap(profile)
+	for _, b := range base {
+		if strings.HasSuffix(b.Name, "[*]") || !strings.HasSuffix(b.Name, "]") {
+			continue
+		}
+
+		if _, ok := profileMap[b.Name]; !ok && wildcards[wildcardPrefix(b.Name)] {
+			expanded = append(expanded, &ResourceUsage{Name: b.Name})
+		}
+	}
+
+	MergeWildcardResourceUsages(expanded)
+
+	return expanded
+}
+
+// mergeProfileResourceUsages returns new resource usages with the values of
+// the profile resource usages and any values they don't define from the base
+// resource usages. Neither of the inputs are changed.
+func mergeProfileResourceUsages(profile []*ResourceUsage, base []*ResourceUsage) []*ResourceUsage {
+	profileMap := resourceUsagesMap(profile)
+	merged := make([]*ResourceUsage, 0, len(base)+len(profile))
+
+	for _, b := range base {
+		r := &ResourceUsage{Name: b.Name}
+		r.MergeResourceUsage(profileMap[b.Name])
+		r.MergeResourceUsage(b)
+		merged = append(merged, r)
+	}
+
+	baseMap := resourceUsagesMap(base)
+	for _, p := range profile {
+		if _, ok := baseMap[p.Name]; ok {
+			continue
+		}
+
+		r := &ResourceUsage{Name: p.Name}
+		r.MergeResourceUsage(p)
+		merged = append(merged, r)
+	}
+
+	return merged
+}
+
+func (u *UsageFile) parseUsageProfiles() error {
+	u.UsageProfiles = nil
+
+	if u.RawUsageProfiles.Kind == 0 {
+		return nil
+	}
+
+	if u.RawUsageProfiles.Kind != yamlv3.MappingNode {
+		return fmt.Errorf("usage_profiles must be a map of usage profile names to usage on line %d", u.RawUsageProfiles.Line)
+	}
+
+	seen := make(map[string]bool)
+
+	for i := 0; i+1 < len(u.RawUsageProfiles.Content); i += 2 {
+		keyNode := u.RawUsageProfiles.Content[i]
+		valNode := u.RawUsageProfiles.Content[i+1]
+
+		if keyNode.Value == "" {
+			return fmt.Errorf("usage profile on line %d must have a name", keyNode.Line)
+		}
+		if seen[keyNode.Value] {
+			return fmt.Errorf("usage profile %s on line %d is defined more than once", keyNode.Value, keyNode.Line)
+		}
+		seen[keyNode.Value] = true
+
+		profile, err := usageProfileFromYAML(keyNode.Value, valNode)
+		if err != nil {
+			return errors.Wrapf(err, "Error parsing usage profile %s", keyNode.Value)
+		}
+
+		u.UsageProfiles = append(u.UsageProfiles, profile)
+	}
+
+	return nil
+}
+
+func usageProfileFromYAML(name string, raw *yamlv3.Node) (*UsageProfile, error) {
+	profile := &UsageProfile{Name: name}
+
+	if raw.Kind == yamlv3.ScalarNode && raw.Tag == "!!null" {
+		return profile, nil
+	}
+
+	if raw.Kind != yamlv3.MappingNode {
+		return nil, fmt.Errorf("expected a map on line %d", raw.Line)
+	}
+
+	for i := 0; i+1 < len(raw.Content); i += 2 {
+		keyNode := raw.Content[i]
+		valNode := raw.Content[i+1]
+
+		if keyNode.Value == "variables" {
+			variables, err := variablesFromYAML(*valNode)
+			if err != nil {
+				return nil, err
+			}
+
+			profile.Variables = variables
+			continue
+		}
+
+		usages, err := ResourceUsagesFromYAML(*valNode)
+		if err != nil {
+			return nil, err
+		}
+
+		switch keyNode.Value {
+		case "resource_type_default_usage":
+			profile.ResourceTypeUsages = usages
+		case "resource_usage":
+			profile.ResourceUsages = usages
+		default:
+			return nil, fmt.Errorf("unknown key %s on line %d, expected variables, resource_type_default_usage or resource_usage", keyNode.Value, keyNode.Line)
+		}
+	}
+
+	return profile, nil
+}
+
+func nonNilResourceUsages(resourceUsages ...*ResourceUsage) []*ResourceUsage {
+	var l []*ResourceUsage
+	for _, r := range resourceUsages {
+		if r != nil {
+			l = append(l, r)
+		}
+	}
+
+	return l
+}
@@ -0,0 +1,174 @@
+package usage_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage"
+)
+
+const usageProfilesFile = `
+version: 0.1
+resource_type_default_usage:
+  aws_lambda_function:
+    monthly_requests: 1000
+    request_duration_ms: 100
+resource_usage:
+  aws_lambda_function.api:
+    monthly_requests: 5000
+  aws_s3_bucket.logs:
+    standard:
+      storage_gb: 10
+      monthly_tier_1_requests: 100
+usage_profiles:
+  low:
+    resource_type_default_usage:
+      aws_lambda_function:
+        monthly_requests: 100
+  peak:
+    resource_usage:
+      aws_lambda_function.api:
+        monthly_requests: 50000
+      aws_s3_bucket.logs:
+        standard:
+          storage_gb: 100
+      aws_sqs_queue.jobs:
+        monthly_requests: 2000000
+`
+
+func TestUsageProfiles(t *testing.T) {
+	usageFile, err := usage.LoadUsageFileFromString(usageProfilesFile)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"expected", "low", "peak"}, usageFile.UsageProfileNames())
+
+	low := usageFile.ProfileUsageDataMap("low")
+	assert.Equal(t, int64(100), low.Get("aws_lambda_function.worker").Get("monthly_requests").Int())
+	assert.Equal(t, int64(100), low.Get("aws_lambda_function.worker").Get("request_duration_ms").Int())
+	// Resource usage overrides the resource type defaults of the profile.
+	assert.Equal(t, int64(5000), low.Get("aws_lambda_function.api").Get("monthly_requests").Int())
+
+	peak := usageFile.ProfileUsageDataMap("peak")
+	assert.Equal(t, int64(50000), peak.Get("aws_lambda_function.api").Get("monthly_requests").Int())
+	assert.Equal(t, int64(100), peak.Get("aws_lambda_function.api").Get("request_duration_ms").Int())
+	assert.Equal(t, int64(1000), peak.Get("aws_lambda_function.worker").Get("monthly_requests").Int())
+	assert.Equal(t, int64(2000000), peak.Get("aws_sqs_queue.jobs").Get("monthly_requests").Int())
+	assert.Equal(t, float64(100), peak.Get("aws_s3_bucket.logs").Get("standard").Get("storage_gb").Float())
+	assert.Equal(t, float64(100), peak.Get("aws_s3_bucket.logs").Get("standard").Get("monthly_tier_1_requests").Float())
+
+	// The usage of the usage file is not changed by the profiles.
+	expected := usageFile.ExpectedUsageDataMap()
+	assert.Equal(t, int64(5000), expected.Get("aws_lambda_function.api").Get("monthly_requests").Int())
+	assert.Equal(t, float64(10), expected.Get("aws_s3_bucket.logs").Get("standard").Get("storage_gb").Float())
+	assert.Nil(t, expected.Get("aws_sqs_queue.jobs"))
+}
+
+func TestUsageProfilesExpectedProfile(t *testing.T) {
+	usageFile, err := usage.LoadUsageFileFromString(`
+version: 0.1
+resource_usage:
+  aws_lambda_function.api:
+    monthly_requests: 5000
+usage_profiles:
+  low:
+    resource_usage:
+      aws_lambda_function.api:
+        monthly_requests: 500
+  expected:
+    resource_usage:
+      aws_lambda_function.api:
+        monthly_requests: 6000
+`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"low", schema.ExpectedUsageProfile}, usageFile.UsageProfileNames())
+	assert.Equal(t, int64(6000), usageFile.ExpectedUsageDataMap().Get("aws_lambda_function.api").Get("monthly_requests").Int())
+	assert.Equal(t, int64(5000), usageFile.ToUsageDataMap().Get("aws_lambda_function.api").Get("monthly_requests").Int())
+}
+
+func TestUsageProfilesInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name:    "not a map",
+			yaml:    "version: 0.1\nusage_profiles: [low]",
+			wantErr: "usage_profiles must be a map of usage profile names to usage on line 2",
+		},
+		{
+			name:    "unknown key",
+			yaml:    "version: 0.1\nusage_profiles:\n  low:\n    resource_usages: {}",
+			wantErr: "Error parsing usage profile low: unknown key resource_usages on line 4, expected variables, resource_type_default_usage or resource_usage",
+		},
+		{
+			name:    "duplicate profile",
+			yaml:    "version: 0.1\nusage_profiles:\n  low: {}\n  low: {}",
+			wantErr: "usage profile low on line 4 is defined more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := usage.LoadUsageFileFromString(tt.yaml)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestUsageProfilesWriteToPath(t *testing.T) {
+	usageFile, err := usage.LoadUsageFileFromString(usageProfilesFile)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "infracost-usage.yml")
+	require.NoError(t, usageFile.WriteToPath(path))
+
+	written, err := usage.LoadUsageFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, usageFile.UsageProfileNames(), written.UsageProfileNames())
+	assert.Equal(t, int64(50000), written.ProfileUsageDataMap("peak").Get("aws_lambda_function.api").Get("monthly_requests").Int())
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "usage_profiles:\n  low:\n")
+}
+
+func TestUsageProfilesWildcard(t *testing.T) {
+	usageFile, err := usage.LoadUsageFileFromString(`
+version: 0.1
+resource_usage:
+  aws_lambda_function.fn[*]:
+    monthly_requests: 1000
+    request_duration_ms: 100
+  aws_lambda_function.fn["a"]:
+    request_duration_ms: 200
+usage_profiles:
+  peak:
+    resource_usage:
+      aws_lambda_function.fn[*]:
+        monthly_requests: 9000
+`)
+	require.NoError(t, err)
+
+	// The wildcard usage of the usage file is merged into the indexed usage
+	// before the resources are loaded.
+	usage.MergeWildcardResourceUsages(usageFile.ResourceUsages)
+
+	peak := usageFile.ProfileUsageDataMap("peak")
+	assert.Equal(t, int64(9000), peak.Get(`aws_lambda_function.fn["a"]`).Get("monthly_requests").Int())
+	assert.Equal(t, int64(200), peak.Get(`aws_lambda_function.fn["a"]`).Get("request_duration_ms").Int())
+	assert.Equal(t, int64(9000), peak.Get(`aws_lambda_function.fn["b"]`).Get("monthly_requests").Int())
+	assert.Equal(t, int64(100), peak.Get(`aws_lambda_function.fn["b"]`).Get("request_duration_ms").Int())
+
+	expected := usageFile.ExpectedUsageDataMap()
+	assert.Equal(t, int64(1000), expected.Get(`aws_lambda_function.fn["a"]`).Get("monthly_requests").Int())
+	assert.Equal(t, int64(200), expected.Get(`aws_lambda_function.fn["a"]`).Get("request_duration_ms").Int())
+}
@@ -49,7 +49,7 @@ var (
 	versionRegxp     = regexp.MustCompile(`^v\d/`)
 
 	allowedProviders = map[string]struct{}{
-		"github": {}, "gitlab": {}, "azure_repos": {}, "bitbucket": {},
+		"github": {}, "gitlab": {}, "azure_repos": {}, "bitbucket": {}, "gitea": {}, "forgejo": {},
 	}
 )
 
@@ -299,12 +299,32 @@ func (f *metadataFetcher) Get(path string, gitDiffTarget *string) (m Metadata, e
 		return StubMetadata, nil
 	}
 
-	v, ok := lookupEnv("GITHUB_ACTIONS")
+	// Gitea and Forgejo Actions also set the GITHUB_ env variables so these need
+	// to be checked before GitHub Actions.
+	v, ok := lookupEnv("FORGEJO_ACTIONS")
+	if ok && v != "" {
+		logging.Logger.Debug().Msg("fetching Forgejo Actions VCS metadata")
+		return f.getGiteaActionsMetadata(path, gitDiffTarget, "forgejo")
+	}
+
+	v, ok = lookupEnv("GITEA_ACTIONS")
+	if ok && v != "" {
+		logging.Logger.Debug().Msg("fetching Gitea Actions VCS metadata")
+		return f.getGiteaActionsMetadata(path, gitDiffTarget, "gitea")
+	}
+
+	v, ok = lookupEnv("GITHUB_ACTIONS")
 	if ok && v != "" {
 		logging.Logger.Debug().Msg("fetching GitHub action VCS metadata")
 		return f.getGithubMetadata(path, gitDiffTarget)
 	}
 
+	v, ok = lookupEnv("CI")
+	if ok && v == "woodpecker" {
+		logging.Logger.Debug().Msg("fetching Woodpecker CI VCS metadata")
+		return f.getWoodpeckerMetadata(path, gitDiffTarget)
+	}
+
 	_, ok = lookupEnv("GITLAB_CI")
 	if ok {
 		logging.Logger.Debug().Msg("fetching Gitlab CI VCS metadata")
@@ -505,6 +525,103 @@ func (f *metadataFetcher) getGithubMetadata(path string, gitDiffTarget *string)
 	return m, nil
 }
 
+// getGiteaActionsMetadata returns the metadata for Gitea and Forgejo Actions.
+// These are compatible with GitHub Actions so the event file has the same
+// format as the GitHub one, apart from the pull request URL. Unlike GitHub
+// Actions, the checkout action checks out the head commit of the pull request
+// so there's no need to fetch the author commit.
+func (f *metadataFetcher) getGiteaActionsMetadata(path string, gitDiffTarget *string, provider string) (Metadata, error) {
+	event, err := os.ReadFile(getEnv("GITHUB_EVENT_PATH"))
+	if err != nil {
+		return Metadata{}, fmt.Errorf("could not read the %s Actions event file %w", provider, err)
+	}
+
+	m, err := f.getLocalGitMetadata(path, gitDiffTarget)
+	if err != nil {
+		return m, fmt.Errorf("%s Actions metadata error, could not fetch initial metadata from local git %w", provider, err)
+	}
+
+	headRef := gjson.GetBytes(event, "pull_request.head.ref").String()
+	if m.Branch.Name == "HEAD" && headRef != "" {
+		m.Branch.Name = headRef
+	}
+
+	m.Remote = urlStringToRemote(gjson.GetBytes(event, "repository.html_url").String())
+	m.Pipeline = &Pipeline{ID: getEnv("GITHUB_RUN_ID")}
+
+	l := gjson.GetBytes(event, "pull_request.labels.#.name").Array()
+	labels := make([]string, 0, len(l))
+	for _, v := range l {
+		labels = append(labels, v.String())
+	}
+
+	m.PullRequest = &PullRequest{
+		VCSProvider:  provider,
+		ID:           gjson.GetBytes(event, "pull_request.number").String(),
+		Title:        gjson.GetBytes(event, "pull_request.title").String(),
+		Author:       gjson.GetBytes(event, "pull_request.user.login").String(),
+		Labels:       labels,
+		SourceBranch: headRef,
+		BaseBranch:   gjson.GetBytes(event, "pull_request.base.ref").String(),
+		URL:          gjson.GetBytes(event, "pull_request.html_url").String(),
+	}
+
+	return m, nil
+}
+
+// getWoodpeckerMetadata returns the metadata for Woodpecker CI, which is
+// commonly used with Gitea and Forgejo. The variables are documented at
+// https://woodpecker-ci.org/docs/usage/environment.
+func (f *metadataFetcher) getWoodpeckerMetadata(path string, gitDiffTarget *string) (Metadata, error) {
+	m, err := f.getLocalGitMetadata(path, gitDiffTarget)
+	if err != nil {
+		return m, fmt.Errorf("Woodpecker CI metadata error, could not fetch initial metadata from local git %w", err)
+	}
+
+	if m.Branch.Name == "HEAD" {
+		m.Branch.Name = getEnv("CI_COMMIT_SOURCE_BRANCH")
+		if m.Branch.Name == "" {
+			m.Branch.Name = getEnv("CI_COMMIT_BRANCH")
+		}
+	}
+
+	repoURL := getEnv("CI_REPO_URL")
+	if repoURL == "" {
+		// CI_REPO_LINK was renamed to CI_REPO_URL in Woodpecker 2.0.
+		repoURL = getEnv("CI_REPO_LINK")
+	}
+
+	m.Remote = urlStringToRemote(repoURL)
+	m.Pipeline = &Pipeline{ID: getEnv("CI_PIPELINE_NUMBER")}
+
+	provider := strings.ToLower(getEnv("CI_FORGE_TYPE"))
+	if provider == "" {
+		provider = vcsProviderFromHost(m.Remote.Host)
+	}
+
+	prID := getEnv("CI_COMMIT_PULL_REQUEST")
+	prURL := ""
+	if prID != "" {
+		prURL = getEnv("CI_PIPELINE_FORGE_URL")
+	}
+
+	m.PullRequest = &PullRequest{
+		VCSProvider:  provider,
+		ID:           prID,
+		Author:       getEnv("CI_COMMIT_AUTHOR"),
+		Labels:       getEnvList("CI_COMMIT_PULL_REQUEST_LABELS"),
+		SourceBranch: getEnv("CI_COMMIT_SOURCE_BRANCH"),
+		BaseBranch:   getEnv("CI_COMMIT_TARGET_BRANCH"),
+		URL:          prURL,
+
+		// Woodpecker doesn't provide the pull request title and we're unable to
+		// fetch it without an API token for the forge.
+		Title: "",
+	}
+
+	return m, nil
+}
+
 func (f *metadataFetcher) getLocalGitMetadata(path string, gitDiffTarget *string) (Metadata, error) {
 	r, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
 	if err != nil {
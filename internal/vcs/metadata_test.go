@@ -230,6 +230,129 @@ func Test_metadataFetcher_GetGitlabMetadata(t *testing.T) {
 	}, actual)
 }
 
+func Test_metadataFetcher_GetGiteaActionsMetadata(t *testing.T) {
+	tmp := t.TempDir()
+	_, lastCommit := createLocalRepoWithCommits(t, tmp)
+
+	eventPath := filepath.Join(t.TempDir(), "event.json")
+	err := os.WriteFile(eventPath, []byte(`{
+		"pull_request": {
+			"number": 7,
+			"title": "pr title",
+			"html_url": "https://gitea.example.com/org/repo/pulls/7",
+			"user": {"login": "gitea-user"},
+			"labels": [{"name": "infra"}, {"name": "cost"}],
+			"head": {"ref": "feature"},
+			"base": {"ref": "main"}
+		},
+		"repository": {"html_url": "https://gitea.example.com/org/repo"}
+	}`), 0600)
+	require.NoError(t, err)
+
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITEA_ACTIONS", "true")
+	t.Setenv("GITHUB_EVENT_PATH", eventPath)
+	t.Setenv("GITHUB_RUN_ID", "42")
+
+	test := false
+	m := metadataFetcher{
+		mu:     &sync.KeyMutex{},
+		client: &http.Client{Timeout: time.Second * 5},
+		test:   &test,
+	}
+
+	actual, err := m.Get(tmp, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, Metadata{
+		Remote: Remote{
+			Host: "gitea.example.com",
+			Name: "org/repo",
+			URL:  "https://gitea.example.com/org/repo",
+		},
+		Branch: Branch{
+			Name: "master",
+		},
+		Commit: Commit{
+			SHA:         lastCommit.Hash.String(),
+			AuthorName:  lastCommit.Author.Name,
+			AuthorEmail: lastCommit.Author.Email,
+			Time:        lastCommit.Author.When,
+			Message:     lastCommit.Message,
+			ChangedObjects: []string{
+				filepath.Join(tmp, "added-file"),
+			},
+		},
+		PullRequest: &PullRequest{
+			ID:           "7",
+			Title:        "pr title",
+			Author:       "gitea-user",
+			Labels:       []string{"infra", "cost"},
+			VCSProvider:  "gitea",
+			SourceBranch: "feature",
+			BaseBranch:   "main",
+			URL:          "https://gitea.example.com/org/repo/pulls/7",
+		},
+		Pipeline: &Pipeline{ID: "42"},
+	}, actual)
+}
+
+func Test_metadataFetcher_GetWoodpeckerMetadata(t *testing.T) {
+	tmp := t.TempDir()
+	_, lastCommit := createLocalRepoWithCommits(t, tmp)
+
+	t.Setenv("GITHUB_ACTIONS", "")
+	t.Setenv("CI", "woodpecker")
+	t.Setenv("CI_FORGE_TYPE", "forgejo")
+	t.Setenv("CI_REPO_URL", "https://codeberg.org/org/repo")
+	t.Setenv("CI_PIPELINE_NUMBER", "12")
+	t.Setenv("CI_COMMIT_PULL_REQUEST", "3")
+	t.Setenv("CI_COMMIT_PULL_REQUEST_LABELS", "infra,cost")
+	t.Setenv("CI_COMMIT_AUTHOR", "woodpecker-user")
+	t.Setenv("CI_COMMIT_SOURCE_BRANCH", "feature")
+	t.Setenv("CI_COMMIT_TARGET_BRANCH", "main")
+	t.Setenv("CI_PIPELINE_FORGE_URL", "https://codeberg.org/org/repo/pulls/3")
+
+	test := false
+	m := metadataFetcher{
+		mu:     &sync.KeyMutex{},
+		client: &http.Client{Timeout: time.Second * 5},
+		test:   &test,
+	}
+
+	actual, err := m.Get(tmp, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, Metadata{
+		Remote: Remote{
+			Host: "codeberg.org",
+			Name: "org/repo",
+			URL:  "https://codeberg.org/org/repo",
+		},
+		Branch: Branch{
+			Name: "master",
+		},
+		Commit: Commit{
+			SHA:         lastCommit.Hash.String(),
+			AuthorName:  lastCommit.Author.Name,
+			AuthorEmail: lastCommit.Author.Email,
+			Time:        lastCommit.Author.When,
+			Message:     lastCommit.Message,
+			ChangedObjects: []string{
+				filepath.Join(tmp, "added-file"),
+			},
+		},
+		PullRequest: &PullRequest{
+			ID:           "3",
+			Author:       "woodpecker-user",
+			Labels:       []string{"infra", "cost"},
+			VCSProvider:  "forgejo",
+			SourceBranch: "feature",
+			BaseBranch:   "main",
+			URL:          "https://codeberg.org/org/repo/pulls/3",
+		},
+		Pipeline: &Pipeline{ID: "12"},
+	}, actual)
+}
+
 func Test_metadataFetcher_GetLocalMetadata(t *testing.T) {
 	tmp := t.TempDir()
 	_, lastCommit := createLocalRepoWithCommits(t, tmp)